@@ -0,0 +1,86 @@
+package addresslookuptableprog
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// LookupTableMetaSize is the size of the header, addresses are stored after it
+const LookupTableMetaSize = 56
+
+// LookupTableMaxAddresses is the max number of addresses a lookup table can hold
+const LookupTableMaxAddresses = 256
+
+type ProgramStateType uint32
+
+const (
+	ProgramStateTypeUninitialized ProgramStateType = iota
+	ProgramStateTypeLookupTable
+)
+
+type AddressLookupTable struct {
+	// DeactivationSlot is math.MaxUint64 until the table is deactivated
+	DeactivationSlot           uint64
+	LastExtendedSlot           uint64
+	LastExtendedSlotStartIndex uint8
+	// Authority is nil once the table is frozen
+	Authority *common.PublicKey
+	Addresses []common.PublicKey
+}
+
+// lookupTableMeta is the header of a lookup table, it is padded to LookupTableMetaSize
+type lookupTableMeta struct {
+	Type                       ProgramStateType
+	DeactivationSlot           uint64
+	LastExtendedSlot           uint64
+	LastExtendedSlotStartIndex uint8
+	Authority                  *common.PublicKey
+}
+
+func AddressLookupTableDeserialize(data []byte) (AddressLookupTable, error) {
+	if len(data) < LookupTableMetaSize {
+		return AddressLookupTable{}, fmt.Errorf("address lookup table data size is not enough")
+	}
+	var meta lookupTableMeta
+	if err := borsh.Unmarshal(data[:LookupTableMetaSize], &meta); err != nil {
+		return AddressLookupTable{}, err
+	}
+	if meta.Type != ProgramStateTypeLookupTable {
+		return AddressLookupTable{}, errors.New("address lookup table is uninitialized")
+	}
+	if (len(data)-LookupTableMetaSize)%32 != 0 {
+		return AddressLookupTable{}, errors.New("address lookup table addresses data size is invalid")
+	}
+
+	var addresses struct {
+		Addresses []common.PublicKey `borsh:"rest"`
+	}
+	if err := borsh.Unmarshal(data[LookupTableMetaSize:], &addresses); err != nil {
+		return AddressLookupTable{}, err
+	}
+
+	return AddressLookupTable{
+		DeactivationSlot:           meta.DeactivationSlot,
+		LastExtendedSlot:           meta.LastExtendedSlot,
+		LastExtendedSlotStartIndex: meta.LastExtendedSlotStartIndex,
+		Authority:                  meta.Authority,
+		Addresses:                  addresses.Addresses,
+	}, nil
+}
+
+func (t AddressLookupTable) IsDeactivated() bool {
+	return t.DeactivationSlot != math.MaxUint64
+}
+
+// LookupTableAccount pairs the table with its address so that messages can resolve their accounts with it
+func (t AddressLookupTable) LookupTableAccount(key common.PublicKey) types.AddressLookupTableAccount {
+	return types.AddressLookupTableAccount{
+		Key:       key,
+		Addresses: t.Addresses,
+	}
+}
@@ -0,0 +1,73 @@
+package addresslookuptableprog
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+func TestAddressLookupTableDeserialize(t *testing.T) {
+	authority := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	address := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+
+	active := []byte{1, 0, 0, 0, 255, 255, 255, 255, 255, 255, 255, 255, 10, 0, 0, 0, 0, 0, 0, 0, 1, 1}
+	active = append(active, authority.Bytes()...)
+	active = append(active, 0, 0)
+	active = append(active, common.SystemProgramID.Bytes()...)
+	active = append(active, address.Bytes()...)
+
+	frozen := make([]byte, LookupTableMetaSize)
+	copy(frozen, []byte{1, 0, 0, 0, 5, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+	frozen = append(frozen, address.Bytes()...)
+
+	type args struct {
+		data []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    AddressLookupTable
+		wantErr bool
+	}{
+		{
+			args: args{data: active},
+			want: AddressLookupTable{
+				DeactivationSlot:           math.MaxUint64,
+				LastExtendedSlot:           10,
+				LastExtendedSlotStartIndex: 1,
+				Authority:                  &authority,
+				Addresses:                  []common.PublicKey{common.SystemProgramID, address},
+			},
+		},
+		{
+			args: args{data: frozen},
+			want: AddressLookupTable{
+				DeactivationSlot: 5,
+				LastExtendedSlot: 3,
+				Addresses:        []common.PublicKey{address},
+			},
+		},
+		{
+			args:    args{data: make([]byte, LookupTableMetaSize)},
+			wantErr: true,
+		},
+		{
+			args:    args{data: active[:len(active)-1]},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AddressLookupTableDeserialize(tt.args.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AddressLookupTableDeserialize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AddressLookupTableDeserialize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
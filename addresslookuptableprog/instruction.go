@@ -0,0 +1,154 @@
+package addresslookuptableprog
+
+import (
+	"encoding/binary"
+
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+type Instruction uint32
+
+const (
+	InstructionCreateLookupTable Instruction = iota
+	InstructionFreezeLookupTable
+	InstructionExtendLookupTable
+	InstructionDeactivateLookupTable
+	InstructionCloseLookupTable
+)
+
+type CreateLookupTableInstruction struct {
+	Instruction Instruction
+	RecentSlot  uint64
+	BumpSeed    uint8
+}
+type FreezeLookupTableInstruction struct {
+	Instruction Instruction
+}
+type ExtendLookupTableInstruction struct {
+	Instruction Instruction
+	Addresses   []common.PublicKey `borsh:"u64len"`
+}
+type DeactivateLookupTableInstruction struct {
+	Instruction Instruction
+}
+type CloseLookupTableInstruction struct {
+	Instruction Instruction
+}
+
+// DeriveLookupTableAddress returns the lookup table address created by authority with the recent slot
+func DeriveLookupTableAddress(authority common.PublicKey, recentSlot uint64) (common.PublicKey, uint8, error) {
+	slot := make([]byte, 8)
+	binary.LittleEndian.PutUint64(slot, recentSlot)
+	pubkey, bumpSeed, err := common.FindProgramAddress([][]byte{authority.Bytes(), slot}, common.AddressLookupTableProgramID)
+	return pubkey, uint8(bumpSeed), err
+}
+
+// CreateLookupTable creates a lookup table at the address derived by DeriveLookupTableAddress
+func CreateLookupTable(lookupTable, authority, payer common.PublicKey, recentSlot uint64, bumpSeed uint8) types.Instruction {
+	data, err := borsh.Marshal(CreateLookupTableInstruction{
+		Instruction: InstructionCreateLookupTable,
+		RecentSlot:  recentSlot,
+		BumpSeed:    bumpSeed,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.AddressLookupTableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: lookupTable, IsSigner: false, IsWritable: true},
+			{PubKey: authority, IsSigner: true, IsWritable: false},
+			{PubKey: payer, IsSigner: true, IsWritable: true},
+			{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+func FreezeLookupTable(lookupTable, authority common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(FreezeLookupTableInstruction{
+		Instruction: InstructionFreezeLookupTable,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.AddressLookupTableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: lookupTable, IsSigner: false, IsWritable: true},
+			{PubKey: authority, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+// ExtendLookupTable appends addresses to the lookup table, payer funds the extra rent
+// and can be empty if the table already holds enough lamports
+func ExtendLookupTable(lookupTable, authority, payer common.PublicKey, addresses []common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(ExtendLookupTableInstruction{
+		Instruction: InstructionExtendLookupTable,
+		Addresses:   addresses,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := []types.AccountMeta{
+		{PubKey: lookupTable, IsSigner: false, IsWritable: true},
+		{PubKey: authority, IsSigner: true, IsWritable: false},
+	}
+	if payer != (common.PublicKey{}) {
+		accounts = append(accounts,
+			types.AccountMeta{PubKey: payer, IsSigner: true, IsWritable: true},
+			types.AccountMeta{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+		)
+	}
+
+	return types.Instruction{
+		ProgramID: common.AddressLookupTableProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+func DeactivateLookupTable(lookupTable, authority common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(DeactivateLookupTableInstruction{
+		Instruction: InstructionDeactivateLookupTable,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.AddressLookupTableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: lookupTable, IsSigner: false, IsWritable: true},
+			{PubKey: authority, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+// CloseLookupTable closes a deactivated lookup table and sends its lamports to recipient
+func CloseLookupTable(lookupTable, authority, recipient common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(CloseLookupTableInstruction{
+		Instruction: InstructionCloseLookupTable,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.AddressLookupTableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: lookupTable, IsSigner: false, IsWritable: true},
+			{PubKey: authority, IsSigner: true, IsWritable: false},
+			{PubKey: recipient, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
@@ -0,0 +1,106 @@
+package addresslookuptableprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func TestCreateLookupTable(t *testing.T) {
+	type args struct {
+		lookupTable common.PublicKey
+		authority   common.PublicKey
+		payer       common.PublicKey
+		recentSlot  uint64
+		bumpSeed    uint8
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				lookupTable: common.PublicKeyFromString("9FfgNAyyvGPMCgjsDYgW9wcWaxzKw5ohtkMudySGU7Pd"),
+				authority:   common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				payer:       common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				recentSlot:  258,
+				bumpSeed:    254,
+			},
+			want: types.Instruction{
+				ProgramID: common.AddressLookupTableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("9FfgNAyyvGPMCgjsDYgW9wcWaxzKw5ohtkMudySGU7Pd"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: true},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{0, 0, 0, 0, 2, 1, 0, 0, 0, 0, 0, 0, 254},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CreateLookupTable(tt.args.lookupTable, tt.args.authority, tt.args.payer, tt.args.recentSlot, tt.args.bumpSeed); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateLookupTable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtendLookupTable(t *testing.T) {
+	type args struct {
+		lookupTable common.PublicKey
+		authority   common.PublicKey
+		payer       common.PublicKey
+		addresses   []common.PublicKey
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				lookupTable: common.PublicKeyFromString("9FfgNAyyvGPMCgjsDYgW9wcWaxzKw5ohtkMudySGU7Pd"),
+				authority:   common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				payer:       common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				addresses:   []common.PublicKey{common.SystemProgramID},
+			},
+			want: types.Instruction{
+				ProgramID: common.AddressLookupTableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("9FfgNAyyvGPMCgjsDYgW9wcWaxzKw5ohtkMudySGU7Pd"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: true},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+		},
+		{
+			args: args{
+				lookupTable: common.PublicKeyFromString("9FfgNAyyvGPMCgjsDYgW9wcWaxzKw5ohtkMudySGU7Pd"),
+				authority:   common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				addresses:   []common.PublicKey{},
+			},
+			want: types.Instruction{
+				ProgramID: common.AddressLookupTableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("9FfgNAyyvGPMCgjsDYgW9wcWaxzKw5ohtkMudySGU7Pd"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtendLookupTable(tt.args.lookupTable, tt.args.authority, tt.args.payer, tt.args.addresses); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtendLookupTable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
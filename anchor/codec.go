@@ -0,0 +1,645 @@
+package anchor
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"unicode/utf8"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+// Values are go values of the IDL types, decoding returns:
+//
+//	bool, u8 ~ u64, i8 ~ i64    bool, uint8 ~ uint64, int8 ~ int64
+//	f32, f64                    float32, float64
+//	u128, i128                  *big.Int
+//	string, bytes, pubkey       string, []byte, common.PublicKey
+//	vec, array                  []interface{}
+//	option, coption             nil or the value
+//	struct                      map[string]interface{}, []interface{} for a tuple struct
+//	enum                        map[string]interface{} of the variant name and its fields, nil for a unit variant
+//
+// Encoding accepts these types and the types json.Unmarshal returns, any integer, float64 and json.Number
+// for the numbers, a base58 string for a pubkey, any slice or array for vec and array, and the variant name
+// for a unit variant.
+
+var errNotEnoughData = errors.New("not enough data")
+
+// codec encodes and decodes the values of the IDL types, defined types are looked up by name
+type codec struct {
+	types map[string]*IDLTypeDef
+}
+
+func newCodec(idl *IDL) codec {
+	c := codec{types: make(map[string]*IDLTypeDef, len(idl.Types)+len(idl.Accounts))}
+	for i := range idl.Accounts {
+		c.types[idl.Accounts[i].Name] = &idl.Accounts[i]
+	}
+	// types take precedence, the account of an Anchor 0.30 IDL is a copy of the type
+	for i := range idl.Types {
+		c.types[idl.Types[i].Name] = &idl.Types[i]
+	}
+	return c
+}
+
+func (c codec) encodeFields(buf []byte, fields []IDLField, values map[string]interface{}) ([]byte, error) {
+	var err error
+	for _, field := range fields {
+		v, ok := values[field.Name]
+		if !ok && field.Type.Kind != TypeOption && field.Type.Kind != TypeCOption {
+			return nil, fmt.Errorf("missing field %v", field.Name)
+		}
+		buf, err = c.encode(buf, field.Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("field %v: %v", field.Name, err)
+		}
+	}
+	return buf, nil
+}
+
+func (c codec) encode(buf []byte, t IDLType, v interface{}) ([]byte, error) {
+	switch t.Kind {
+	case TypeBool:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expect bool, got %T", v)
+		}
+		if b {
+			return append(buf, 1), nil
+		}
+		return append(buf, 0), nil
+	case TypeU8, TypeU16, TypeU32, TypeU64:
+		n, err := toBigInt(v)
+		if err != nil {
+			return nil, err
+		}
+		return putInt(buf, n, intSize(t.Kind), false)
+	case TypeI8, TypeI16, TypeI32, TypeI64:
+		n, err := toBigInt(v)
+		if err != nil {
+			return nil, err
+		}
+		return putInt(buf, n, intSize(t.Kind), true)
+	case TypeU128, TypeI128:
+		n, err := toBigInt(v)
+		if err != nil {
+			return nil, err
+		}
+		return putInt(buf, n, 16, t.Kind == TypeI128)
+	case TypeF32, TypeF64:
+		f, err := toFloat(v)
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind == TypeF32 {
+			return appendUint(buf, uint64(math.Float32bits(float32(f))), 4), nil
+		}
+		return appendUint(buf, math.Float64bits(f), 8), nil
+	case TypeString:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expect string, got %T", v)
+		}
+		buf = appendUint(buf, uint64(len(s)), 4)
+		return append(buf, s...), nil
+	case TypeBytes:
+		b, ok := v.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("expect []byte, got %T", v)
+		}
+		buf = appendUint(buf, uint64(len(b)), 4)
+		return append(buf, b...), nil
+	case TypePubkey:
+		pubkey, err := toPublicKey(v)
+		if err != nil {
+			return nil, err
+		}
+		return append(buf, pubkey.Bytes()...), nil
+	case TypeVec, TypeArray:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, fmt.Errorf("expect a slice, got %T", v)
+		}
+		if t.Kind == TypeVec {
+			buf = appendUint(buf, uint64(rv.Len()), 4)
+		} else if rv.Len() != t.Len {
+			return nil, fmt.Errorf("expect %d elements, got %d", t.Len, rv.Len())
+		}
+		var err error
+		for i := 0; i < rv.Len(); i++ {
+			buf, err = c.encode(buf, *t.Elem, rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case TypeOption:
+		if isNil(v) {
+			return append(buf, 0), nil
+		}
+		return c.encode(append(buf, 1), *t.Elem, v)
+	case TypeCOption:
+		if isNil(v) {
+			buf = appendUint(buf, 0, 4)
+			return c.encodeZero(buf, *t.Elem)
+		}
+		return c.encode(appendUint(buf, 1, 4), *t.Elem, v)
+	case TypeDefined:
+		def, ok := c.types[t.Defined]
+		if !ok {
+			return nil, fmt.Errorf("undefined type %v", t.Defined)
+		}
+		return c.encodeDefined(buf, def.Type, v)
+	}
+	return nil, fmt.Errorf("unsupported type %v", t.Kind)
+}
+
+func (c codec) encodeDefined(buf []byte, t IDLTypeDefTy, v interface{}) ([]byte, error) {
+	switch t.Kind {
+	case "struct":
+		return c.encodeStructFields(buf, t.Fields, v)
+	case "enum":
+		name, fields, err := variantOf(v)
+		if err != nil {
+			return nil, err
+		}
+		for i, variant := range t.Variants {
+			if variant.Name != name {
+				continue
+			}
+			buf = append(buf, uint8(i))
+			if variant.Fields.Len() == 0 {
+				return buf, nil
+			}
+			return c.encodeStructFields(buf, variant.Fields, fields)
+		}
+		return nil, fmt.Errorf("unknown variant %v", name)
+	case "alias":
+		if t.Value == nil {
+			return nil, errors.New("alias has no value")
+		}
+		return c.encode(buf, *t.Value, v)
+	}
+	return nil, fmt.Errorf("unsupported type kind %v", t.Kind)
+}
+
+func (c codec) encodeStructFields(buf []byte, fields IDLFields, v interface{}) ([]byte, error) {
+	if len(fields.Tuple) > 0 {
+		values, ok := v.([]interface{})
+		if !ok || len(values) != len(fields.Tuple) {
+			return nil, fmt.Errorf("expect %d values, got %v", len(fields.Tuple), v)
+		}
+		var err error
+		for i, t := range fields.Tuple {
+			buf, err = c.encode(buf, t, values[i])
+			if err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	}
+	values, ok := v.(map[string]interface{})
+	if !ok && len(fields.Named) > 0 {
+		return nil, fmt.Errorf("expect map[string]interface{}, got %T", v)
+	}
+	return c.encodeFields(buf, fields.Named, values)
+}
+
+// encodeZero writes the zero value, which is what a COption holds when it is none
+func (c codec) encodeZero(buf []byte, t IDLType) ([]byte, error) {
+	switch t.Kind {
+	case TypeBool, TypeU8, TypeI8:
+		return append(buf, 0), nil
+	case TypeU16, TypeI16, TypeU32, TypeI32, TypeF32, TypeU64, TypeI64, TypeF64, TypeU128, TypeI128:
+		return append(buf, make([]byte, intSize(t.Kind))...), nil
+	case TypeString, TypeBytes, TypeVec:
+		return appendUint(buf, 0, 4), nil
+	case TypePubkey:
+		return append(buf, make([]byte, 32)...), nil
+	case TypeOption:
+		return append(buf, 0), nil
+	case TypeCOption:
+		buf = appendUint(buf, 0, 4)
+		return c.encodeZero(buf, *t.Elem)
+	case TypeArray:
+		var err error
+		for i := 0; i < t.Len; i++ {
+			if buf, err = c.encodeZero(buf, *t.Elem); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case TypeDefined:
+		def, ok := c.types[t.Defined]
+		if !ok {
+			return nil, fmt.Errorf("undefined type %v", t.Defined)
+		}
+		switch def.Type.Kind {
+		case "struct":
+			var err error
+			for _, f := range def.Type.Fields.Named {
+				if buf, err = c.encodeZero(buf, f.Type); err != nil {
+					return nil, err
+				}
+			}
+			for _, f := range def.Type.Fields.Tuple {
+				if buf, err = c.encodeZero(buf, f); err != nil {
+					return nil, err
+				}
+			}
+			return buf, nil
+		case "alias":
+			if def.Type.Value != nil {
+				return c.encodeZero(buf, *def.Type.Value)
+			}
+		}
+	}
+	return nil, fmt.Errorf("type %v has no zero value", t)
+}
+
+type decoder struct {
+	codec
+	data []byte
+	pos  int
+}
+
+func (d *decoder) read(n int) ([]byte, error) {
+	if n < 0 || len(d.data)-d.pos < n {
+		return nil, errNotEnoughData
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *decoder) uint(size int) (uint64, error) {
+	b, err := d.read(size)
+	if err != nil {
+		return 0, err
+	}
+	var buf [8]byte
+	copy(buf[:], b)
+	return binary.LittleEndian.Uint64(buf[:]), nil
+}
+
+func (d *decoder) length() (int, error) {
+	n, err := d.uint(4)
+	if err != nil {
+		return 0, err
+	}
+	// every element takes a byte at least, don't trust the length to allocate
+	if n > uint64(len(d.data)-d.pos) {
+		return 0, errNotEnoughData
+	}
+	return int(n), nil
+}
+
+func (d *decoder) decodeFields(fields []IDLField) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		v, err := d.decode(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %v: %v", field.Name, err)
+		}
+		values[field.Name] = v
+	}
+	return values, nil
+}
+
+func (d *decoder) decode(t IDLType) (interface{}, error) {
+	switch t.Kind {
+	case TypeBool:
+		n, err := d.uint(1)
+		if err != nil {
+			return nil, err
+		}
+		if n > 1 {
+			return nil, fmt.Errorf("invalid bool %d", n)
+		}
+		return n == 1, nil
+	case TypeU8:
+		n, err := d.uint(1)
+		return uint8(n), err
+	case TypeU16:
+		n, err := d.uint(2)
+		return uint16(n), err
+	case TypeU32:
+		n, err := d.uint(4)
+		return uint32(n), err
+	case TypeU64:
+		n, err := d.uint(8)
+		return n, err
+	case TypeI8:
+		n, err := d.uint(1)
+		return int8(n), err
+	case TypeI16:
+		n, err := d.uint(2)
+		return int16(n), err
+	case TypeI32:
+		n, err := d.uint(4)
+		return int32(n), err
+	case TypeI64:
+		n, err := d.uint(8)
+		return int64(n), err
+	case TypeU128, TypeI128:
+		lo, err := d.uint(8)
+		if err != nil {
+			return nil, err
+		}
+		hi, err := d.uint(8)
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind == TypeI128 {
+			return borsh.Int128{Lo: lo, Hi: int64(hi)}.BigInt(), nil
+		}
+		return borsh.Uint128{Lo: lo, Hi: hi}.BigInt(), nil
+	case TypeF32:
+		n, err := d.uint(4)
+		return math.Float32frombits(uint32(n)), err
+	case TypeF64:
+		n, err := d.uint(8)
+		return math.Float64frombits(n), err
+	case TypeString, TypeBytes:
+		n, err := d.length()
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.read(n)
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind == TypeBytes {
+			return append([]byte{}, b...), nil
+		}
+		if !utf8.Valid(b) {
+			return nil, errors.New("invalid utf-8 string")
+		}
+		return string(b), nil
+	case TypePubkey:
+		b, err := d.read(32)
+		if err != nil {
+			return nil, err
+		}
+		return common.PublicKeyFromBytes(b), nil
+	case TypeVec, TypeArray:
+		n := t.Len
+		if t.Kind == TypeVec {
+			var err error
+			if n, err = d.length(); err != nil {
+				return nil, err
+			}
+		}
+		values := make([]interface{}, 0, n)
+		for i := 0; i < n; i++ {
+			v, err := d.decode(*t.Elem)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	case TypeOption, TypeCOption:
+		size := 1
+		if t.Kind == TypeCOption {
+			size = 4
+		}
+		tag, err := d.uint(size)
+		if err != nil {
+			return nil, err
+		}
+		if tag > 1 {
+			return nil, fmt.Errorf("invalid option tag %d", tag)
+		}
+		if tag == 0 && t.Kind == TypeOption {
+			return nil, nil
+		}
+		// the value of a coption is there even if it is none
+		v, err := d.decode(*t.Elem)
+		if err != nil || tag == 0 {
+			return nil, err
+		}
+		return v, nil
+	case TypeDefined:
+		def, ok := d.types[t.Defined]
+		if !ok {
+			return nil, fmt.Errorf("undefined type %v", t.Defined)
+		}
+		return d.decodeDefined(def.Type)
+	}
+	return nil, fmt.Errorf("unsupported type %v", t.Kind)
+}
+
+func (d *decoder) decodeDefined(t IDLTypeDefTy) (interface{}, error) {
+	switch t.Kind {
+	case "struct":
+		return d.decodeStructFields(t.Fields)
+	case "enum":
+		index, err := d.uint(1)
+		if err != nil {
+			return nil, err
+		}
+		if int(index) >= len(t.Variants) {
+			return nil, fmt.Errorf("unknown variant %d", index)
+		}
+		variant := t.Variants[index]
+		if variant.Fields.Len() == 0 {
+			return map[string]interface{}{variant.Name: nil}, nil
+		}
+		fields, err := d.decodeStructFields(variant.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{variant.Name: fields}, nil
+	case "alias":
+		if t.Value == nil {
+			return nil, errors.New("alias has no value")
+		}
+		return d.decode(*t.Value)
+	}
+	return nil, fmt.Errorf("unsupported type kind %v", t.Kind)
+}
+
+func (d *decoder) decodeStructFields(fields IDLFields) (interface{}, error) {
+	if len(fields.Tuple) > 0 {
+		values := make([]interface{}, 0, len(fields.Tuple))
+		for _, t := range fields.Tuple {
+			v, err := d.decode(t)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	}
+	return d.decodeFields(fields.Named)
+}
+
+func intSize(kind string) int {
+	switch kind {
+	case TypeU8, TypeI8:
+		return 1
+	case TypeU16, TypeI16:
+		return 2
+	case TypeU32, TypeI32, TypeF32:
+		return 4
+	case TypeU64, TypeI64, TypeF64:
+		return 8
+	}
+	return 16
+}
+
+func appendUint(buf []byte, n uint64, size int) []byte {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], n)
+	return append(buf, b[:size]...)
+}
+
+// putInt writes the little endian two's complement of n, it fails if n is out of the range
+func putInt(buf []byte, n *big.Int, size int, signed bool) ([]byte, error) {
+	bits := uint(size * 8)
+	min, max := new(big.Int), new(big.Int).Lsh(big.NewInt(1), bits)
+	if signed {
+		max.Rsh(max, 1)
+		min.Neg(max)
+	}
+	if n.Cmp(min) < 0 || n.Cmp(max) >= 0 {
+		return nil, fmt.Errorf("%v is out of the range of %d-bit integer", n, bits)
+	}
+	u := new(big.Int).Set(n)
+	if u.Sign() < 0 {
+		u.Add(u, new(big.Int).Lsh(big.NewInt(1), bits))
+	}
+	be := u.Bytes()
+	b := make([]byte, size)
+	for i := range be {
+		b[i] = be[len(be)-1-i]
+	}
+	return append(buf, b...), nil
+}
+
+func toBigInt(v interface{}) (*big.Int, error) {
+	switch n := v.(type) {
+	case int:
+		return big.NewInt(int64(n)), nil
+	case int8:
+		return big.NewInt(int64(n)), nil
+	case int16:
+		return big.NewInt(int64(n)), nil
+	case int32:
+		return big.NewInt(int64(n)), nil
+	case int64:
+		return big.NewInt(n), nil
+	case uint:
+		return new(big.Int).SetUint64(uint64(n)), nil
+	case uint8:
+		return new(big.Int).SetUint64(uint64(n)), nil
+	case uint16:
+		return new(big.Int).SetUint64(uint64(n)), nil
+	case uint32:
+		return new(big.Int).SetUint64(uint64(n)), nil
+	case uint64:
+		return new(big.Int).SetUint64(n), nil
+	case float64:
+		if n != math.Trunc(n) {
+			return nil, fmt.Errorf("%v is not an integer", n)
+		}
+		i, _ := big.NewFloat(n).Int(nil)
+		return i, nil
+	case json.Number:
+		i, ok := new(big.Int).SetString(n.String(), 10)
+		if !ok {
+			return nil, fmt.Errorf("%v is not an integer", n)
+		}
+		return i, nil
+	case string:
+		i, ok := new(big.Int).SetString(n, 10)
+		if !ok {
+			return nil, fmt.Errorf("%v is not an integer", n)
+		}
+		return i, nil
+	case *big.Int:
+		if n == nil {
+			return nil, errors.New("expect an integer, got nil")
+		}
+		return n, nil
+	case borsh.Uint128:
+		return n.BigInt(), nil
+	case borsh.Int128:
+		return n.BigInt(), nil
+	}
+	return nil, fmt.Errorf("expect an integer, got %T", v)
+}
+
+func toFloat(v interface{}) (float64, error) {
+	switch f := v.(type) {
+	case float32:
+		return float64(f), nil
+	case float64:
+		return f, nil
+	case json.Number:
+		return f.Float64()
+	}
+	n, err := toBigInt(v)
+	if err != nil {
+		return 0, fmt.Errorf("expect a float, got %T", v)
+	}
+	f, _ := new(big.Float).SetInt(n).Float64()
+	return f, nil
+}
+
+func toPublicKey(v interface{}) (common.PublicKey, error) {
+	switch k := v.(type) {
+	case common.PublicKey:
+		return k, nil
+	case *common.PublicKey:
+		if k != nil {
+			return *k, nil
+		}
+	case string:
+		b, err := base58.Decode(k)
+		if err != nil || len(b) != common.PublicKeyLength {
+			return common.PublicKey{}, fmt.Errorf("invalid public key %q", k)
+		}
+		return common.PublicKeyFromBytes(b), nil
+	case [32]byte:
+		return common.PublicKey(k), nil
+	}
+	return common.PublicKey{}, fmt.Errorf("expect a public key, got %T", v)
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// variantOf returns the name and the fields of an enum value, which is the name of a unit variant
+// or a map of a variant name to its fields
+func variantOf(v interface{}) (string, interface{}, error) {
+	switch e := v.(type) {
+	case string:
+		return e, nil, nil
+	case map[string]interface{}:
+		if len(e) != 1 {
+			return "", nil, fmt.Errorf("expect one variant, got %d", len(e))
+		}
+		for name, fields := range e {
+			return name, fields, nil
+		}
+	}
+	return "", nil, fmt.Errorf("expect an enum variant, got %T", v)
+}
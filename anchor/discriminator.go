@@ -0,0 +1,53 @@
+package anchor
+
+import (
+	"crypto/sha256"
+	"strings"
+	"unicode"
+)
+
+// DiscriminatorSize is the size of the discriminators Anchor derives from the names
+const DiscriminatorSize = 8
+
+// InstructionDiscriminator returns the first 8 bytes of sha256("global:<snake case name>")
+func InstructionDiscriminator(name string) Discriminator {
+	return sighash("global", SnakeCase(name))
+}
+
+// AccountDiscriminator returns the first 8 bytes of sha256("account:<name>")
+func AccountDiscriminator(name string) Discriminator {
+	return sighash("account", name)
+}
+
+// EventDiscriminator returns the first 8 bytes of sha256("event:<name>")
+func EventDiscriminator(name string) Discriminator {
+	return sighash("event", name)
+}
+
+func sighash(namespace, name string) Discriminator {
+	hash := sha256.Sum256([]byte(namespace + ":" + name))
+	return Discriminator(hash[:DiscriminatorSize])
+}
+
+// SnakeCase converts a camel case name of the legacy IDL to the rust function name,
+// a new word starts at an upper case letter after a lower case letter or a digit,
+// or at the last upper case letter of an acronym, so "initializeMint2" is "initialize_mint2"
+// and "setURIValue" is "set_uri_value"
+func SnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if r == '-' || r == ' ' {
+			r = '_'
+		}
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,44 @@
+package anchor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInstructionDiscriminator(t *testing.T) {
+	tests := []struct {
+		name string
+		want Discriminator
+	}{
+		{name: "initialize", want: Discriminator{175, 175, 109, 31, 13, 152, 155, 237}},
+		{name: "setConfig", want: Discriminator{108, 158, 154, 175, 212, 98, 52, 66}},
+		{name: "set_config", want: Discriminator{108, 158, 154, 175, 212, 98, 52, 66}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InstructionDiscriminator(tt.name); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InstructionDiscriminator() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "initialize", want: "initialize"},
+		{name: "setConfig", want: "set_config"},
+		{name: "initializeMint2", want: "initialize_mint2"},
+		{name: "setURIValue", want: "set_uri_value"},
+		{name: "already_snake", want: "already_snake"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SnakeCase(tt.name); got != tt.want {
+				t.Errorf("SnakeCase() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package anchor
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/client"
+)
+
+// Event is an event the program emits
+type Event struct {
+	Name string
+	Data map[string]interface{}
+}
+
+// DecodeEvent decodes the data Anchor logs for an event, which is the discriminator followed by the fields
+func (p *Program) DecodeEvent(data []byte) (Event, error) {
+	for _, event := range p.IDL.Events {
+		if !bytes.HasPrefix(data, event.Discriminator) {
+			continue
+		}
+		d := decoder{codec: p.codec, data: data, pos: len(event.Discriminator)}
+		fields, err := d.decodeFields(event.Fields)
+		if err != nil {
+			return Event{}, fmt.Errorf("failed to decode event %v, err: %v", event.Name, err)
+		}
+		return Event{Name: event.Name, Data: fields}, nil
+	}
+	return Event{}, errors.New("unknown event discriminator")
+}
+
+// DecodeEvents decodes the events of the program in the logs of a transaction.
+// Anchor logs an event as "Program data: <base64>", only the lines logged while the program is running are decoded
+// and the data which is not an event of the program is skipped.
+func (p *Program) DecodeEvents(logs []string) ([]Event, error) {
+	const dataPrefix = "Program data: "
+	programID := p.ProgramID.ToBase58()
+	var stack []string
+	var events []Event
+	for _, log := range logs {
+		if strings.HasPrefix(log, dataPrefix) {
+			if len(stack) == 0 || stack[len(stack)-1] != programID {
+				continue
+			}
+			fields := strings.Fields(strings.TrimPrefix(log, dataPrefix))
+			if len(fields) == 0 {
+				continue
+			}
+			// a program may log any data, only an event of this program is decoded
+			data, err := base64.StdEncoding.DecodeString(fields[0])
+			if err != nil {
+				continue
+			}
+			event, err := p.DecodeEvent(data)
+			if err != nil {
+				continue
+			}
+			events = append(events, event)
+			continue
+		}
+
+		id, action, ok := parseProgramLog(log)
+		if !ok {
+			continue
+		}
+		switch action {
+		case "invoke":
+			stack = append(stack, id)
+		case "success", "failed":
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	return events, nil
+}
+
+// parseProgramLog parses the lines the runtime logs when a program starts and ends,
+// "Program <id> invoke [<depth>]", "Program <id> success" and "Program <id> failed: <reason>".
+// Lines a program logs itself like "Program log: invoke" are not matched.
+func parseProgramLog(log string) (string, string, bool) {
+	fields := strings.Fields(log)
+	if len(fields) < 3 || fields[0] != "Program" {
+		return "", "", false
+	}
+	id, err := base58.Decode(fields[1])
+	if err != nil || len(id) != 32 {
+		return "", "", false
+	}
+	switch {
+	case len(fields) == 4 && fields[2] == "invoke" && isDepth(fields[3]):
+		return fields[1], "invoke", true
+	case len(fields) == 3 && fields[2] == "success":
+		return fields[1], "success", true
+	case fields[2] == "failed" || strings.HasPrefix(fields[2], "failed:"):
+		return fields[1], "failed", true
+	}
+	return "", "", false
+}
+
+// isDepth reports whether s is the invoke depth like "[1]"
+func isDepth(s string) bool {
+	if len(s) < 3 || s[0] != '[' || s[len(s)-1] != ']' {
+		return false
+	}
+	_, err := strconv.ParseUint(s[1:len(s)-1], 10, 8)
+	return err == nil
+}
+
+// DecodeTransactionEvents decodes the events of the program in the logs of the transaction meta
+func (p *Program) DecodeTransactionEvents(meta client.TransactionMeta) ([]Event, error) {
+	return p.DecodeEvents(meta.LogMessages)
+}
@@ -0,0 +1,63 @@
+package anchor
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+func TestDecodeEvents(t *testing.T) {
+	p := loadTestProgram(t, "testdata/counter.json")
+
+	data := append([]byte{183, 249, 195, 117, 235, 147, 100, 186}, testCounter.Bytes()...)
+	data = append(data, make([]byte, 8)...)
+	binary.LittleEndian.PutUint64(data[40:], 8)
+	event := "Program data: " + base64.StdEncoding.EncodeToString(data)
+
+	logs := []string{
+		"Program Fg6PaFpoGXkYsidMpWTK6W2BeZ7FEfcYkg476zPFsLnS invoke [1]",
+		"Program log: Instruction: Increment",
+		// logged by the program itself, it doesn't start another program
+		"Program log: invoke [2]",
+		"Program data: not base64!",
+		event,
+		"Program 11111111111111111111111111111111 invoke [2]",
+		// logged by another program
+		event,
+		"Program 11111111111111111111111111111111 success",
+		"Program data: AQIDBAUGBwg=",
+		"Program Fg6PaFpoGXkYsidMpWTK6W2BeZ7FEfcYkg476zPFsLnS consumed 5000 of 200000 compute units",
+		"Program log: success",
+		event,
+		"Program Fg6PaFpoGXkYsidMpWTK6W2BeZ7FEfcYkg476zPFsLnS success",
+		event,
+	}
+	got, err := p.DecodeTransactionEvents(client.TransactionMeta{LogMessages: logs})
+	if err != nil {
+		t.Fatalf("DecodeTransactionEvents() error = %v", err)
+	}
+	want := []Event{
+		{
+			Name: "CountChanged",
+			Data: map[string]interface{}{"counter": testCounter, "count": uint64(8)},
+		},
+		{
+			Name: "CountChanged",
+			Data: map[string]interface{}{"counter": testCounter, "count": uint64(8)},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DecodeTransactionEvents() = %v, want %v", got, want)
+	}
+
+	if _, err := p.DecodeEvent(data[:20]); err == nil {
+		t.Errorf("DecodeEvent() of short data should fail")
+	}
+	if _, err := p.DecodeEvent(common.SystemProgramID.Bytes()); err == nil {
+		t.Errorf("DecodeEvent() of unknown discriminator should fail")
+	}
+}
@@ -0,0 +1,424 @@
+// Package anchor loads the IDL of an Anchor program to build its instructions and decode its
+// instructions, accounts and events at runtime. Both the legacy IDL and the IDL of Anchor 0.30 are supported.
+package anchor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// IDL is the interface description of an Anchor program
+type IDL struct {
+	// Address is the program id of an Anchor 0.30 IDL
+	Address      string           `json:"address,omitempty"`
+	Version      string           `json:"version,omitempty"`
+	Name         string           `json:"name,omitempty"`
+	Metadata     IDLMetadata      `json:"metadata"`
+	Docs         []string         `json:"docs,omitempty"`
+	Instructions []IDLInstruction `json:"instructions"`
+	Accounts     []IDLTypeDef     `json:"accounts,omitempty"`
+	Types        []IDLTypeDef     `json:"types,omitempty"`
+	Events       []IDLEvent       `json:"events,omitempty"`
+	Errors       []IDLErrorCode   `json:"errors,omitempty"`
+}
+
+// IDLMetadata is where Anchor 0.30 keeps the name and the version, the legacy IDL keeps the address here
+type IDLMetadata struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+	Address string `json:"address,omitempty"`
+}
+
+// IDLInstruction is an instruction with its accounts and args in order
+type IDLInstruction struct {
+	Name          string           `json:"name"`
+	Docs          []string         `json:"docs,omitempty"`
+	Discriminator Discriminator    `json:"discriminator,omitempty"`
+	Accounts      []IDLAccountItem `json:"accounts"`
+	Args          []IDLField       `json:"args"`
+	Returns       *IDLType         `json:"returns,omitempty"`
+}
+
+// IDLAccountItem is an account of an instruction, or a group of accounts if Accounts is not empty
+type IDLAccountItem struct {
+	Name     string
+	Docs     []string
+	Writable bool
+	Signer   bool
+	Optional bool
+	// Address is the fixed address of the account, if the IDL knows it
+	Address  string
+	Accounts []IDLAccountItem
+}
+
+// UnmarshalJSON accepts isMut/isSigner/isOptional of the legacy IDL and writable/signer/optional of Anchor 0.30
+func (a *IDLAccountItem) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Name       string           `json:"name"`
+		Docs       []string         `json:"docs"`
+		IsMut      bool             `json:"isMut"`
+		IsSigner   bool             `json:"isSigner"`
+		IsOptional bool             `json:"isOptional"`
+		Writable   bool             `json:"writable"`
+		Signer     bool             `json:"signer"`
+		Optional   bool             `json:"optional"`
+		Address    string           `json:"address"`
+		Accounts   []IDLAccountItem `json:"accounts"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*a = IDLAccountItem{
+		Name:     v.Name,
+		Docs:     v.Docs,
+		Writable: v.IsMut || v.Writable,
+		Signer:   v.IsSigner || v.Signer,
+		Optional: v.IsOptional || v.Optional,
+		Address:  v.Address,
+		Accounts: v.Accounts,
+	}
+	return nil
+}
+
+// MarshalJSON encodes the account in the Anchor 0.30 format
+func (a IDLAccountItem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Name     string           `json:"name"`
+		Docs     []string         `json:"docs,omitempty"`
+		Writable bool             `json:"writable,omitempty"`
+		Signer   bool             `json:"signer,omitempty"`
+		Optional bool             `json:"optional,omitempty"`
+		Address  string           `json:"address,omitempty"`
+		Accounts []IDLAccountItem `json:"accounts,omitempty"`
+	}{a.Name, a.Docs, a.Writable, a.Signer, a.Optional, a.Address, a.Accounts})
+}
+
+// IDLField is a named field of a struct, an arg of an instruction or a field of an event
+type IDLField struct {
+	Name string   `json:"name"`
+	Docs []string `json:"docs,omitempty"`
+	Type IDLType  `json:"type"`
+}
+
+// IDLTypeDef is a type defined by the program, or an account of the program
+type IDLTypeDef struct {
+	Name          string        `json:"name"`
+	Docs          []string      `json:"docs,omitempty"`
+	Discriminator Discriminator `json:"discriminator,omitempty"`
+	Type          IDLTypeDefTy  `json:"type"`
+}
+
+// IDLTypeDefTy is the body of a defined type, Kind is struct, enum or alias
+type IDLTypeDefTy struct {
+	Kind     string           `json:"kind"`
+	Fields   IDLFields        `json:"fields,omitempty"`
+	Variants []IDLEnumVariant `json:"variants,omitempty"`
+	// Value is the aliased type
+	Value *IDLType `json:"value,omitempty"`
+}
+
+// IDLEnumVariant is a variant of an enum, its fields are empty for a unit variant
+type IDLEnumVariant struct {
+	Name   string    `json:"name"`
+	Fields IDLFields `json:"fields,omitempty"`
+}
+
+// IDLFields are the named fields of a struct or the types of a tuple, at most one of them is set
+type IDLFields struct {
+	Named []IDLField
+	Tuple []IDLType
+}
+
+// Len returns the number of fields
+func (f IDLFields) Len() int {
+	return len(f.Named) + len(f.Tuple)
+}
+
+// UnmarshalJSON decodes a list of named fields or a list of types
+func (f *IDLFields) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*f = IDLFields{}
+	for _, r := range raw {
+		var named struct {
+			Name *string `json:"name"`
+		}
+		// a defined type is an object too, but it has no name at the top level
+		if err := json.Unmarshal(r, &named); err == nil && named.Name != nil {
+			var field IDLField
+			if err := json.Unmarshal(r, &field); err != nil {
+				return err
+			}
+			f.Named = append(f.Named, field)
+			continue
+		}
+		var t IDLType
+		if err := json.Unmarshal(r, &t); err != nil {
+			return err
+		}
+		f.Tuple = append(f.Tuple, t)
+	}
+	if len(f.Named) > 0 && len(f.Tuple) > 0 {
+		return fmt.Errorf("fields are named and unnamed")
+	}
+	return nil
+}
+
+// MarshalJSON encodes the named fields or the types
+func (f IDLFields) MarshalJSON() ([]byte, error) {
+	if len(f.Tuple) > 0 {
+		return json.Marshal(f.Tuple)
+	}
+	if f.Named == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(f.Named)
+}
+
+// IDLEvent is an event the program emits. The fields of an Anchor 0.30 event are in the type of the same name.
+type IDLEvent struct {
+	Name          string        `json:"name"`
+	Discriminator Discriminator `json:"discriminator,omitempty"`
+	Fields        []IDLField    `json:"fields,omitempty"`
+}
+
+// IDLErrorCode is a custom error of the program
+type IDLErrorCode struct {
+	Code uint32 `json:"code"`
+	Name string `json:"name"`
+	Msg  string `json:"msg,omitempty"`
+}
+
+// Discriminator is the prefix which tells instructions, accounts and events apart
+type Discriminator []byte
+
+// UnmarshalJSON decodes a list of numbers, encoding/json expects base64 for a byte slice
+func (d *Discriminator) UnmarshalJSON(data []byte) error {
+	var v []uint8
+	var n []int
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	for _, b := range n {
+		if b < 0 || b > 255 {
+			return fmt.Errorf("invalid discriminator byte %d", b)
+		}
+		v = append(v, uint8(b))
+	}
+	*d = v
+	return nil
+}
+
+// MarshalJSON encodes a list of numbers
+func (d Discriminator) MarshalJSON() ([]byte, error) {
+	n := make([]int, 0, len(d))
+	for _, b := range d {
+		n = append(n, int(b))
+	}
+	return json.Marshal(n)
+}
+
+// Primitive types of IDLType.Kind
+const (
+	TypeBool    = "bool"
+	TypeU8      = "u8"
+	TypeI8      = "i8"
+	TypeU16     = "u16"
+	TypeI16     = "i16"
+	TypeU32     = "u32"
+	TypeI32     = "i32"
+	TypeF32     = "f32"
+	TypeU64     = "u64"
+	TypeI64     = "i64"
+	TypeF64     = "f64"
+	TypeU128    = "u128"
+	TypeI128    = "i128"
+	TypeBytes   = "bytes"
+	TypeString  = "string"
+	TypePubkey  = "pubkey"
+	TypeVec     = "vec"
+	TypeOption  = "option"
+	TypeCOption = "coption"
+	TypeArray   = "array"
+	TypeDefined = "defined"
+)
+
+// IDLType is a primitive, a compound type of Elem, or a type defined by the program
+type IDLType struct {
+	Kind string
+	// Elem is the element of vec, option, coption and array
+	Elem *IDLType
+	// Len is the length of an array
+	Len int
+	// Defined is the name of a defined type
+	Defined string
+}
+
+// UnmarshalJSON decodes a type name like "u64" or an object like {"vec": "u8"}
+func (t *IDLType) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		// the legacy IDL calls it publicKey
+		if name == "publicKey" {
+			name = TypePubkey
+		}
+		switch name {
+		case TypeBool, TypeU8, TypeI8, TypeU16, TypeI16, TypeU32, TypeI32, TypeF32, TypeU64, TypeI64, TypeF64,
+			TypeU128, TypeI128, TypeBytes, TypeString, TypePubkey:
+			*t = IDLType{Kind: name}
+			return nil
+		}
+		return fmt.Errorf("unsupported type %q", name)
+	}
+
+	var v struct {
+		Vec     *IDLType          `json:"vec"`
+		Option  *IDLType          `json:"option"`
+		COption *IDLType          `json:"coption"`
+		Array   []json.RawMessage `json:"array"`
+		Defined json.RawMessage   `json:"defined"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch {
+	case v.Vec != nil:
+		*t = IDLType{Kind: TypeVec, Elem: v.Vec}
+	case v.Option != nil:
+		*t = IDLType{Kind: TypeOption, Elem: v.Option}
+	case v.COption != nil:
+		*t = IDLType{Kind: TypeCOption, Elem: v.COption}
+	case v.Array != nil:
+		if len(v.Array) != 2 {
+			return fmt.Errorf("invalid array type %s", data)
+		}
+		var elem IDLType
+		if err := json.Unmarshal(v.Array[0], &elem); err != nil {
+			return err
+		}
+		var n int
+		if err := json.Unmarshal(v.Array[1], &n); err != nil {
+			return fmt.Errorf("unsupported array length %s", v.Array[1])
+		}
+		*t = IDLType{Kind: TypeArray, Elem: &elem, Len: n}
+	case v.Defined != nil:
+		// the legacy IDL has the name, Anchor 0.30 has an object with the name
+		var name string
+		if err := json.Unmarshal(v.Defined, &name); err != nil {
+			var defined struct {
+				Name string `json:"name"`
+			}
+			if err := json.Unmarshal(v.Defined, &defined); err != nil {
+				return err
+			}
+			name = defined.Name
+		}
+		*t = IDLType{Kind: TypeDefined, Defined: name}
+	default:
+		return fmt.Errorf("unsupported type %s", data)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the type in the Anchor 0.30 format
+func (t IDLType) MarshalJSON() ([]byte, error) {
+	switch t.Kind {
+	case TypeVec, TypeOption, TypeCOption:
+		return json.Marshal(map[string]interface{}{t.Kind: t.Elem})
+	case TypeArray:
+		return json.Marshal(map[string]interface{}{t.Kind: []interface{}{t.Elem, t.Len}})
+	case TypeDefined:
+		return json.Marshal(map[string]interface{}{t.Kind: map[string]string{"name": t.Defined}})
+	}
+	return json.Marshal(t.Kind)
+}
+
+// String returns the type in the rust syntax
+func (t IDLType) String() string {
+	switch t.Kind {
+	case TypeVec:
+		return "Vec<" + t.Elem.String() + ">"
+	case TypeOption:
+		return "Option<" + t.Elem.String() + ">"
+	case TypeCOption:
+		return "COption<" + t.Elem.String() + ">"
+	case TypeArray:
+		return fmt.Sprintf("[%s; %d]", t.Elem.String(), t.Len)
+	case TypeDefined:
+		return t.Defined
+	case TypeBytes:
+		return "Vec<u8>"
+	case TypeString:
+		return "String"
+	case TypePubkey:
+		return "Pubkey"
+	}
+	return t.Kind
+}
+
+// ParseIDL decodes the IDL json and fills the discriminators and the event fields the IDL leaves out
+func ParseIDL(data []byte) (*IDL, error) {
+	var idl IDL
+	if err := json.Unmarshal(data, &idl); err != nil {
+		return nil, fmt.Errorf("failed to decode idl, err: %v", err)
+	}
+	if idl.Name == "" {
+		idl.Name = idl.Metadata.Name
+	}
+	if idl.Version == "" {
+		idl.Version = idl.Metadata.Version
+	}
+	if idl.Address == "" {
+		idl.Address = idl.Metadata.Address
+	}
+
+	types := make(map[string]IDLTypeDef, len(idl.Types))
+	for _, t := range idl.Types {
+		types[t.Name] = t
+	}
+	for i := range idl.Instructions {
+		if len(idl.Instructions[i].Discriminator) == 0 {
+			idl.Instructions[i].Discriminator = InstructionDiscriminator(idl.Instructions[i].Name)
+		}
+	}
+	for i := range idl.Accounts {
+		account := &idl.Accounts[i]
+		if len(account.Discriminator) == 0 {
+			account.Discriminator = AccountDiscriminator(account.Name)
+		}
+		// Anchor 0.30 defines the layout of an account in types
+		if account.Type.Kind == "" {
+			t, ok := types[account.Name]
+			if !ok {
+				return nil, fmt.Errorf("account %v has no type", account.Name)
+			}
+			account.Type = t.Type
+		}
+	}
+	for i := range idl.Events {
+		event := &idl.Events[i]
+		if len(event.Discriminator) == 0 {
+			event.Discriminator = EventDiscriminator(event.Name)
+		}
+		if event.Fields == nil {
+			t, ok := types[event.Name]
+			if !ok || t.Type.Kind != "struct" {
+				return nil, fmt.Errorf("event %v has no fields", event.Name)
+			}
+			event.Fields = t.Type.Fields.Named
+		}
+	}
+	return &idl, nil
+}
+
+// LoadIDL reads the IDL json file
+func LoadIDL(path string) (*IDL, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseIDL(data)
+}
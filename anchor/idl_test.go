@@ -0,0 +1,67 @@
+package anchor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadIDL(t *testing.T) {
+	legacy, err := LoadIDL("testdata/counter_legacy.json")
+	if err != nil {
+		t.Fatalf("failed to load legacy idl, err: %v", err)
+	}
+	idl, err := LoadIDL("testdata/counter.json")
+	if err != nil {
+		t.Fatalf("failed to load idl, err: %v", err)
+	}
+
+	for _, idl := range []*IDL{legacy, idl} {
+		if idl.Name != "counter" || idl.Version != "0.1.0" || idl.Address != "Fg6PaFpoGXkYsidMpWTK6W2BeZ7FEfcYkg476zPFsLnS" {
+			t.Errorf("name, version or address not match, got %v %v %v", idl.Name, idl.Version, idl.Address)
+		}
+	}
+	for i := range legacy.Instructions {
+		if got, want := legacy.Instructions[i].Discriminator, idl.Instructions[i].Discriminator; !reflect.DeepEqual(got, want) {
+			t.Errorf("instruction %v discriminator = %v, want %v", legacy.Instructions[i].Name, got, want)
+		}
+	}
+	if !reflect.DeepEqual(legacy.Accounts[0].Discriminator, idl.Accounts[0].Discriminator) {
+		t.Errorf("account discriminator = %v, want %v", legacy.Accounts[0].Discriminator, idl.Accounts[0].Discriminator)
+	}
+	if !reflect.DeepEqual(legacy.Accounts[0].Type, idl.Accounts[0].Type) {
+		t.Errorf("account type = %+v, want %+v", legacy.Accounts[0].Type, idl.Accounts[0].Type)
+	}
+	if !reflect.DeepEqual(legacy.Events[0].Discriminator, idl.Events[0].Discriminator) {
+		t.Errorf("event discriminator = %v, want %v", legacy.Events[0].Discriminator, idl.Events[0].Discriminator)
+	}
+	if !reflect.DeepEqual(legacy.Events[0].Fields, idl.Events[0].Fields) {
+		t.Errorf("event fields = %+v, want %+v", legacy.Events[0].Fields, idl.Events[0].Fields)
+	}
+
+	referrer := idl.Instructions[1].Accounts[2]
+	if referrer.Writable || referrer.Signer || !referrer.Optional {
+		t.Errorf("referrer = %+v, want an optional read-only account", referrer)
+	}
+}
+
+func TestIDLTypeString(t *testing.T) {
+	idl, err := LoadIDL("testdata/counter.json")
+	if err != nil {
+		t.Fatalf("failed to load idl, err: %v", err)
+	}
+	tests := []struct {
+		t    IDLType
+		want string
+	}{
+		{t: idl.Instructions[1].Args[1].Type, want: "Option<String>"},
+		{t: idl.Types[2].Type.Fields.Named[3].Type, want: "Vec<i32>"},
+		{t: idl.Types[2].Type.Fields.Named[4].Type, want: "[u8; 4]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.t.String(); got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package anchor
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// Program builds and decodes the instructions, the accounts and the events of an Anchor program by its IDL
+type Program struct {
+	ProgramID common.PublicKey
+	IDL       *IDL
+	codec     codec
+}
+
+// NewProgram returns the program of the IDL, the program id of the IDL is used if programID is empty
+func NewProgram(idl *IDL, programID common.PublicKey) (*Program, error) {
+	if programID == (common.PublicKey{}) {
+		if idl.Address == "" {
+			return nil, errors.New("idl has no address, a program id is needed")
+		}
+		programID = common.PublicKeyFromString(idl.Address)
+	}
+	return &Program{
+		ProgramID: programID,
+		IDL:       idl,
+		codec:     newCodec(idl),
+	}, nil
+}
+
+// Instruction returns the instruction of the name
+func (p *Program) Instruction(name string) (*IDLInstruction, bool) {
+	for i := range p.IDL.Instructions {
+		if p.IDL.Instructions[i].Name == name {
+			return &p.IDL.Instructions[i], true
+		}
+	}
+	return nil, false
+}
+
+// BuildInstruction encodes the args of the instruction and lists its accounts in order.
+// Accounts of a group are named group.account. An optional account which is not given is the program id,
+// which Anchor takes as none, and an account with a fixed address in the IDL can be left out.
+func (p *Program) BuildInstruction(name string, args map[string]interface{}, accounts map[string]common.PublicKey) (types.Instruction, error) {
+	ins, ok := p.Instruction(name)
+	if !ok {
+		return types.Instruction{}, fmt.Errorf("instruction %v is not in the idl", name)
+	}
+
+	data := append([]byte{}, ins.Discriminator...)
+	data, err := p.codec.encodeFields(data, ins.Args, args)
+	if err != nil {
+		return types.Instruction{}, fmt.Errorf("failed to encode args of %v, err: %v", name, err)
+	}
+
+	flattened := flattenAccounts(ins.Accounts, "")
+	known := make(map[string]bool, len(flattened))
+	for _, account := range flattened {
+		known[account.Name] = true
+	}
+	for accountName := range accounts {
+		if !known[accountName] {
+			return types.Instruction{}, fmt.Errorf("unknown account %v of %v", accountName, name)
+		}
+	}
+
+	metas := make([]types.AccountMeta, 0, len(flattened))
+	for _, account := range flattened {
+		pubkey, ok := accounts[account.Name]
+		switch {
+		case ok:
+		case account.Address != "":
+			pubkey = common.PublicKeyFromString(account.Address)
+		case account.Optional:
+			pubkey = p.ProgramID
+		default:
+			return types.Instruction{}, fmt.Errorf("missing account %v of %v", account.Name, name)
+		}
+		// a none optional account is the read-only program id
+		writable := account.Writable && pubkey != p.ProgramID
+		metas = append(metas, types.AccountMeta{PubKey: pubkey, IsSigner: account.Signer, IsWritable: writable})
+	}
+
+	return types.Instruction{
+		ProgramID: p.ProgramID,
+		Accounts:  metas,
+		Data:      data,
+	}, nil
+}
+
+// flattenAccounts lists the accounts of the groups in order and names them by their path
+func flattenAccounts(items []IDLAccountItem, prefix string) []IDLAccountItem {
+	accounts := make([]IDLAccountItem, 0, len(items))
+	for _, item := range items {
+		item.Name = prefix + item.Name
+		if len(item.Accounts) > 0 {
+			accounts = append(accounts, flattenAccounts(item.Accounts, item.Name+".")...)
+			continue
+		}
+		accounts = append(accounts, item)
+	}
+	return accounts
+}
+
+// DecodeInstruction finds the instruction by the discriminator of the data and decodes its args
+func (p *Program) DecodeInstruction(data []byte) (*IDLInstruction, map[string]interface{}, error) {
+	for i := range p.IDL.Instructions {
+		ins := &p.IDL.Instructions[i]
+		if !bytes.HasPrefix(data, ins.Discriminator) {
+			continue
+		}
+		d := decoder{codec: p.codec, data: data, pos: len(ins.Discriminator)}
+		args, err := d.decodeFields(ins.Args)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode args of %v, err: %v", ins.Name, err)
+		}
+		return ins, args, nil
+	}
+	return nil, nil, errors.New("unknown instruction discriminator")
+}
+
+// DecodeAccount finds the account by the discriminator of the data and decodes it
+func (p *Program) DecodeAccount(data []byte) (string, map[string]interface{}, error) {
+	for i := range p.IDL.Accounts {
+		account := &p.IDL.Accounts[i]
+		if !bytes.HasPrefix(data, account.Discriminator) {
+			continue
+		}
+		v, err := p.DecodeAccountAs(account.Name, data)
+		return account.Name, v, err
+	}
+	return "", nil, errors.New("unknown account discriminator")
+}
+
+// DecodeAccountAs decodes the data as the account of the name, the discriminator has to match
+func (p *Program) DecodeAccountAs(name string, data []byte) (map[string]interface{}, error) {
+	for i := range p.IDL.Accounts {
+		account := &p.IDL.Accounts[i]
+		if account.Name != name {
+			continue
+		}
+		if !bytes.HasPrefix(data, account.Discriminator) {
+			return nil, fmt.Errorf("account discriminator of %v not match", name)
+		}
+		if account.Type.Kind != "struct" || len(account.Type.Fields.Tuple) > 0 {
+			return nil, fmt.Errorf("account %v is not a struct", name)
+		}
+		d := decoder{codec: p.codec, data: data, pos: len(account.Discriminator)}
+		v, err := d.decodeFields(account.Type.Fields.Named)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode account %v, err: %v", name, err)
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("account %v is not in the idl", name)
+}
+
+// EncodeAccount encodes the fields of the account of the name after its discriminator
+func (p *Program) EncodeAccount(name string, fields map[string]interface{}) ([]byte, error) {
+	for i := range p.IDL.Accounts {
+		account := &p.IDL.Accounts[i]
+		if account.Name != name {
+			continue
+		}
+		data := append([]byte{}, account.Discriminator...)
+		data, err := p.codec.encodeStructFields(data, account.Type.Fields, fields)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode account %v, err: %v", name, err)
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("account %v is not in the idl", name)
+}
+
+// Error returns the custom error of the code
+func (p *Program) Error(code uint32) (IDLErrorCode, bool) {
+	for _, e := range p.IDL.Errors {
+		if e.Code == code {
+			return e, true
+		}
+	}
+	return IDLErrorCode{}, false
+}
+
+// Register makes types.ParseInstruction decode the instructions of the program with Parse
+func (p *Program) Register() {
+	types.RegisterInstructionParser(p.ProgramID, p.Parse)
+}
+
+// Parse decodes the instruction, the info holds the args and the accounts by their names,
+// the accounts after the ones of the instruction are in remainingAccounts
+func (p *Program) Parse(ins types.Instruction) (types.ParsedInstruction, error) {
+	var parsedInstruction types.ParsedInstruction
+	idlIns, args, err := p.DecodeInstruction(ins.Data)
+	if err != nil {
+		return parsedInstruction, err
+	}
+
+	info := make(map[string]interface{}, len(args)+len(ins.Accounts))
+	for k, v := range args {
+		info[k] = parsedValue(v)
+	}
+	accounts := flattenAccounts(idlIns.Accounts, "")
+	for i, meta := range ins.Accounts {
+		if i >= len(accounts) {
+			remaining := make([]string, 0, len(ins.Accounts)-i)
+			for _, meta := range ins.Accounts[i:] {
+				remaining = append(remaining, meta.PubKey.ToBase58())
+			}
+			info["remainingAccounts"] = remaining
+			break
+		}
+		if accounts[i].Optional && meta.PubKey == p.ProgramID {
+			continue
+		}
+		info[accounts[i].Name] = meta.PubKey.ToBase58()
+	}
+
+	parsedInstruction.Program = p.IDL.Name
+	parsedInstruction.Parsed = &types.InstructionInfo{
+		Info:            info,
+		InstructionType: idlIns.Name,
+	}
+	return parsedInstruction, nil
+}
+
+// parsedValue turns the decoded values into what the rpc node returns for the parsed json,
+// public keys are base58, bytes are base64 and 128-bit integers are decimal strings
+func parsedValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case common.PublicKey:
+		return v.ToBase58()
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case *big.Int:
+		return v.String()
+	case []interface{}:
+		values := make([]interface{}, 0, len(v))
+		for _, e := range v {
+			values = append(values, parsedValue(e))
+		}
+		return values
+	case map[string]interface{}:
+		values := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			values[k] = parsedValue(e)
+		}
+		return values
+	}
+	return v
+}
@@ -0,0 +1,311 @@
+package anchor
+
+import (
+	"encoding/binary"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+var (
+	testProgramID = common.PublicKeyFromString("Fg6PaFpoGXkYsidMpWTK6W2BeZ7FEfcYkg476zPFsLnS")
+	testCounter   = common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	testAuthority = common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvk7")
+	testReferrer  = common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUJfe1X9K")
+)
+
+func loadTestProgram(t *testing.T, path string) *Program {
+	idl, err := LoadIDL(path)
+	if err != nil {
+		t.Fatalf("failed to load idl, err: %v", err)
+	}
+	p, err := NewProgram(idl, common.PublicKey{})
+	if err != nil {
+		t.Fatalf("failed to create program, err: %v", err)
+	}
+	return p
+}
+
+func TestBuildInstruction(t *testing.T) {
+	limit := new(big.Int).Lsh(big.NewInt(1), 70)
+	setConfigData := append([]byte{108, 158, 154, 175, 212, 98, 52, 66}, 2, 0)
+	setConfigData = append(setConfigData, 0, 0, 0, 0, 0, 0, 0, 0, 64, 0, 0, 0, 0, 0, 0, 0)
+	initializeData := append([]byte{175, 175, 109, 31, 13, 152, 155, 237}, make([]byte, 8)...)
+	binary.LittleEndian.PutUint64(initializeData[8:], 10)
+
+	type args struct {
+		name     string
+		args     map[string]interface{}
+		accounts map[string]common.PublicKey
+	}
+	tests := []struct {
+		path    string
+		args    args
+		want    types.Instruction
+		wantErr bool
+	}{
+		{
+			path: "testdata/counter_legacy.json",
+			args: args{
+				name: "initialize",
+				args: map[string]interface{}{"start": 10},
+				accounts: map[string]common.PublicKey{
+					"counter":       testCounter,
+					"authority":     testAuthority,
+					"systemProgram": common.SystemProgramID,
+				},
+			},
+			want: types.Instruction{
+				ProgramID: testProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: testCounter, IsSigner: true, IsWritable: true},
+					{PubKey: testAuthority, IsSigner: true, IsWritable: true},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: initializeData,
+			},
+		},
+		{
+			path: "testdata/counter.json",
+			args: args{
+				name: "initialize",
+				args: map[string]interface{}{"start": uint64(10)},
+				accounts: map[string]common.PublicKey{
+					"counter":   testCounter,
+					"authority": testAuthority,
+				},
+			},
+			want: types.Instruction{
+				ProgramID: testProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: testCounter, IsSigner: true, IsWritable: true},
+					{PubKey: testAuthority, IsSigner: true, IsWritable: true},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: initializeData,
+			},
+		},
+		{
+			path: "testdata/counter.json",
+			args: args{
+				name: "set_config",
+				args: map[string]interface{}{
+					"config": map[string]interface{}{"step": 2, "limit": limit},
+				},
+				accounts: map[string]common.PublicKey{
+					"counter":   testCounter,
+					"authority": testAuthority,
+				},
+			},
+			want: types.Instruction{
+				ProgramID: testProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: testCounter, IsSigner: false, IsWritable: true},
+					{PubKey: testAuthority, IsSigner: true, IsWritable: false},
+					{PubKey: testProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: append(setConfigData, 0),
+			},
+		},
+		{
+			path: "testdata/counter.json",
+			args: args{
+				name: "set_config",
+				args: map[string]interface{}{
+					"config": map[string]interface{}{"step": 2, "limit": limit.String()},
+					"label":  "hi",
+				},
+				accounts: map[string]common.PublicKey{
+					"counter":   testCounter,
+					"authority": testAuthority,
+					"referrer":  testReferrer,
+				},
+			},
+			want: types.Instruction{
+				ProgramID: testProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: testCounter, IsSigner: false, IsWritable: true},
+					{PubKey: testAuthority, IsSigner: true, IsWritable: false},
+					{PubKey: testReferrer, IsSigner: false, IsWritable: false},
+				},
+				Data: append(setConfigData, 1, 2, 0, 0, 0, 'h', 'i'),
+			},
+		},
+		{
+			path: "testdata/counter.json",
+			args: args{
+				name:     "initialize",
+				args:     map[string]interface{}{"start": 10},
+				accounts: map[string]common.PublicKey{"counter": testCounter},
+			},
+			wantErr: true,
+		},
+		{
+			path: "testdata/counter.json",
+			args: args{
+				name: "initialize",
+				args: map[string]interface{}{"start": -1},
+				accounts: map[string]common.PublicKey{
+					"counter":   testCounter,
+					"authority": testAuthority,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			path: "testdata/counter.json",
+			args: args{
+				name: "initialize",
+				args: map[string]interface{}{"start": 10},
+				accounts: map[string]common.PublicKey{
+					"counter":   testCounter,
+					"authority": testAuthority,
+					"payer":     testAuthority,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.args.name, func(t *testing.T) {
+			p := loadTestProgram(t, tt.path)
+			got, err := p.BuildInstruction(tt.args.name, tt.args.args, tt.args.accounts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BuildInstruction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BuildInstruction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeInstruction(t *testing.T) {
+	p := loadTestProgram(t, "testdata/counter.json")
+	limit := new(big.Int).Lsh(big.NewInt(1), 70)
+	ins, err := p.BuildInstruction("set_config", map[string]interface{}{
+		"config": map[string]interface{}{"step": 2, "limit": limit},
+		"label":  "hi",
+	}, map[string]common.PublicKey{"counter": testCounter, "authority": testAuthority})
+	if err != nil {
+		t.Fatalf("failed to build instruction, err: %v", err)
+	}
+
+	idlIns, args, err := p.DecodeInstruction(ins.Data)
+	if err != nil {
+		t.Fatalf("DecodeInstruction() error = %v", err)
+	}
+	if idlIns.Name != "set_config" {
+		t.Errorf("DecodeInstruction() name = %v, want set_config", idlIns.Name)
+	}
+	want := map[string]interface{}{
+		"config": map[string]interface{}{"step": uint16(2), "limit": limit},
+		"label":  "hi",
+	}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("DecodeInstruction() args = %v, want %v", args, want)
+	}
+
+	if _, _, err := p.DecodeInstruction(ins.Data[:len(ins.Data)-1]); err == nil {
+		t.Errorf("DecodeInstruction() of short data should fail")
+	}
+	if _, _, err := p.DecodeInstruction([]byte{1, 2, 3, 4, 5, 6, 7, 8}); err == nil {
+		t.Errorf("DecodeInstruction() of unknown discriminator should fail")
+	}
+}
+
+func TestAccount(t *testing.T) {
+	p := loadTestProgram(t, "testdata/counter_legacy.json")
+	fields := map[string]interface{}{
+		"authority": testAuthority,
+		"count":     uint64(7),
+		"mode":      map[string]interface{}{"Custom": map[string]interface{}{"delta": int64(-3)}},
+		"history":   []interface{}{int32(1), int32(-2)},
+		"seed":      []interface{}{uint8(1), uint8(2), uint8(3), uint8(4)},
+	}
+	data, err := p.EncodeAccount("Counter", fields)
+	if err != nil {
+		t.Fatalf("EncodeAccount() error = %v", err)
+	}
+	// discriminator, authority, count, mode, history, seed
+	if want := 8 + 32 + 8 + 9 + 12 + 4; len(data) != want {
+		t.Fatalf("EncodeAccount() len = %v, want %v", len(data), want)
+	}
+
+	name, got, err := p.DecodeAccount(data)
+	if err != nil {
+		t.Fatalf("DecodeAccount() error = %v", err)
+	}
+	if name != "Counter" {
+		t.Errorf("DecodeAccount() name = %v, want Counter", name)
+	}
+	if !reflect.DeepEqual(got, fields) {
+		t.Errorf("DecodeAccount() = %v, want %v", got, fields)
+	}
+
+	fields["mode"] = "Down"
+	data, err = p.EncodeAccount("Counter", fields)
+	if err != nil {
+		t.Fatalf("EncodeAccount() error = %v", err)
+	}
+	got, err = p.DecodeAccountAs("Counter", data)
+	if err != nil {
+		t.Fatalf("DecodeAccountAs() error = %v", err)
+	}
+	if want := map[string]interface{}{"Down": nil}; !reflect.DeepEqual(got["mode"], want) {
+		t.Errorf("DecodeAccountAs() mode = %v, want %v", got["mode"], want)
+	}
+
+	data[0]++
+	if _, err := p.DecodeAccountAs("Counter", data); err == nil {
+		t.Errorf("DecodeAccountAs() with a wrong discriminator should fail")
+	}
+}
+
+func TestError(t *testing.T) {
+	p := loadTestProgram(t, "testdata/counter.json")
+	if got, ok := p.Error(6000); !ok || got.Name != "Overflow" || got.Msg != "count overflows" {
+		t.Errorf("Error() = %v, %v", got, ok)
+	}
+	if _, ok := p.Error(6001); ok {
+		t.Errorf("Error() of an unknown code should not be found")
+	}
+}
+
+func TestParse(t *testing.T) {
+	p := loadTestProgram(t, "testdata/counter.json")
+	p.Register()
+
+	ins, err := p.BuildInstruction("set_config", map[string]interface{}{
+		"config": map[string]interface{}{"step": 2, "limit": 5},
+	}, map[string]common.PublicKey{"counter": testCounter, "authority": testAuthority})
+	if err != nil {
+		t.Fatalf("failed to build instruction, err: %v", err)
+	}
+	ins.Accounts = append(ins.Accounts, types.AccountMeta{PubKey: testReferrer})
+
+	got, err := types.ParseInstruction(ins)
+	if err != nil {
+		t.Fatalf("ParseInstruction() error = %v", err)
+	}
+	want := types.ParsedInstruction{
+		Program:   "counter",
+		ProgramID: testProgramID.ToBase58(),
+		Parsed: &types.InstructionInfo{
+			InstructionType: "set_config",
+			Info: map[string]interface{}{
+				"config":            map[string]interface{}{"step": uint16(2), "limit": "5"},
+				"label":             nil,
+				"counter":           testCounter.ToBase58(),
+				"authority":         testAuthority.ToBase58(),
+				"remainingAccounts": []string{testReferrer.ToBase58()},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseInstruction() = %+v, want %+v", got, want)
+	}
+}
@@ -5,9 +5,18 @@ import (
 	"github.com/portto/solana-go-sdk/types"
 )
 
-// CreateAssociatedTokenAccount is the only instruction in associated token program
-func CreateAssociatedTokenAccount(funder, wallet, tokenMint common.PublicKey) types.Instruction {
-	assosiatedAccount, _, _ := common.FindAssociatedTokenAddress(wallet, tokenMint)
+type Instruction uint8
+
+const (
+	InstructionCreate Instruction = iota
+	InstructionCreateIdempotent
+	InstructionRecoverNested
+)
+
+// CreateAssociatedTokenAccount creates the associated token account of the wallet, it fails if the account exists.
+// tokenProgramID is the owner of the mint, common.TokenProgramID or common.Token2022ProgramID
+func CreateAssociatedTokenAccount(funder, wallet, tokenMint, tokenProgramID common.PublicKey) types.Instruction {
+	assosiatedAccount, _, _ := common.FindAssociatedTokenAddress(wallet, tokenMint, tokenProgramID)
 	return types.Instruction{
 		ProgramID: common.SPLAssociatedTokenAccountProgramID,
 		Accounts: []types.AccountMeta{
@@ -16,9 +25,48 @@ func CreateAssociatedTokenAccount(funder, wallet, tokenMint common.PublicKey) ty
 			{PubKey: wallet, IsSigner: false, IsWritable: false},
 			{PubKey: tokenMint, IsSigner: false, IsWritable: false},
 			{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
-			{PubKey: common.TokenProgramID, IsSigner: false, IsWritable: false},
+			{PubKey: tokenProgramID, IsSigner: false, IsWritable: false},
 			{PubKey: common.SysVarRentPubkey, IsSigner: false, IsWritable: false},
 		},
 		Data: []byte{},
 	}
 }
+
+// CreateIdempotent is CreateAssociatedTokenAccount but it succeeds if the account exists with the same owner
+func CreateIdempotent(funder, wallet, tokenMint, tokenProgramID common.PublicKey) types.Instruction {
+	assosiatedAccount, _, _ := common.FindAssociatedTokenAddress(wallet, tokenMint, tokenProgramID)
+	return types.Instruction{
+		ProgramID: common.SPLAssociatedTokenAccountProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: funder, IsSigner: true, IsWritable: true},
+			{PubKey: assosiatedAccount, IsSigner: false, IsWritable: true},
+			{PubKey: wallet, IsSigner: false, IsWritable: false},
+			{PubKey: tokenMint, IsSigner: false, IsWritable: false},
+			{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+			{PubKey: tokenProgramID, IsSigner: false, IsWritable: false},
+		},
+		Data: []byte{byte(InstructionCreateIdempotent)},
+	}
+}
+
+// RecoverNested transfers the tokens of nestedMint owned by the wallet's associated token account of ownerMint
+// to the wallet's associated token account of nestedMint, and closes the nested account. Both mints must be
+// owned by tokenProgramID.
+func RecoverNested(wallet, ownerMint, nestedMint, tokenProgramID common.PublicKey) types.Instruction {
+	ownerAssociatedAccount, _, _ := common.FindAssociatedTokenAddress(wallet, ownerMint, tokenProgramID)
+	destinationAssociatedAccount, _, _ := common.FindAssociatedTokenAddress(wallet, nestedMint, tokenProgramID)
+	nestedAssociatedAccount, _, _ := common.FindAssociatedTokenAddress(ownerAssociatedAccount, nestedMint, tokenProgramID)
+	return types.Instruction{
+		ProgramID: common.SPLAssociatedTokenAccountProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: nestedAssociatedAccount, IsSigner: false, IsWritable: true},
+			{PubKey: nestedMint, IsSigner: false, IsWritable: false},
+			{PubKey: destinationAssociatedAccount, IsSigner: false, IsWritable: true},
+			{PubKey: ownerAssociatedAccount, IsSigner: false, IsWritable: false},
+			{PubKey: ownerMint, IsSigner: false, IsWritable: false},
+			{PubKey: wallet, IsSigner: true, IsWritable: true},
+			{PubKey: tokenProgramID, IsSigner: false, IsWritable: false},
+		},
+		Data: []byte{byte(InstructionRecoverNested)},
+	}
+}
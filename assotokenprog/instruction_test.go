@@ -42,9 +42,94 @@ func TestCreateAssociatedTokenAccount(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := CreateAssociatedTokenAccount(tt.args.funder, tt.args.wallet, tt.args.tokenMint); !reflect.DeepEqual(got, tt.want) {
+			if got := CreateAssociatedTokenAccount(tt.args.funder, tt.args.wallet, tt.args.tokenMint, common.TokenProgramID); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("CreateAssociatedTokenAccount() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestCreateIdempotent(t *testing.T) {
+	type args struct {
+		funder         common.PublicKey
+		wallet         common.PublicKey
+		tokenMint      common.PublicKey
+		tokenProgramID common.PublicKey
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				funder:         common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+				wallet:         common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				tokenMint:      common.PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH"),
+				tokenProgramID: common.Token2022ProgramID,
+			},
+			want: types.Instruction{
+				ProgramID: common.SPLAssociatedTokenAccountProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: true, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("Zt9aHhoLTH4d4MBacxVEkXscdXrA4pof2vvsnmDaWRL"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH"), IsSigner: false, IsWritable: false},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.Token2022ProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CreateIdempotent(tt.args.funder, tt.args.wallet, tt.args.tokenMint, tt.args.tokenProgramID); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateIdempotent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecoverNested(t *testing.T) {
+	type args struct {
+		wallet         common.PublicKey
+		ownerMint      common.PublicKey
+		nestedMint     common.PublicKey
+		tokenProgramID common.PublicKey
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				wallet:         common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				ownerMint:      common.PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH"),
+				nestedMint:     common.PublicKeyFromString("G1dYC47buM23b4kdWsa7utfEGM95t2LL3fZn535W5pYC"),
+				tokenProgramID: common.TokenProgramID,
+			},
+			want: types.Instruction{
+				ProgramID: common.SPLAssociatedTokenAccountProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("8skThPzrCGYykQQShuGnr5gMUDmpHNyRWg8xqeVSzEze"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("G1dYC47buM23b4kdWsa7utfEGM95t2LL3fZn535W5pYC"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("FdSpVHRSuVqmfQRRV14LC7gSHaVxF2GF5mVUVhCAqKZW"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("HLzppk6ohPg9Ab99XTFhsa6FcG14Au3rTijGe9c8QHp1"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: true},
+					{PubKey: common.TokenProgramID, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RecoverNested(tt.args.wallet, tt.args.ownerMint, tt.args.nestedMint, tt.args.tokenProgramID); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RecoverNested() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
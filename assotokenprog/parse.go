@@ -1,19 +1,66 @@
 package assotokenprog
 
-import "github.com/portto/solana-go-sdk/types"
+import (
+	"fmt"
 
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func init() {
+	types.RegisterInstructionParser(common.SPLAssociatedTokenAccountProgramID, ParseAssocToken)
+}
+
+// ParseAssocToken decodes the instruction by its data, the legacy create instruction has empty data
 func ParseAssocToken(ins types.Instruction) (types.ParsedInstruction, error) {
 	var parsedInstruction types.ParsedInstruction
 	var err error
-	instructionType := "create"
-	parsedInfo := map[string]interface{}{
-		"source":        ins.Accounts[0].PubKey.ToBase58(),
-		"account":       ins.Accounts[1].PubKey.ToBase58(),
-		"wallet":        ins.Accounts[2].PubKey.ToBase58(),
-		"mint":          ins.Accounts[3].PubKey.ToBase58(),
-		"systemProgram": ins.Accounts[4].PubKey.ToBase58(),
-		"tokenProgram":  ins.Accounts[5].PubKey.ToBase58(),
-		"rentSysvar":    ins.Accounts[6].PubKey.ToBase58(),
+	instruction := InstructionCreate
+	if len(ins.Data) > 0 {
+		instruction = Instruction(ins.Data[0])
+	}
+
+	var instructionType string
+	var parsedInfo map[string]interface{}
+	switch instruction {
+	case InstructionCreate, InstructionCreateIdempotent:
+		if len(ins.Accounts) < 6 {
+			return parsedInstruction, fmt.Errorf("associated token instruction %d needs 6 accounts, got %d", instruction, len(ins.Accounts))
+		}
+		instructionType = "create"
+		if instruction == InstructionCreateIdempotent {
+			instructionType = "createIdempotent"
+		}
+		parsedInfo = map[string]interface{}{
+			"source":        ins.Accounts[0].PubKey.ToBase58(),
+			"account":       ins.Accounts[1].PubKey.ToBase58(),
+			"wallet":        ins.Accounts[2].PubKey.ToBase58(),
+			"mint":          ins.Accounts[3].PubKey.ToBase58(),
+			"systemProgram": ins.Accounts[4].PubKey.ToBase58(),
+			"tokenProgram":  ins.Accounts[5].PubKey.ToBase58(),
+		}
+		// the rent sysvar is no longer required by the program
+		if len(ins.Accounts) > 6 {
+			parsedInfo["rentSysvar"] = ins.Accounts[6].PubKey.ToBase58()
+		}
+		break
+	case InstructionRecoverNested:
+		if len(ins.Accounts) < 7 {
+			return parsedInstruction, fmt.Errorf("associated token instruction %d needs 7 accounts, got %d", instruction, len(ins.Accounts))
+		}
+		instructionType = "recoverNested"
+		parsedInfo = map[string]interface{}{
+			"nestedSource": ins.Accounts[0].PubKey.ToBase58(),
+			"nestedMint":   ins.Accounts[1].PubKey.ToBase58(),
+			"destination":  ins.Accounts[2].PubKey.ToBase58(),
+			"nestedOwner":  ins.Accounts[3].PubKey.ToBase58(),
+			"ownerMint":    ins.Accounts[4].PubKey.ToBase58(),
+			"wallet":       ins.Accounts[5].PubKey.ToBase58(),
+			"tokenProgram": ins.Accounts[6].PubKey.ToBase58(),
+		}
+		break
+	default:
+		return parsedInstruction, fmt.Errorf("unknown associated token instruction %d", instruction)
 	}
 	parsedInstruction.Parsed = &types.InstructionInfo{
 		Info:            parsedInfo,
@@ -0,0 +1,87 @@
+package assotokenprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func TestParseAssocToken(t *testing.T) {
+	funder := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	wallet := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	ownerMint := common.PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH")
+	nestedMint := common.PublicKeyFromString("G1dYC47buM23b4kdWsa7utfEGM95t2LL3fZn535W5pYC")
+
+	tests := []struct {
+		ins      types.Instruction
+		wantType string
+		wantInfo map[string]interface{}
+		wantErr  bool
+	}{
+		{
+			ins:      CreateAssociatedTokenAccount(funder, wallet, ownerMint, common.TokenProgramID),
+			wantType: "create",
+			wantInfo: map[string]interface{}{
+				"source":        funder.ToBase58(),
+				"account":       "HLzppk6ohPg9Ab99XTFhsa6FcG14Au3rTijGe9c8QHp1",
+				"wallet":        wallet.ToBase58(),
+				"mint":          ownerMint.ToBase58(),
+				"systemProgram": common.SystemProgramID.ToBase58(),
+				"tokenProgram":  common.TokenProgramID.ToBase58(),
+				"rentSysvar":    common.SysVarRentPubkey.ToBase58(),
+			},
+		},
+		{
+			ins:      CreateIdempotent(funder, wallet, ownerMint, common.Token2022ProgramID),
+			wantType: "createIdempotent",
+			wantInfo: map[string]interface{}{
+				"source":        funder.ToBase58(),
+				"account":       "Zt9aHhoLTH4d4MBacxVEkXscdXrA4pof2vvsnmDaWRL",
+				"wallet":        wallet.ToBase58(),
+				"mint":          ownerMint.ToBase58(),
+				"systemProgram": common.SystemProgramID.ToBase58(),
+				"tokenProgram":  common.Token2022ProgramID.ToBase58(),
+			},
+		},
+		{
+			ins:      RecoverNested(wallet, ownerMint, nestedMint, common.TokenProgramID),
+			wantType: "recoverNested",
+			wantInfo: map[string]interface{}{
+				"nestedSource": "8skThPzrCGYykQQShuGnr5gMUDmpHNyRWg8xqeVSzEze",
+				"nestedMint":   nestedMint.ToBase58(),
+				"destination":  "FdSpVHRSuVqmfQRRV14LC7gSHaVxF2GF5mVUVhCAqKZW",
+				"nestedOwner":  "HLzppk6ohPg9Ab99XTFhsa6FcG14Au3rTijGe9c8QHp1",
+				"ownerMint":    ownerMint.ToBase58(),
+				"wallet":       wallet.ToBase58(),
+				"tokenProgram": common.TokenProgramID.ToBase58(),
+			},
+		},
+		{
+			ins: types.Instruction{
+				ProgramID: common.SPLAssociatedTokenAccountProgramID,
+				Data:      []byte{3},
+			},
+			wantType: "unknown",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantType, func(t *testing.T) {
+			got, err := ParseAssocToken(tt.ins)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAssocToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Parsed.InstructionType != tt.wantType {
+				t.Errorf("ParseAssocToken() type = %v, want %v", got.Parsed.InstructionType, tt.wantType)
+			}
+			if !reflect.DeepEqual(got.Parsed.Info, tt.wantInfo) {
+				t.Errorf("ParseAssocToken() info = %v, want %v", got.Parsed.Info, tt.wantInfo)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Package borsh encodes and decodes go values in the borsh format most programs use for their
+// instruction and account data, https://borsh.io
+//
+// The go types map to borsh as:
+//
+//	bool                        u8, 0 or 1
+//	int8 ~ int64, uint8 ~ uint64 little endian integers
+//	float32, float64            little endian IEEE 754
+//	Uint128, Int128             little endian 128-bit integers
+//	string                      u32 length + utf-8 bytes
+//	slice                       u32 length + elements
+//	array                       elements, no length
+//	pointer                     Option, u8 tag + the value if it is not nil
+//	map                         u32 length + key value pairs sorted by key
+//	struct                      fields in order
+//	struct with an Enum first   enum, u8 variant index + the field of the variant
+//
+// int and uint don't have a fixed size so they are not supported.
+// Unexported fields are ignored. The behavior of a field can be changed by its tag:
+//
+//	borsh:"skip"     the field is not encoded, it is left as zero when decoding
+//	borsh:"coption"  a pointer is encoded as a u32 tag followed by the value, zero if it is nil,
+//	                 which is the COption layout of the spl programs
+//	borsh:"nonzero"  a pointer is encoded as the value without a tag, an all zero value is nil,
+//	                 which is the OptionalNonZeroPubkey layout of the spl programs
+//	borsh:"u64len"   a string or a slice has a u64 length, which is how bincode encodes them
+//	                 for the native programs, it applies to the value of an Option as well
+//	borsh:"rest"     a string or a slice has no length and takes the rest of the data,
+//	                 it must be the last field
+package borsh
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// Enum is the variant index of an enum.
+// A struct whose first field is an Enum is encoded as the index followed by the field of the variant,
+// the fields after the Enum are the variants in order. Use struct{} for a variant without data.
+type Enum uint8
+
+// Uint128 is an unsigned 128-bit integer
+type Uint128 struct {
+	Lo uint64
+	Hi uint64
+}
+
+// Int128 is a signed 128-bit integer in two's complement
+type Int128 struct {
+	Lo uint64
+	Hi int64
+}
+
+var (
+	enumType   = reflect.TypeOf(Enum(0))
+	mask64     = new(big.Int).SetUint64(math.MaxUint64)
+	two128     = new(big.Int).Lsh(big.NewInt(1), 128)
+	maxUint128 = new(big.Int).Sub(two128, big.NewInt(1))
+	maxInt128  = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+	minInt128  = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 127))
+)
+
+func bigFromUint(lo, hi uint64) *big.Int {
+	n := new(big.Int).SetUint64(hi)
+	n.Lsh(n, 64)
+	return n.Or(n, new(big.Int).SetUint64(lo))
+}
+
+// BigInt returns the value as a big.Int
+func (u Uint128) BigInt() *big.Int {
+	return bigFromUint(u.Lo, u.Hi)
+}
+
+// String returns the value in decimal
+func (u Uint128) String() string {
+	return u.BigInt().String()
+}
+
+// Uint128FromBigInt converts a big.Int, it returns false if the value is out of range
+func Uint128FromBigInt(n *big.Int) (Uint128, bool) {
+	if n.Sign() < 0 || n.Cmp(maxUint128) > 0 {
+		return Uint128{}, false
+	}
+	return Uint128{
+		Lo: new(big.Int).And(n, mask64).Uint64(),
+		Hi: new(big.Int).Rsh(n, 64).Uint64(),
+	}, true
+}
+
+// BigInt returns the value as a big.Int
+func (i Int128) BigInt() *big.Int {
+	n := bigFromUint(i.Lo, uint64(i.Hi))
+	if i.Hi < 0 {
+		n.Sub(n, two128)
+	}
+	return n
+}
+
+// String returns the value in decimal
+func (i Int128) String() string {
+	return i.BigInt().String()
+}
+
+// Int128FromBigInt converts a big.Int, it returns false if the value is out of range
+func Int128FromBigInt(n *big.Int) (Int128, bool) {
+	if n.Cmp(minInt128) < 0 || n.Cmp(maxInt128) > 0 {
+		return Int128{}, false
+	}
+	u := new(big.Int).Set(n)
+	if u.Sign() < 0 {
+		u.Add(u, two128)
+	}
+	return Int128{
+		Lo: new(big.Int).And(u, mask64).Uint64(),
+		Hi: int64(new(big.Int).Rsh(u, 64).Uint64()),
+	}, true
+}
+
+type fieldOptions struct {
+	skip    bool
+	coption bool
+	nonzero bool
+	u64len  bool
+	rest    bool
+}
+
+func parseTag(tag string) fieldOptions {
+	var o fieldOptions
+	for _, s := range strings.Split(tag, ",") {
+		switch strings.TrimSpace(s) {
+		case "skip":
+			o.skip = true
+		case "coption":
+			o.coption = true
+		case "nonzero":
+			o.nonzero = true
+		case "u64len":
+			o.u64len = true
+		case "rest":
+			o.rest = true
+		}
+	}
+	return o
+}
+
+type field struct {
+	index   int
+	name    string
+	options fieldOptions
+}
+
+// fields returns the encoded fields of a struct type and whether it is an enum
+func fields(t reflect.Type) ([]field, bool) {
+	fs := make([]field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		o := parseTag(f.Tag.Get("borsh"))
+		if o.skip {
+			continue
+		}
+		fs = append(fs, field{index: i, name: f.Name, options: o})
+	}
+	isEnum := len(fs) > 0 && t.Field(fs[0].index).Type == enumType
+	return fs, isEnum
+}
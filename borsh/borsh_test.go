@@ -0,0 +1,273 @@
+package borsh
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+type testVariantB struct {
+	X uint16
+	Y string
+}
+
+type testEnum struct {
+	Enum Enum
+	A    struct{}
+	B    testVariantB
+	C    *uint8
+}
+
+type testStruct struct {
+	Bool    bool
+	I8      int8
+	I16     int16
+	I32     int32
+	I64     int64
+	U64     uint64
+	F32     float32
+	Str     string
+	Bytes   []byte
+	Array   [2]uint16
+	Vec     []testVariantB
+	Option  *uint32
+	None    *uint32
+	Map     map[string]uint8
+	U128    Uint128
+	Skipped uint64 `borsh:"skip"`
+	ignored uint64
+}
+
+func u32(n uint32) *uint32 { return &n }
+func u8(n uint8) *uint8    { return &n }
+
+func TestMarshalUnmarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		// v is a pointer to the value so it can be decoded to the same type
+		v    interface{}
+		want []byte
+	}{
+		{
+			name: "struct",
+			v: &testStruct{
+				Bool:   true,
+				I8:     -2,
+				I16:    -3,
+				I32:    4,
+				I64:    -5,
+				U64:    6,
+				F32:    1.5,
+				Str:    "hi",
+				Bytes:  []byte{7, 8},
+				Array:  [2]uint16{9, 10},
+				Vec:    []testVariantB{{X: 11, Y: "a"}},
+				Option: u32(12),
+				Map:    map[string]uint8{"b": 2, "a": 1},
+				U128:   Uint128{Lo: 13, Hi: 14},
+			},
+			want: []byte{
+				1,
+				0xfe,
+				0xfd, 0xff,
+				4, 0, 0, 0,
+				0xfb, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+				6, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 0xc0, 0x3f,
+				2, 0, 0, 0, 'h', 'i',
+				2, 0, 0, 0, 7, 8,
+				9, 0, 10, 0,
+				1, 0, 0, 0, 11, 0, 1, 0, 0, 0, 'a',
+				1, 12, 0, 0, 0,
+				0,
+				2, 0, 0, 0, 1, 0, 0, 0, 'a', 1, 1, 0, 0, 0, 'b', 2,
+				13, 0, 0, 0, 0, 0, 0, 0, 14, 0, 0, 0, 0, 0, 0, 0,
+			},
+		},
+		{
+			name: "unit variant",
+			v:    &testEnum{Enum: 0},
+			want: []byte{0},
+		},
+		{
+			name: "struct variant",
+			v:    &testEnum{Enum: 1, B: testVariantB{X: 1, Y: "z"}},
+			want: []byte{1, 1, 0, 1, 0, 0, 0, 'z'},
+		},
+		{
+			name: "option variant",
+			v:    &testEnum{Enum: 2, C: u8(3)},
+			want: []byte{2, 1, 3},
+		},
+		{
+			name: "tags",
+			v: &struct {
+				COption   *[2]byte `borsh:"coption"`
+				None      *[2]byte `borsh:"coption"`
+				NonZero   *[2]byte `borsh:"nonzero"`
+				Zero      *[2]byte `borsh:"nonzero"`
+				Seed      string   `borsh:"u64len"`
+				OptionU64 *[]uint8 `borsh:"u64len"`
+				Rest      []byte   `borsh:"rest"`
+			}{
+				COption:   &[2]byte{1, 2},
+				NonZero:   &[2]byte{0, 6},
+				Seed:      "s",
+				OptionU64: &[]uint8{7},
+				Rest:      []byte{3, 4, 5},
+			},
+			want: []byte{
+				1, 0, 0, 0, 1, 2,
+				0, 0, 0, 0, 0, 0,
+				0, 6,
+				0, 0,
+				1, 0, 0, 0, 0, 0, 0, 0, 's',
+				1, 1, 0, 0, 0, 0, 0, 0, 0, 7,
+				3, 4, 5,
+			},
+		},
+		{
+			name: "rest of a slice",
+			v: &struct {
+				Instruction uint8
+				Rest        []uint16 `borsh:"rest"`
+			}{
+				Instruction: 1,
+				Rest:        []uint16{2, 3},
+			},
+			want: []byte{1, 2, 0, 3, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(reflect.ValueOf(tt.v).Elem().Interface())
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Marshal() = %v, want %v", got, tt.want)
+			}
+			v := reflect.New(reflect.TypeOf(tt.v).Elem())
+			n, err := UnmarshalPrefix(got, v.Interface())
+			if err != nil {
+				t.Fatalf("UnmarshalPrefix() error = %v", err)
+			}
+			if n != len(got) {
+				t.Errorf("UnmarshalPrefix() read %v bytes, want %v", n, len(got))
+			}
+			if !reflect.DeepEqual(v.Interface(), tt.v) {
+				t.Errorf("UnmarshalPrefix() = %+v, want %+v", v.Interface(), tt.v)
+			}
+		})
+	}
+}
+
+func TestMarshalError(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+	}{
+		{name: "int", v: 1},
+		{name: "unknown variant", v: testEnum{Enum: 3}},
+		{name: "rest is not last", v: struct {
+			A []byte `borsh:"rest"`
+			B uint8
+		}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Marshal(tt.v); err == nil {
+				t.Errorf("Marshal() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestUnmarshalError(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		v    interface{}
+	}{
+		{name: "not a pointer", data: []byte{1}, v: uint8(0)},
+		{name: "not enough data", data: []byte{1, 2}, v: new(uint32)},
+		{name: "invalid bool", data: []byte{2}, v: new(bool)},
+		{name: "invalid option", data: []byte{2, 0}, v: new(*uint8)},
+		{name: "invalid utf-8", data: []byte{1, 0, 0, 0, 0xff}, v: new(string)},
+		{name: "length too long", data: []byte{0xff, 0xff, 0, 0, 1}, v: new([]uint16)},
+		{name: "unknown variant", data: []byte{3}, v: new(testEnum)},
+		{name: "partial rest", data: []byte{1, 2, 0, 3}, v: &struct {
+			Instruction uint8
+			Rest        []uint16 `borsh:"rest"`
+		}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Unmarshal(tt.data, tt.v); err == nil {
+				t.Errorf("Unmarshal() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestUnmarshalIgnoresTrailingData(t *testing.T) {
+	var v struct {
+		Instruction uint8
+	}
+	if err := Unmarshal([]byte{3, 1, 2}, &v); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if v.Instruction != 3 {
+		t.Errorf("Unmarshal() = %v, want 3", v.Instruction)
+	}
+}
+
+func TestInt128(t *testing.T) {
+	tests := []struct {
+		name string
+		n    string
+	}{
+		{name: "zero", n: "0"},
+		{name: "positive", n: "18446744073709551616"},
+		{name: "negative", n: "-1"},
+		{name: "min", n: "-170141183460469231731687303715884105728"},
+		{name: "max", n: "170141183460469231731687303715884105727"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, _ := new(big.Int).SetString(tt.n, 10)
+			i, ok := Int128FromBigInt(n)
+			if !ok {
+				t.Fatalf("Int128FromBigInt() = false")
+			}
+			if got := i.String(); got != tt.n {
+				t.Errorf("Int128.String() = %v, want %v", got, tt.n)
+			}
+		})
+	}
+
+	if i, _ := Int128FromBigInt(big.NewInt(-1)); i != (Int128{Lo: ^uint64(0), Hi: -1}) {
+		t.Errorf("Int128FromBigInt(-1) = %+v", i)
+	}
+	tooLarge, _ := new(big.Int).SetString("170141183460469231731687303715884105728", 10)
+	if _, ok := Int128FromBigInt(tooLarge); ok {
+		t.Errorf("Int128FromBigInt() = true, want false")
+	}
+}
+
+func TestUint128(t *testing.T) {
+	n, _ := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+	u, ok := Uint128FromBigInt(n)
+	if !ok || u != (Uint128{Lo: ^uint64(0), Hi: ^uint64(0)}) {
+		t.Fatalf("Uint128FromBigInt() = %+v, %v", u, ok)
+	}
+	if u.BigInt().Cmp(n) != 0 {
+		t.Errorf("Uint128.BigInt() = %v, want %v", u.BigInt(), n)
+	}
+	if _, ok := Uint128FromBigInt(new(big.Int).Add(n, big.NewInt(1))); ok {
+		t.Errorf("Uint128FromBigInt() = true, want false")
+	}
+	if _, ok := Uint128FromBigInt(big.NewInt(-1)); ok {
+		t.Errorf("Uint128FromBigInt() = true, want false")
+	}
+}
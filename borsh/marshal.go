@@ -0,0 +1,207 @@
+package borsh
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// Marshal encodes v in borsh
+func Marshal(v interface{}) ([]byte, error) {
+	e := encoder{buf: make([]byte, 0, 64)}
+	if err := e.encode(reflect.ValueOf(v), fieldOptions{}); err != nil {
+		return nil, err
+	}
+	return e.buf, nil
+}
+
+// MustMarshal is Marshal but panics on error, for the values whose types are known to be supported
+func MustMarshal(v interface{}) []byte {
+	data, err := Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) putUint(n uint64, size int) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], n)
+	e.buf = append(e.buf, b[:size]...)
+}
+
+func (e *encoder) putLen(n int, o fieldOptions) {
+	if o.u64len {
+		e.putUint(uint64(n), 8)
+		return
+	}
+	e.putUint(uint64(n), 4)
+}
+
+func (e *encoder) encode(v reflect.Value, o fieldOptions) error {
+	if !v.IsValid() {
+		return fmt.Errorf("borsh: unsupported nil value")
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			e.buf = append(e.buf, 1)
+		} else {
+			e.buf = append(e.buf, 0)
+		}
+	case reflect.Int8:
+		e.putUint(uint64(v.Int()), 1)
+	case reflect.Int16:
+		e.putUint(uint64(v.Int()), 2)
+	case reflect.Int32:
+		e.putUint(uint64(v.Int()), 4)
+	case reflect.Int64:
+		e.putUint(uint64(v.Int()), 8)
+	case reflect.Uint8:
+		e.putUint(v.Uint(), 1)
+	case reflect.Uint16:
+		e.putUint(v.Uint(), 2)
+	case reflect.Uint32:
+		e.putUint(v.Uint(), 4)
+	case reflect.Uint64:
+		e.putUint(v.Uint(), 8)
+	case reflect.Float32:
+		e.putUint(uint64(math.Float32bits(float32(v.Float()))), 4)
+	case reflect.Float64:
+		e.putUint(math.Float64bits(v.Float()), 8)
+	case reflect.String:
+		if !o.rest {
+			e.putLen(v.Len(), o)
+		}
+		e.buf = append(e.buf, v.String()...)
+	case reflect.Slice:
+		if !o.rest {
+			e.putLen(v.Len(), o)
+		}
+		return e.encodeElems(v)
+	case reflect.Array:
+		return e.encodeElems(v)
+	case reflect.Ptr:
+		if o.coption {
+			return e.encodeCOption(v)
+		}
+		if o.nonzero {
+			if v.IsNil() {
+				return e.encode(reflect.New(v.Type().Elem()).Elem(), fieldOptions{})
+			}
+			return e.encode(v.Elem(), fieldOptions{})
+		}
+		if v.IsNil() {
+			e.buf = append(e.buf, 0)
+			return nil
+		}
+		e.buf = append(e.buf, 1)
+		return e.encode(v.Elem(), fieldOptions{u64len: o.u64len})
+	case reflect.Map:
+		return e.encodeMap(v, o)
+	case reflect.Struct:
+		return e.encodeStruct(v)
+	default:
+		return fmt.Errorf("borsh: unsupported type %v", v.Type())
+	}
+	return nil
+}
+
+func (e *encoder) encodeElems(v reflect.Value) error {
+	if v.Type().Elem().Kind() == reflect.Uint8 {
+		if v.Kind() == reflect.Slice {
+			e.buf = append(e.buf, v.Bytes()...)
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			e.buf = append(e.buf, byte(v.Index(i).Uint()))
+		}
+		return nil
+	}
+	for i := 0; i < v.Len(); i++ {
+		if err := e.encode(v.Index(i), fieldOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *encoder) encodeCOption(v reflect.Value) error {
+	if v.IsNil() {
+		e.putUint(0, 4)
+		return e.encode(reflect.New(v.Type().Elem()).Elem(), fieldOptions{})
+	}
+	e.putUint(1, 4)
+	return e.encode(v.Elem(), fieldOptions{})
+}
+
+func (e *encoder) encodeMap(v reflect.Value, o fieldOptions) error {
+	type entry struct {
+		key   []byte
+		value reflect.Value
+		k     reflect.Value
+	}
+	entries := make([]entry, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		sub := encoder{}
+		if err := sub.encode(iter.Key(), fieldOptions{}); err != nil {
+			return err
+		}
+		entries = append(entries, entry{key: sub.buf, k: iter.Key(), value: iter.Value()})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return lessKey(entries[i].k, entries[j].k, entries[i].key, entries[j].key)
+	})
+
+	e.putLen(len(entries), o)
+	for _, entry := range entries {
+		e.buf = append(e.buf, entry.key...)
+		if err := e.encode(entry.value, fieldOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lessKey orders keys as rust does for the numbers and the strings, other keys are ordered by their encoding
+func lessKey(a, b reflect.Value, encodedA, encodedB []byte) bool {
+	switch a.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return a.Uint() < b.Uint()
+	case reflect.String:
+		return a.String() < b.String()
+	}
+	return bytes.Compare(encodedA, encodedB) < 0
+}
+
+func (e *encoder) encodeStruct(v reflect.Value) error {
+	fs, isEnum := fields(v.Type())
+	if isEnum {
+		index := int(v.Field(fs[0].index).Uint())
+		if index+1 >= len(fs) {
+			return fmt.Errorf("borsh: %v has no variant %d", v.Type(), index)
+		}
+		e.buf = append(e.buf, byte(index))
+		variant := fs[index+1]
+		return e.encode(v.Field(variant.index), variant.options)
+	}
+	for i, f := range fs {
+		if f.options.rest && i != len(fs)-1 {
+			return fmt.Errorf("borsh: rest field %v.%v is not the last field", v.Type(), f.name)
+		}
+		if err := e.encode(v.Field(f.index), f.options); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+package borsh
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"unicode/utf8"
+)
+
+// ErrNotEnoughData is returned when the data ends before the value
+var ErrNotEnoughData = errors.New("borsh: not enough data")
+
+// Unmarshal decodes the data into v, which must be a non-nil pointer.
+// Bytes after the value are ignored, as the programs do with their instruction data,
+// use UnmarshalPrefix to know how many bytes are read.
+func Unmarshal(data []byte, v interface{}) error {
+	_, err := UnmarshalPrefix(data, v)
+	return err
+}
+
+// UnmarshalPrefix decodes the value at the start of the data into v and returns the number of bytes it reads
+func UnmarshalPrefix(data []byte, v interface{}) (int, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return 0, fmt.Errorf("borsh: unmarshal needs a non-nil pointer, got %T", v)
+	}
+	d := decoder{data: data}
+	if err := d.decode(rv.Elem(), fieldOptions{}); err != nil {
+		return d.pos, err
+	}
+	return d.pos, nil
+}
+
+type decoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *decoder) read(n int) ([]byte, error) {
+	if n < 0 || len(d.data)-d.pos < n {
+		return nil, ErrNotEnoughData
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *decoder) readUint(size int) (uint64, error) {
+	b, err := d.read(size)
+	if err != nil {
+		return 0, err
+	}
+	var buf [8]byte
+	copy(buf[:], b)
+	return binary.LittleEndian.Uint64(buf[:]), nil
+}
+
+func (d *decoder) readLen(o fieldOptions) (int, error) {
+	size := 4
+	if o.u64len {
+		size = 8
+	}
+	n, err := d.readUint(size)
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(math.MaxInt32) {
+		return 0, fmt.Errorf("borsh: length %d is too large", n)
+	}
+	return int(n), nil
+}
+
+func (d *decoder) decode(v reflect.Value, o fieldOptions) error {
+	switch v.Kind() {
+	case reflect.Bool:
+		n, err := d.readUint(1)
+		if err != nil {
+			return err
+		}
+		if n > 1 {
+			return fmt.Errorf("borsh: invalid bool %d", n)
+		}
+		v.SetBool(n == 1)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		size := int(v.Type().Size())
+		n, err := d.readUint(size)
+		if err != nil {
+			return err
+		}
+		// sign extend
+		shift := uint(64 - 8*size)
+		v.SetInt(int64(n<<shift) >> shift)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := d.readUint(int(v.Type().Size()))
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32:
+		n, err := d.readUint(4)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(float64(math.Float32frombits(uint32(n))))
+	case reflect.Float64:
+		n, err := d.readUint(8)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(math.Float64frombits(n))
+	case reflect.String:
+		n := len(d.data) - d.pos
+		if !o.rest {
+			var err error
+			if n, err = d.readLen(o); err != nil {
+				return err
+			}
+		}
+		b, err := d.read(n)
+		if err != nil {
+			return err
+		}
+		if !utf8.Valid(b) {
+			return errors.New("borsh: invalid utf-8 string")
+		}
+		v.SetString(string(b))
+	case reflect.Slice:
+		return d.decodeSlice(v, o)
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b, err := d.read(v.Len())
+			if err != nil {
+				return err
+			}
+			reflect.Copy(v, reflect.ValueOf(b))
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := d.decode(v.Index(i), fieldOptions{}); err != nil {
+				return err
+			}
+		}
+	case reflect.Ptr:
+		return d.decodeOption(v, o)
+	case reflect.Map:
+		return d.decodeMap(v, o)
+	case reflect.Struct:
+		return d.decodeStruct(v)
+	default:
+		return fmt.Errorf("borsh: unsupported type %v", v.Type())
+	}
+	return nil
+}
+
+func (d *decoder) decodeSlice(v reflect.Value, o fieldOptions) error {
+	t := v.Type()
+	if t.Elem().Kind() == reflect.Uint8 {
+		n := len(d.data) - d.pos
+		if !o.rest {
+			var err error
+			if n, err = d.readLen(o); err != nil {
+				return err
+			}
+		}
+		b, err := d.read(n)
+		if err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(t, n, n)
+		reflect.Copy(s, reflect.ValueOf(b))
+		v.Set(s)
+		return nil
+	}
+	if o.rest {
+		return d.decodeRest(v)
+	}
+	n, err := d.readLen(o)
+	if err != nil {
+		return err
+	}
+	// every element takes a byte at least unless it is zero sized, don't trust the length to allocate
+	if t.Elem().Size() > 0 && n > len(d.data)-d.pos {
+		return ErrNotEnoughData
+	}
+	s := reflect.MakeSlice(t, n, n)
+	for i := 0; i < n; i++ {
+		if err := d.decode(s.Index(i), fieldOptions{}); err != nil {
+			return err
+		}
+	}
+	v.Set(s)
+	return nil
+}
+
+// decodeRest decodes elements until the data ends
+func (d *decoder) decodeRest(v reflect.Value) error {
+	t := v.Type()
+	if t.Elem().Size() == 0 {
+		return fmt.Errorf("borsh: rest doesn't support zero sized elements, got %v", t)
+	}
+	s := reflect.MakeSlice(t, 0, 0)
+	for d.pos < len(d.data) {
+		elem := reflect.New(t.Elem()).Elem()
+		if err := d.decode(elem, fieldOptions{}); err != nil {
+			return err
+		}
+		s = reflect.Append(s, elem)
+	}
+	v.Set(s)
+	return nil
+}
+
+func (d *decoder) decodeOption(v reflect.Value, o fieldOptions) error {
+	var some bool
+	if o.coption {
+		tag, err := d.readUint(4)
+		if err != nil {
+			return err
+		}
+		if tag > 1 {
+			return fmt.Errorf("borsh: invalid coption tag %d", tag)
+		}
+		some = tag == 1
+		// the value is there even if it is none
+		value := reflect.New(v.Type().Elem())
+		if err := d.decode(value.Elem(), fieldOptions{}); err != nil {
+			return err
+		}
+		if some {
+			v.Set(value)
+		} else {
+			v.Set(reflect.Zero(v.Type()))
+		}
+		return nil
+	}
+	if o.nonzero {
+		value := reflect.New(v.Type().Elem())
+		if err := d.decode(value.Elem(), fieldOptions{}); err != nil {
+			return err
+		}
+		if value.Elem().IsZero() {
+			v.Set(reflect.Zero(v.Type()))
+		} else {
+			v.Set(value)
+		}
+		return nil
+	}
+
+	tag, err := d.readUint(1)
+	if err != nil {
+		return err
+	}
+	if tag > 1 {
+		return fmt.Errorf("borsh: invalid option tag %d", tag)
+	}
+	if tag == 0 {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	value := reflect.New(v.Type().Elem())
+	if err := d.decode(value.Elem(), fieldOptions{u64len: o.u64len}); err != nil {
+		return err
+	}
+	v.Set(value)
+	return nil
+}
+
+func (d *decoder) decodeMap(v reflect.Value, o fieldOptions) error {
+	n, err := d.readLen(o)
+	if err != nil {
+		return err
+	}
+	t := v.Type()
+	if n > len(d.data)-d.pos {
+		return ErrNotEnoughData
+	}
+	m := reflect.MakeMapWithSize(t, n)
+	for i := 0; i < n; i++ {
+		key := reflect.New(t.Key()).Elem()
+		if err := d.decode(key, fieldOptions{}); err != nil {
+			return err
+		}
+		value := reflect.New(t.Elem()).Elem()
+		if err := d.decode(value, fieldOptions{}); err != nil {
+			return err
+		}
+		m.SetMapIndex(key, value)
+	}
+	v.Set(m)
+	return nil
+}
+
+func (d *decoder) decodeStruct(v reflect.Value) error {
+	fs, isEnum := fields(v.Type())
+	if isEnum {
+		index, err := d.readUint(1)
+		if err != nil {
+			return err
+		}
+		if int(index)+1 >= len(fs) {
+			return fmt.Errorf("borsh: %v has no variant %d", v.Type(), index)
+		}
+		v.Field(fs[0].index).SetUint(index)
+		variant := fs[index+1]
+		return d.decode(v.Field(variant.index), variant.options)
+	}
+	for i, f := range fs {
+		if f.options.rest && i != len(fs)-1 {
+			return fmt.Errorf("borsh: rest field %v.%v is not the last field", v.Type(), f.name)
+		}
+		if err := d.decode(v.Field(f.index), f.options); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package bpfloaderprog
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+const (
+	// BufferMetadataSize is the size of the buffer header, the program data is written after it
+	BufferMetadataSize = 37
+	// ProgramSize is the size of a program account
+	ProgramSize = 36
+	// ProgramDataMetadataSize is the size of the program data header, the executable is stored after it
+	ProgramDataMetadataSize = 45
+)
+
+type State uint32
+
+const (
+	StateUninitialized State = iota
+	StateBuffer
+	StateProgram
+	StateProgramData
+)
+
+type BufferAccount struct {
+	// Authority is nil if the buffer is immutable
+	Authority *common.PublicKey
+	Data      []byte
+}
+
+type ProgramAccount struct {
+	ProgramDataAddress common.PublicKey
+}
+
+type ProgramDataAccount struct {
+	// Slot is the slot the program was last deployed at
+	Slot uint64
+	// UpgradeAuthority is nil if the program is immutable
+	UpgradeAuthority *common.PublicKey
+	Data             []byte
+}
+
+// bufferLayout is the header of a buffer account. The loader reserves the space of the authority
+// even if it is none, so the header always takes BufferMetadataSize bytes.
+type bufferLayout struct {
+	State     State
+	Authority *common.PublicKey
+}
+
+type programLayout struct {
+	State              State
+	ProgramDataAddress common.PublicKey
+}
+
+// programDataLayout is the header of a program data account, it always takes ProgramDataMetadataSize bytes
+type programDataLayout struct {
+	State            State
+	Slot             uint64
+	UpgradeAuthority *common.PublicKey
+}
+
+// StateFromData returns the state of an account owned by the loader
+func StateFromData(data []byte) (State, error) {
+	var state State
+	if err := borsh.Unmarshal(data, &state); err != nil {
+		return StateUninitialized, errors.New("loader account data size is not enough")
+	}
+	if state > StateProgramData {
+		return state, fmt.Errorf("unknown loader account state %d", state)
+	}
+	return state, nil
+}
+
+func BufferAccountFromData(data []byte) (BufferAccount, error) {
+	if err := checkState(data, StateBuffer, BufferMetadataSize); err != nil {
+		return BufferAccount{}, err
+	}
+	var layout bufferLayout
+	if err := borsh.Unmarshal(data[:BufferMetadataSize], &layout); err != nil {
+		return BufferAccount{}, err
+	}
+	return BufferAccount{
+		Authority: layout.Authority,
+		Data:      data[BufferMetadataSize:],
+	}, nil
+}
+
+func ProgramAccountFromData(data []byte) (ProgramAccount, error) {
+	if err := checkState(data, StateProgram, ProgramSize); err != nil {
+		return ProgramAccount{}, err
+	}
+	var layout programLayout
+	if err := borsh.Unmarshal(data, &layout); err != nil {
+		return ProgramAccount{}, err
+	}
+	return ProgramAccount{
+		ProgramDataAddress: layout.ProgramDataAddress,
+	}, nil
+}
+
+func ProgramDataAccountFromData(data []byte) (ProgramDataAccount, error) {
+	if err := checkState(data, StateProgramData, ProgramDataMetadataSize); err != nil {
+		return ProgramDataAccount{}, err
+	}
+	var layout programDataLayout
+	if err := borsh.Unmarshal(data[:ProgramDataMetadataSize], &layout); err != nil {
+		return ProgramDataAccount{}, err
+	}
+	return ProgramDataAccount{
+		Slot:             layout.Slot,
+		UpgradeAuthority: layout.UpgradeAuthority,
+		Data:             data[ProgramDataMetadataSize:],
+	}, nil
+}
+
+func checkState(data []byte, want State, size int) error {
+	state, err := StateFromData(data)
+	if err != nil {
+		return err
+	}
+	if state != want {
+		return fmt.Errorf("loader account state is %d, expected %d", state, want)
+	}
+	if len(data) < size {
+		return errors.New("loader account data size is not enough")
+	}
+	return nil
+}
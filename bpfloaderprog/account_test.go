@@ -0,0 +1,117 @@
+package bpfloaderprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+func TestBufferAccountFromData(t *testing.T) {
+	authority := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	data := append([]byte{1, 0, 0, 0, 1}, authority.Bytes()...)
+	data = append(data, 0x7f, 'E', 'L', 'F')
+
+	immutable := append([]byte{1, 0, 0, 0, 0}, make([]byte, 32)...)
+
+	type args struct {
+		data []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    BufferAccount
+		wantErr bool
+	}{
+		{
+			args: args{data: data},
+			want: BufferAccount{
+				Authority: &authority,
+				Data:      []byte{0x7f, 'E', 'L', 'F'},
+			},
+		},
+		{
+			name: "immutable",
+			args: args{data: immutable},
+			want: BufferAccount{
+				Data: []byte{},
+			},
+		},
+		{
+			name:    "program account",
+			args:    args{data: append([]byte{2, 0, 0, 0}, authority.Bytes()...)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BufferAccountFromData(tt.args.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BufferAccountFromData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BufferAccountFromData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgramAccountFromData(t *testing.T) {
+	programData := common.PublicKeyFromString("EHE7f3WRq3WwuxwLydJK5MuZt9weSJi5b7JKo4VvpoxW")
+	got, err := ProgramAccountFromData(append([]byte{2, 0, 0, 0}, programData.Bytes()...))
+	if err != nil {
+		t.Fatalf("ProgramAccountFromData() error = %v", err)
+	}
+	if want := (ProgramAccount{ProgramDataAddress: programData}); !reflect.DeepEqual(got, want) {
+		t.Errorf("ProgramAccountFromData() = %v, want %v", got, want)
+	}
+}
+
+func TestProgramDataAccountFromData(t *testing.T) {
+	authority := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	data := []byte{3, 0, 0, 0, 64, 226, 1, 0, 0, 0, 0, 0, 1}
+	data = append(data, authority.Bytes()...)
+	data = append(data, 0x7f, 'E', 'L', 'F')
+
+	type args struct {
+		data []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    ProgramDataAccount
+		wantErr bool
+	}{
+		{
+			args: args{data: data},
+			want: ProgramDataAccount{
+				Slot:             123456,
+				UpgradeAuthority: &authority,
+				Data:             []byte{0x7f, 'E', 'L', 'F'},
+			},
+		},
+		{
+			name:    "data size is not enough",
+			args:    args{data: data[:20]},
+			wantErr: true,
+		},
+		{
+			name:    "unknown state",
+			args:    args{data: []byte{4, 0, 0, 0}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ProgramDataAccountFromData(tt.args.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProgramDataAccountFromData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ProgramDataAccountFromData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
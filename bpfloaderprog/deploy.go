@@ -0,0 +1,173 @@
+package bpfloaderprog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/sysprog"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// MaxWriteChunkSize returns the max bytes a Write instruction can carry in a transaction paid by the payer
+func MaxWriteChunkSize(payer, buffer, authority common.PublicKey) int {
+	message := types.NewMessage(payer, []types.Instruction{Write(buffer, authority, 0, []byte{})}, common.PublicKey{}.ToBase58())
+	b, err := message.Serialize()
+	if err != nil {
+		panic(err)
+	}
+	signatures := int(message.Header.NumRequireSignatures)
+	size := len(common.UintToVarLenBytes(uint64(signatures))) + signatures*64 + len(b)
+	// the length prefix of the instruction data takes one more byte once the chunk is in
+	return types.PacketDataSize - size - 1
+}
+
+type DeployInstructionsParam struct {
+	Payer     common.PublicKey
+	Program   common.PublicKey
+	Buffer    common.PublicKey
+	Authority common.PublicKey
+	// ProgramData is the ELF of the program
+	ProgramData []byte
+	// MaxDataLen is the max size the program can be upgraded to, default: twice the size of ProgramData
+	MaxDataLen uint64
+	// BufferLamports is the rent exemption of BufferMetadataSize plus the size of ProgramData
+	BufferLamports uint64
+	// ProgramLamports is the rent exemption of ProgramSize
+	ProgramLamports uint64
+}
+
+// DeployInstructions are the instructions of a deploy grouped by transaction. CreateBuffer must land first,
+// then every Write in a transaction of its own in any order, and Deploy last.
+type DeployInstructions struct {
+	CreateBuffer []types.Instruction
+	Write        []types.Instruction
+	Deploy       []types.Instruction
+}
+
+// NewDeployInstructions splits the deploy of a program into transactions which fit in the packet size.
+// The buffer is signed by the buffer account, the writes by the authority and the deploy by the program account
+// and the authority, all of them by the payer.
+func NewDeployInstructions(param DeployInstructionsParam) DeployInstructions {
+	maxDataLen := param.MaxDataLen
+	if maxDataLen == 0 {
+		maxDataLen = uint64(len(param.ProgramData)) * 2
+	}
+	return DeployInstructions{
+		CreateBuffer: []types.Instruction{
+			sysprog.CreateAccount(param.Payer, param.Buffer, common.BPFLoaderUpgradeableProgramID, param.BufferLamports, uint64(BufferMetadataSize+len(param.ProgramData))),
+			InitializeBuffer(param.Buffer, param.Authority),
+		},
+		Write: WriteChunks(param.Buffer, param.Authority, param.ProgramData, MaxWriteChunkSize(param.Payer, param.Buffer, param.Authority)),
+		Deploy: []types.Instruction{
+			sysprog.CreateAccount(param.Payer, param.Program, common.BPFLoaderUpgradeableProgramID, param.ProgramLamports, ProgramSize),
+			DeployWithMaxDataLen(param.Payer, param.Program, param.Buffer, param.Authority, maxDataLen),
+		},
+	}
+}
+
+type DeployParam struct {
+	Payer   types.Account
+	Program types.Account
+	Buffer  types.Account
+	// Authority is the upgrade authority of the program, default: Payer
+	Authority types.Account
+	// ProgramData is the ELF of the program
+	ProgramData []byte
+	// MaxDataLen is the max size the program can be upgraded to, default: twice the size of ProgramData
+	MaxDataLen uint64
+	// SendConfig is used for every transaction, its LastValidBlockHeight is set by Deploy
+	SendConfig client.SendAndConfirmTransactionConfig
+}
+
+// DeployResult holds the signatures of the transactions which landed
+type DeployResult struct {
+	CreateBuffer string
+	Write        []string
+	Deploy       string
+}
+
+// Deploy creates a buffer, writes the program to it and deploys it to the program account, waiting for every
+// transaction to be confirmed. On a failed write or deploy the buffer is left with its lamports; it can be
+// resumed by writing the rest with Write and deploying, or closed with Close.
+func Deploy(ctx context.Context, c *client.Client, param DeployParam) (DeployResult, error) {
+	var result DeployResult
+	authority := param.Authority
+	if authority.PublicKey == (common.PublicKey{}) {
+		authority = param.Payer
+	}
+
+	bufferLamports, err := c.GetMinimumBalanceForRentExemption(ctx, uint64(BufferMetadataSize+len(param.ProgramData)))
+	if err != nil {
+		return result, fmt.Errorf("failed to get the rent of the buffer: %w", err)
+	}
+	programLamports, err := c.GetMinimumBalanceForRentExemption(ctx, ProgramSize)
+	if err != nil {
+		return result, fmt.Errorf("failed to get the rent of the program: %w", err)
+	}
+
+	instructions := NewDeployInstructions(DeployInstructionsParam{
+		Payer:           param.Payer.PublicKey,
+		Program:         param.Program.PublicKey,
+		Buffer:          param.Buffer.PublicKey,
+		Authority:       authority.PublicKey,
+		ProgramData:     param.ProgramData,
+		MaxDataLen:      param.MaxDataLen,
+		BufferLamports:  bufferLamports,
+		ProgramLamports: programLamports,
+	})
+
+	result.CreateBuffer, err = sendInstructions(ctx, c, param, instructions.CreateBuffer, param.Payer, param.Buffer)
+	if err != nil {
+		return result, fmt.Errorf("failed to create the buffer: %w", err)
+	}
+	for i, instruction := range instructions.Write {
+		signature, err := sendInstructions(ctx, c, param, []types.Instruction{instruction}, param.Payer, authority)
+		if err != nil {
+			return result, fmt.Errorf("failed to write chunk %d of %d: %w", i+1, len(instructions.Write), err)
+		}
+		result.Write = append(result.Write, signature)
+	}
+	result.Deploy, err = sendInstructions(ctx, c, param, instructions.Deploy, param.Payer, param.Program, authority)
+	if err != nil {
+		return result, fmt.Errorf("failed to deploy the program: %w", err)
+	}
+	return result, nil
+}
+
+func sendInstructions(ctx context.Context, c *client.Client, param DeployParam, instructions []types.Instruction, signers ...types.Account) (string, error) {
+	commitment := param.SendConfig.Commitment
+	if commitment == "" {
+		commitment = client.CommitmentFinalized
+	}
+	blockhash, err := c.GetLatestBlockhash(ctx, commitment)
+	if err != nil {
+		return "", err
+	}
+
+	// the payer is usually the authority as well
+	uniqueSigners := make([]types.Account, 0, len(signers))
+	exist := map[common.PublicKey]bool{}
+	for _, signer := range signers {
+		if exist[signer.PublicKey] {
+			continue
+		}
+		exist[signer.PublicKey] = true
+		uniqueSigners = append(uniqueSigners, signer)
+	}
+
+	tx, err := types.CreateRawTransaction(types.CreateRawTransactionParam{
+		Instructions:    instructions,
+		Signers:         uniqueSigners,
+		FeePayer:        param.Payer.PublicKey,
+		RecentBlockHash: blockhash.Blockhash,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	cfg := param.SendConfig
+	cfg.LastValidBlockHeight = blockhash.LastValidBlockHeight
+	return c.SendAndConfirmTransaction(ctx, tx, cfg)
+}
@@ -0,0 +1,57 @@
+package bpfloaderprog
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func TestNewDeployInstructions(t *testing.T) {
+	payer := types.NewAccount()
+	program := types.NewAccount()
+	buffer := types.NewAccount()
+	programData := bytes.Repeat([]byte{0xab}, 2500)
+
+	got := NewDeployInstructions(DeployInstructionsParam{
+		Payer:           payer.PublicKey,
+		Program:         program.PublicKey,
+		Buffer:          buffer.PublicKey,
+		Authority:       payer.PublicKey,
+		ProgramData:     programData,
+		BufferLamports:  1,
+		ProgramLamports: 1,
+	})
+
+	if len(got.CreateBuffer) != 2 || len(got.Deploy) != 2 {
+		t.Fatalf("NewDeployInstructions() = %v", got)
+	}
+	if want := DeployWithMaxDataLen(payer.PublicKey, program.PublicKey, buffer.PublicKey, payer.PublicKey, 5000); !bytes.Equal(got.Deploy[1].Data, want.Data) {
+		t.Errorf("NewDeployInstructions() deploy data = %v, want %v", got.Deploy[1].Data, want.Data)
+	}
+
+	// 1012 + 1012 + 476
+	if len(got.Write) != 3 {
+		t.Fatalf("NewDeployInstructions() writes = %v, want 3", len(got.Write))
+	}
+	written := []byte{}
+	for _, instruction := range got.Write {
+		tx, err := types.CreateRawTransaction(types.CreateRawTransactionParam{
+			Instructions:    []types.Instruction{instruction},
+			Signers:         []types.Account{payer},
+			FeePayer:        payer.PublicKey,
+			RecentBlockHash: common.PublicKey{}.ToBase58(),
+		})
+		if err != nil {
+			t.Fatalf("CreateRawTransaction() error = %v", err)
+		}
+		if len(tx) > types.PacketDataSize {
+			t.Errorf("write transaction size = %v, want <= %v", len(tx), types.PacketDataSize)
+		}
+		written = append(written, instruction.Data[16:]...)
+	}
+	if !bytes.Equal(written, programData) {
+		t.Errorf("NewDeployInstructions() writes don't add up to the program data")
+	}
+}
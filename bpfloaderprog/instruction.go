@@ -0,0 +1,274 @@
+package bpfloaderprog
+
+import (
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+type Instruction uint32
+
+const (
+	InstructionInitializeBuffer Instruction = iota
+	InstructionWrite
+	InstructionDeployWithMaxDataLen
+	InstructionUpgrade
+	InstructionSetAuthority
+	InstructionClose
+	InstructionExtendProgram
+	InstructionSetAuthorityChecked
+)
+
+type InitializeBufferInstruction struct {
+	Instruction Instruction
+}
+
+type WriteInstruction struct {
+	Instruction Instruction
+	Offset      uint32
+	Bytes       []byte `borsh:"u64len"`
+}
+
+type DeployWithMaxDataLenInstruction struct {
+	Instruction Instruction
+	MaxDataLen  uint64
+}
+
+type UpgradeInstruction struct {
+	Instruction Instruction
+}
+
+type SetAuthorityInstruction struct {
+	Instruction Instruction
+}
+
+type CloseInstruction struct {
+	Instruction Instruction
+}
+
+type ExtendProgramInstruction struct {
+	Instruction     Instruction
+	AdditionalBytes uint32
+}
+
+type SetAuthorityCheckedInstruction struct {
+	Instruction Instruction
+}
+
+// FindProgramDataAddress returns the address the executable data of the program is stored at
+func FindProgramDataAddress(program common.PublicKey) (common.PublicKey, int, error) {
+	return common.FindProgramAddress([][]byte{program.Bytes()}, common.BPFLoaderUpgradeableProgramID)
+}
+
+// InitializeBuffer initializes a buffer account created with BufferMetadataSize plus the program size,
+// the program is written to it by Write
+func InitializeBuffer(buffer, authority common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(InitializeBufferInstruction{
+		Instruction: InstructionInitializeBuffer,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.BPFLoaderUpgradeableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: buffer, IsSigner: false, IsWritable: true},
+			{PubKey: authority, IsSigner: false, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+// Write writes bytes to the buffer at the offset of the program data,
+// see WriteChunks for writing a program which doesn't fit in a transaction
+func Write(buffer, authority common.PublicKey, offset uint32, bytes []byte) types.Instruction {
+	data, err := borsh.Marshal(WriteInstruction{
+		Instruction: InstructionWrite,
+		Offset:      offset,
+		Bytes:       bytes,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.BPFLoaderUpgradeableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: buffer, IsSigner: false, IsWritable: true},
+			{PubKey: authority, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+// WriteChunks splits the program data into Write instructions of at most chunkSize bytes,
+// see MaxWriteChunkSize for the chunk size which fits in a transaction
+func WriteChunks(buffer, authority common.PublicKey, programData []byte, chunkSize int) []types.Instruction {
+	if chunkSize <= 0 {
+		panic("chunk size must be greater than 0")
+	}
+	instructions := make([]types.Instruction, 0, (len(programData)+chunkSize-1)/chunkSize)
+	for offset := 0; offset < len(programData); offset += chunkSize {
+		end := offset + chunkSize
+		if end > len(programData) {
+			end = len(programData)
+		}
+		instructions = append(instructions, Write(buffer, authority, uint32(offset), programData[offset:end]))
+	}
+	return instructions
+}
+
+// DeployWithMaxDataLen deploys the program in the buffer to the program account, which must be created
+// with ProgramSize and owned by the loader in the same transaction. maxDataLen is the max size the program
+// can be upgraded to, the payer pays the rent of the program data account and receives the buffer's lamports.
+func DeployWithMaxDataLen(payer, program, buffer, authority common.PublicKey, maxDataLen uint64) types.Instruction {
+	data, err := borsh.Marshal(DeployWithMaxDataLenInstruction{
+		Instruction: InstructionDeployWithMaxDataLen,
+		MaxDataLen:  maxDataLen,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	programData, _, _ := FindProgramDataAddress(program)
+	return types.Instruction{
+		ProgramID: common.BPFLoaderUpgradeableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: payer, IsSigner: true, IsWritable: true},
+			{PubKey: programData, IsSigner: false, IsWritable: true},
+			{PubKey: program, IsSigner: false, IsWritable: true},
+			{PubKey: buffer, IsSigner: false, IsWritable: true},
+			{PubKey: common.SysVarRentPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+			{PubKey: authority, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+// Upgrade replaces the program with the one in the buffer, the buffer's lamports go to the spill account
+func Upgrade(program, buffer, authority, spill common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(UpgradeInstruction{
+		Instruction: InstructionUpgrade,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	programData, _, _ := FindProgramDataAddress(program)
+	return types.Instruction{
+		ProgramID: common.BPFLoaderUpgradeableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: programData, IsSigner: false, IsWritable: true},
+			{PubKey: program, IsSigner: false, IsWritable: true},
+			{PubKey: buffer, IsSigner: false, IsWritable: true},
+			{PubKey: spill, IsSigner: false, IsWritable: true},
+			{PubKey: common.SysVarRentPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: authority, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+// SetAuthority changes the authority of a buffer or a program data account.
+// An empty newAuthority makes the program immutable, buffers always need one.
+func SetAuthority(account, currentAuthority, newAuthority common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(SetAuthorityInstruction{
+		Instruction: InstructionSetAuthority,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := []types.AccountMeta{
+		{PubKey: account, IsSigner: false, IsWritable: true},
+		{PubKey: currentAuthority, IsSigner: true, IsWritable: false},
+	}
+	if newAuthority != (common.PublicKey{}) {
+		accounts = append(accounts, types.AccountMeta{PubKey: newAuthority, IsSigner: false, IsWritable: false})
+	}
+	return types.Instruction{
+		ProgramID: common.BPFLoaderUpgradeableProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// SetAuthorityChecked is SetAuthority but the new authority must sign as well
+func SetAuthorityChecked(account, currentAuthority, newAuthority common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(SetAuthorityCheckedInstruction{
+		Instruction: InstructionSetAuthorityChecked,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.BPFLoaderUpgradeableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: account, IsSigner: false, IsWritable: true},
+			{PubKey: currentAuthority, IsSigner: true, IsWritable: false},
+			{PubKey: newAuthority, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+// Close closes a buffer or a program data account and sends its lamports to the recipient.
+// authority is empty for an uninitialized account, program is only required for a program data account.
+func Close(account, recipient, authority, program common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(CloseInstruction{
+		Instruction: InstructionClose,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := []types.AccountMeta{
+		{PubKey: account, IsSigner: false, IsWritable: true},
+		{PubKey: recipient, IsSigner: false, IsWritable: true},
+	}
+	if authority != (common.PublicKey{}) {
+		accounts = append(accounts, types.AccountMeta{PubKey: authority, IsSigner: true, IsWritable: false})
+	}
+	if program != (common.PublicKey{}) {
+		accounts = append(accounts, types.AccountMeta{PubKey: program, IsSigner: false, IsWritable: true})
+	}
+	return types.Instruction{
+		ProgramID: common.BPFLoaderUpgradeableProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// ExtendProgram grows the program data account by additionalBytes. The payer pays the extra rent,
+// it can be empty if the program data account already holds enough lamports.
+func ExtendProgram(program, payer common.PublicKey, additionalBytes uint32) types.Instruction {
+	data, err := borsh.Marshal(ExtendProgramInstruction{
+		Instruction:     InstructionExtendProgram,
+		AdditionalBytes: additionalBytes,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	programData, _, _ := FindProgramDataAddress(program)
+	accounts := []types.AccountMeta{
+		{PubKey: programData, IsSigner: false, IsWritable: true},
+		{PubKey: program, IsSigner: false, IsWritable: true},
+	}
+	if payer != (common.PublicKey{}) {
+		accounts = append(accounts,
+			types.AccountMeta{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+			types.AccountMeta{PubKey: payer, IsSigner: true, IsWritable: true},
+		)
+	}
+	return types.Instruction{
+		ProgramID: common.BPFLoaderUpgradeableProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
@@ -0,0 +1,169 @@
+package bpfloaderprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func TestWrite(t *testing.T) {
+	type args struct {
+		buffer    common.PublicKey
+		authority common.PublicKey
+		offset    uint32
+		bytes     []byte
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				buffer:    common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+				authority: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				offset:    1012,
+				bytes:     []byte{1, 2, 3},
+			},
+			want: types.Instruction{
+				ProgramID: common.BPFLoaderUpgradeableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{1, 0, 0, 0, 244, 3, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 1, 2, 3},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Write(tt.args.buffer, tt.args.authority, tt.args.offset, tt.args.bytes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Write() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteChunks(t *testing.T) {
+	buffer := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	authority := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	got := WriteChunks(buffer, authority, []byte{1, 2, 3, 4, 5}, 2)
+	want := []types.Instruction{
+		Write(buffer, authority, 0, []byte{1, 2}),
+		Write(buffer, authority, 2, []byte{3, 4}),
+		Write(buffer, authority, 4, []byte{5}),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WriteChunks() = %v, want %v", got, want)
+	}
+}
+
+func TestDeployWithMaxDataLen(t *testing.T) {
+	type args struct {
+		payer      common.PublicKey
+		program    common.PublicKey
+		buffer     common.PublicKey
+		authority  common.PublicKey
+		maxDataLen uint64
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				payer:      common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				program:    common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+				buffer:     common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+				authority:  common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				maxDataLen: 2000,
+			},
+			want: types.Instruction{
+				ProgramID: common.BPFLoaderUpgradeableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EHE7f3WRq3WwuxwLydJK5MuZt9weSJi5b7JKo4VvpoxW"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: false, IsWritable: true},
+					{PubKey: common.SysVarRentPubkey, IsSigner: false, IsWritable: false},
+					{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+					{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{2, 0, 0, 0, 208, 7, 0, 0, 0, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DeployWithMaxDataLen(tt.args.payer, tt.args.program, tt.args.buffer, tt.args.authority, tt.args.maxDataLen); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DeployWithMaxDataLen() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetAuthority(t *testing.T) {
+	account := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	current := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	newAuthority := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	tests := []struct {
+		name string
+		got  types.Instruction
+		want types.Instruction
+	}{
+		{
+			name: "new authority",
+			got:  SetAuthority(account, current, newAuthority),
+			want: types.Instruction{
+				ProgramID: common.BPFLoaderUpgradeableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: account, IsSigner: false, IsWritable: true},
+					{PubKey: current, IsSigner: true, IsWritable: false},
+					{PubKey: newAuthority, IsSigner: false, IsWritable: false},
+				},
+				Data: []byte{4, 0, 0, 0},
+			},
+		},
+		{
+			name: "immutable",
+			got:  SetAuthority(account, current, common.PublicKey{}),
+			want: types.Instruction{
+				ProgramID: common.BPFLoaderUpgradeableProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: account, IsSigner: false, IsWritable: true},
+					{PubKey: current, IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{4, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("SetAuthority() = %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtendProgram(t *testing.T) {
+	program := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	payer := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	want := types.Instruction{
+		ProgramID: common.BPFLoaderUpgradeableProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: common.PublicKeyFromString("EHE7f3WRq3WwuxwLydJK5MuZt9weSJi5b7JKo4VvpoxW"), IsSigner: false, IsWritable: true},
+			{PubKey: program, IsSigner: false, IsWritable: true},
+			{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+			{PubKey: payer, IsSigner: true, IsWritable: true},
+		},
+		Data: []byte{6, 0, 0, 0, 0, 4, 0, 0},
+	}
+	if got := ExtendProgram(program, payer, 1024); !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtendProgram() = %v, want %v", got, want)
+	}
+}
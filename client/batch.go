@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var ErrBatchNotSent = errors.New("batch has not been sent")
+
+// Batch queues rpc calls and sends them as one json-rpc array.
+// Each queued call returns a handle, read its result after Send.
+type Batch struct {
+	client *Client
+	calls  []*batchCall
+}
+
+type batchCall struct {
+	method   string
+	params   []interface{}
+	response interface{}
+	err      error
+	done     bool
+}
+
+func (c *batchCall) result() error {
+	if !c.done {
+		return ErrBatchNotSent
+	}
+	return c.err
+}
+
+// NewBatch creates an empty batch
+func (s *Client) NewBatch() *Batch {
+	return &Batch{client: s}
+}
+
+// Len returns the number of queued calls
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+func (b *Batch) add(method string, params []interface{}, response interface{}) *batchCall {
+	call := &batchCall{method: method, params: params, response: response}
+	b.calls = append(b.calls, call)
+	return call
+}
+
+// Send posts all queued calls in one request. The returned error is about the request itself,
+// the error of each call is returned by its handle.
+func (b *Batch) Send(ctx context.Context) error {
+	if len(b.calls) == 0 {
+		return nil
+	}
+
+	reqs := make([]map[string]interface{}, 0, len(b.calls))
+	methods := make([]string, 0, len(b.calls))
+	for id, call := range b.calls {
+		methods = append(methods, call.method)
+		reqs = append(reqs, map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      id,
+			"method":  call.method,
+			"params":  call.params,
+		})
+	}
+	j, err := json.Marshal(reqs)
+	if err != nil {
+		return err
+	}
+
+	body, err := b.client.post(ctx, j, methods...)
+	if err != nil {
+		return err
+	}
+
+	var responses []json.RawMessage
+	if err := json.Unmarshal(body, &responses); err != nil {
+		// the whole batch is rejected with a single error object
+		var general GeneralResponse
+		if json.Unmarshal(body, &general) == nil && general.Error != nil {
+			return general.Error
+		}
+		return fmt.Errorf("failed to parse batch response: %v", err)
+	}
+
+	for _, call := range b.calls {
+		call.done = true
+		call.err = errors.New("no response for this call")
+	}
+	for _, r := range responses {
+		var id struct {
+			ID *uint64 `json:"id"`
+		}
+		if err := json.Unmarshal(r, &id); err != nil {
+			return fmt.Errorf("failed to parse batch response: %v", err)
+		}
+		// a response without id can't be matched to a call
+		if id.ID == nil {
+			continue
+		}
+		if *id.ID >= uint64(len(b.calls)) {
+			return fmt.Errorf("unexpected response id: %d", *id.ID)
+		}
+		call := b.calls[*id.ID]
+		call.err = decodeResponse(r, call.response)
+	}
+	return nil
+}
+
+// BatchCall is the handle of a call queued by Batch.Call
+type BatchCall struct {
+	call *batchCall
+	res  struct {
+		GeneralResponse
+		Result json.RawMessage `json:"result"`
+	}
+}
+
+// Call queues any rpc method, the result is kept undecoded
+func (b *Batch) Call(method string, params []interface{}) *BatchCall {
+	h := &BatchCall{}
+	h.call = b.add(method, params, &h.res)
+	return h
+}
+
+func (h *BatchCall) Result() (json.RawMessage, error) {
+	if err := h.call.result(); err != nil {
+		return nil, err
+	}
+	return h.res.Result, nil
+}
+
+// BatchGetBalance is the handle of a call queued by Batch.GetBalance
+type BatchGetBalance struct {
+	call *batchCall
+	res  struct {
+		GeneralResponse
+		Result struct {
+			Context Context `json:"context"`
+			Value   uint64  `json:"value"`
+		} `json:"result"`
+	}
+}
+
+func (b *Batch) GetBalance(base58Addr string) *BatchGetBalance {
+	h := &BatchGetBalance{}
+	h.call = b.add("getBalance", []interface{}{base58Addr}, &h.res)
+	return h
+}
+
+func (h *BatchGetBalance) Result() (uint64, error) {
+	if err := h.call.result(); err != nil {
+		return 0, err
+	}
+	return h.res.Result.Value, nil
+}
+
+// BatchGetAccountInfo is the handle of a call queued by Batch.GetAccountInfo
+type BatchGetAccountInfo struct {
+	call *batchCall
+	res  struct {
+		GeneralResponse
+		Result struct {
+			Context Context                `json:"context"`
+			Value   GetAccountInfoResponse `json:"value"`
+		} `json:"result"`
+	}
+}
+
+func (b *Batch) GetAccountInfo(account string, cfg GetAccountInfoConfig) *BatchGetAccountInfo {
+	h := &BatchGetAccountInfo{}
+	h.call = b.add("getAccountInfo", []interface{}{account, cfg}, &h.res)
+	return h
+}
+
+func (h *BatchGetAccountInfo) Result() (GetAccountInfoResponse, error) {
+	if err := h.call.result(); err != nil {
+		return GetAccountInfoResponse{}, err
+	}
+	return h.res.Result.Value, nil
+}
+
+// BatchGetSignatureStatuses is the handle of a call queued by Batch.GetSignatureStatuses
+type BatchGetSignatureStatuses struct {
+	call *batchCall
+	res  struct {
+		GeneralResponse
+		Result struct {
+			Context Context                        `json:"context"`
+			Value   []GetSignatureStatusesResponse `json:"value"`
+		} `json:"result"`
+	}
+}
+
+func (b *Batch) GetSignatureStatuses(signatures []string) *BatchGetSignatureStatuses {
+	h := &BatchGetSignatureStatuses{}
+	h.call = b.add("getSignatureStatuses", []interface{}{signatures, map[string]interface{}{"searchTransactionHistory": true}}, &h.res)
+	return h
+}
+
+func (h *BatchGetSignatureStatuses) Result() ([]GetSignatureStatusesResponse, error) {
+	if err := h.call.result(); err != nil {
+		return nil, err
+	}
+	return h.res.Result.Value, nil
+}
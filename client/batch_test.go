@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBatchSend(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var reqs []struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+		}
+		if err := json.Unmarshal(body, &reqs); err != nil || len(reqs) != 3 {
+			t.Errorf("unexpected request: %s", string(body))
+		}
+		// responses in a different order than requests
+		w.Write([]byte(`[
+			{"jsonrpc":"2.0","id":2,"result":{"context":{"slot":1},"value":[{"slot":5,"confirmations":null,"confirmationStatus":"finalized","err":null}]}},
+			{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"Invalid param: WrongSize"}},
+			{"jsonrpc":"2.0","id":0,"result":{"context":{"slot":1},"value":100}}
+		]`))
+	}))
+	defer srv.Close()
+
+	b := NewClient(srv.URL).NewBatch()
+	balance := b.GetBalance("RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7")
+	accountInfo := b.GetAccountInfo("invalid", GetAccountInfoConfig{Encoding: GetAccountInfoConfigEncodingBase64})
+	statuses := b.GetSignatureStatuses([]string{"sig"})
+
+	if _, err := balance.Result(); err != ErrBatchNotSent {
+		t.Errorf("Result() before Send error = %v, want %v", err, ErrBatchNotSent)
+	}
+	if err := b.Send(context.Background()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if got, err := balance.Result(); err != nil || got != 100 {
+		t.Errorf("GetBalance Result() = %v, %v, want 100", got, err)
+	}
+	var rpcErr *RPCError
+	if _, err := accountInfo.Result(); !errors.As(err, &rpcErr) || rpcErr.Code != RPCErrorCodeInvalidParams {
+		t.Errorf("GetAccountInfo Result() error = %v, want invalid params", err)
+	}
+	got, err := statuses.Result()
+	if err != nil || len(got) != 1 || got[0].Slot != 5 {
+		t.Errorf("GetSignatureStatuses Result() = %v, %v", got, err)
+	}
+}
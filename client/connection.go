@@ -4,9 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"net/http"
+	"time"
 )
 
 const (
@@ -24,11 +24,34 @@ const (
 )
 
 type Client struct {
-	endpoint string
+	endpoint   string
+	httpClient *http.Client
+	headers    http.Header
+	timeout    time.Duration
+	retry      RetryPolicy
+	limiter    *rateLimiter
+	// pool is set by NewMultiClient
+	pool *endpointPool
 }
 
-func NewClient(endpoint string) *Client {
-	return &Client{endpoint: endpoint}
+// NewClient creates a client for the endpoint. The underlying http client,
+// and therefore its connection pool, is shared by all rpc methods.
+func NewClient(endpoint string, opts ...Option) *Client {
+	cfg := clientConfig{headers: http.Header{}}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.userAgent != "" {
+		cfg.headers.Set("User-Agent", cfg.userAgent)
+	}
+	return &Client{
+		endpoint:   endpoint,
+		httpClient: cfg.buildHTTPClient(),
+		headers:    cfg.headers,
+		timeout:    cfg.timeout,
+		retry:      cfg.retry,
+		limiter:    cfg.limiter,
+	}
 }
 
 func (s *Client) request(ctx context.Context, method string, params []interface{}, response interface{}) error {
@@ -43,42 +66,127 @@ func (s *Client) request(ctx context.Context, method string, params []interface{
 		return err
 	}
 
-	// post request
-	req, err := http.NewRequestWithContext(ctx, "POST", s.endpoint, bytes.NewBuffer(j))
+	body, err := s.post(ctx, j, method)
 	if err != nil {
 		return err
 	}
-	req.Header.Add("Content-Type", "application/json")
+	return decodeResponse(body, response)
+}
+
+// post sends the json-rpc payload which calls methods and returns the response body.
+// It is retried according to the retry policy.
+func (s *Client) post(ctx context.Context, payload []byte, methods ...string) ([]byte, error) {
+	attempts := 1
+	if s.retry.allow(methods...) {
+		attempts = s.retry.MaxAttempts
+	}
+	for attempt := 1; ; attempt++ {
+		body, err := s.postEndpoints(ctx, payload, s.retry.idempotent(methods...))
+		if err == nil || attempt >= attempts {
+			return body, err
+		}
+		retryable, retryAfter := isRetryable(ctx, err)
+		if !retryable {
+			return nil, err
+		}
+		if err := sleep(ctx, s.retry.backoff(attempt, retryAfter)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// postEndpoints posts to the best endpoint and fails over to the next ones if failover is set
+func (s *Client) postEndpoints(ctx context.Context, payload []byte, failover bool) ([]byte, error) {
+	if s.pool == nil {
+		return s.postOnce(ctx, s.endpoint, payload)
+	}
+
+	endpoints := s.pool.order()
+	var err error
+	for i, endpoint := range endpoints {
+		var body []byte
+		body, err = s.postOnce(ctx, endpoint, payload)
+		if err == nil {
+			return body, nil
+		}
+		if retryable, _ := isRetryable(ctx, err); !retryable {
+			return nil, err
+		}
+		s.pool.markFailed(endpoint, err)
+		if !failover || i == len(endpoints)-1 {
+			break
+		}
+	}
+	return nil, err
+}
+
+func (s *Client) postOnce(ctx context.Context, endpoint string, payload []byte) ([]byte, error) {
+	if s.limiter != nil {
+		if err := s.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
 
-	// http client and send request
-	httpclient := &http.Client{}
-	res, err := httpclient.Do(req)
+	// post request
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(payload))
 	if err != nil {
-		return err
+		return nil, err
+	}
+	for k, v := range s.headers {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// send request
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
 	}
 	defer res.Body.Close()
 
-	// parse body
+	// read body
 	body, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		return err
-	}
-	if len(body) != 0 {
-		if err := json.Unmarshal(body, &response); err != nil {
-			return err
-		}
+		return nil, err
 	}
 
-	// return result
 	if res.StatusCode < 200 || res.StatusCode > 300 {
-		return fmt.Errorf("get status code: %d", res.StatusCode)
+		// the node may still report a json-rpc error, it is more useful than the status code
+		var general GeneralResponse
+		if err := json.Unmarshal(body, &general); err == nil && general.Error != nil {
+			return nil, general.Error
+		}
+		return nil, &HTTPStatusError{
+			StatusCode: res.StatusCode,
+			RetryAfter: parseRetryAfter(res.Header.Get("Retry-After")),
+		}
 	}
-	return nil
+	// a node behind the cluster fails the request so it is retried or sent to another node
+	if bytes.Contains(body, []byte(`"error"`)) {
+		var general GeneralResponse
+		if err := json.Unmarshal(body, &general); err == nil && general.Error != nil && isNodeBehind(general.Error) {
+			return nil, general.Error
+		}
+	}
+	return body, nil
 }
 
-type ErrorResponse struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+// decodeResponse decodes a single json-rpc response into response, which should embed GeneralResponse
+func decodeResponse(body []byte, response interface{}) error {
+	if len(body) == 0 {
+		return nil
+	}
+	var general GeneralResponse
+	if err := json.Unmarshal(body, &general); err == nil && general.Error != nil {
+		return general.Error
+	}
+	return json.Unmarshal(body, response)
 }
 
 type Context struct {
@@ -86,17 +194,20 @@ type Context struct {
 }
 
 type GeneralResponse struct {
-	JsonRPC string        `json:"jsonrpc"`
-	ID      uint64        `json:"id"`
-	Error   ErrorResponse `json:"error"`
+	JsonRPC string    `json:"jsonrpc"`
+	ID      uint64    `json:"id"`
+	Error   *RPCError `json:"error"`
 }
 
+// CallRequest calls any rpc method and returns the undecoded result
 func (s *Client) CallRequest(ctx context.Context, method string, params []interface{}) (interface{}, error) {
-	var out interface{}
-	var err error
-
+	res := struct {
+		GeneralResponse
+		Result interface{} `json:"result"`
+	}{}
+	err := s.request(ctx, method, params, &res)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return res.Result, nil
 }
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+type RPCErrorCode int
+
+// standard json-rpc 2.0 error codes
+const (
+	RPCErrorCodeParseError     RPCErrorCode = -32700
+	RPCErrorCodeInvalidRequest RPCErrorCode = -32600
+	RPCErrorCodeMethodNotFound RPCErrorCode = -32601
+	RPCErrorCodeInvalidParams  RPCErrorCode = -32602
+	RPCErrorCodeInternalError  RPCErrorCode = -32603
+)
+
+// solana json-rpc server error codes
+const (
+	RPCErrorCodeBlockCleanedUp                           RPCErrorCode = -32001
+	RPCErrorCodeSendTransactionPreflightFailure          RPCErrorCode = -32002
+	RPCErrorCodeTransactionSignatureVerificationFailure  RPCErrorCode = -32003
+	RPCErrorCodeBlockNotAvailable                        RPCErrorCode = -32004
+	RPCErrorCodeNodeUnhealthy                            RPCErrorCode = -32005
+	RPCErrorCodeTransactionPrecompileVerificationFailure RPCErrorCode = -32006
+	RPCErrorCodeSlotSkipped                              RPCErrorCode = -32007
+	RPCErrorCodeNoSnapshot                               RPCErrorCode = -32008
+	RPCErrorCodeLongTermStorageSlotSkipped               RPCErrorCode = -32009
+	RPCErrorCodeKeyExcludedFromSecondaryIndex            RPCErrorCode = -32010
+	RPCErrorCodeTransactionHistoryNotAvailable           RPCErrorCode = -32011
+	RPCErrorCodeScanError                                RPCErrorCode = -32012
+	RPCErrorCodeTransactionSignatureLenMismatch          RPCErrorCode = -32013
+	RPCErrorCodeBlockStatusNotAvailableYet               RPCErrorCode = -32014
+	RPCErrorCodeUnsupportedTransactionVersion            RPCErrorCode = -32015
+	RPCErrorCodeMinContextSlotNotReached                 RPCErrorCode = -32016
+)
+
+// RPCError is the error object returned by a json-rpc node. Use errors.As to get it from any client method.
+type RPCError struct {
+	Code    RPCErrorCode  `json:"code"`
+	Message string        `json:"message"`
+	Data    *RPCErrorData `json:"data,omitempty"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("rpc error: code = %d, message = %s", e.Code, e.Message)
+}
+
+// IsPreflightFailure reports whether the node rejected the tx during the preflight simulation.
+// The simulation result is in e.Data.
+func (e *RPCError) IsPreflightFailure() bool {
+	return e.Code == RPCErrorCodeSendTransactionPreflightFailure
+}
+
+// RPCErrorData is the decoded `data` field of an error.
+// Which fields are filled depends on the error code, Raw always keeps the original json.
+type RPCErrorData struct {
+	// SendTransactionPreflightFailure
+	Err           *TransactionError `json:"err"`
+	Logs          []string          `json:"logs"`
+	UnitsConsumed *uint64           `json:"unitsConsumed"`
+
+	// NodeUnhealthy
+	NumSlotsBehind *uint64 `json:"numSlotsBehind"`
+
+	// MinContextSlotNotReached
+	ContextSlot *uint64 `json:"contextSlot"`
+
+	Raw json.RawMessage `json:"-"`
+}
+
+func (d *RPCErrorData) UnmarshalJSON(b []byte) error {
+	type rpcErrorData RPCErrorData
+	var data rpcErrorData
+	// data is not always an object, keep the raw value in that case
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")) {
+		if err := json.Unmarshal(b, &data); err != nil {
+			return err
+		}
+	}
+	*d = RPCErrorData(data)
+	d.Raw = append(json.RawMessage{}, b...)
+	return nil
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestRPCError(t *testing.T) {
+	var preflightErr TransactionError
+	if err := json.Unmarshal([]byte(`{"InstructionError":[0,{"Custom":1}]}`), &preflightErr); err != nil {
+		t.Fatalf("failed to decode transaction error, err: %v", err)
+	}
+	u64 := func(v uint64) *uint64 { return &v }
+
+	tests := []struct {
+		name          string
+		statusCode    int
+		body          string
+		want          *RPCError
+		wantPreflight bool
+	}{
+		{
+			name:       "preflight failure",
+			statusCode: http.StatusOK,
+			body:       `{"jsonrpc":"2.0","id":0,"error":{"code":-32002,"message":"Transaction simulation failed","data":{"err":{"InstructionError":[0,{"Custom":1}]},"logs":["Program log: x"],"unitsConsumed":120}}}`,
+			want: &RPCError{
+				Code:    RPCErrorCodeSendTransactionPreflightFailure,
+				Message: "Transaction simulation failed",
+				Data: &RPCErrorData{
+					Err:           &preflightErr,
+					Logs:          []string{"Program log: x"},
+					UnitsConsumed: u64(120),
+					Raw:           json.RawMessage(`{"err":{"InstructionError":[0,{"Custom":1}]},"logs":["Program log: x"],"unitsConsumed":120}`),
+				},
+			},
+			wantPreflight: true,
+		},
+		{
+			name:       "node unhealthy",
+			statusCode: http.StatusOK,
+			body:       `{"jsonrpc":"2.0","id":0,"error":{"code":-32005,"message":"Node is behind by 42 slots","data":{"numSlotsBehind":42}}}`,
+			want: &RPCError{
+				Code:    RPCErrorCodeNodeUnhealthy,
+				Message: "Node is behind by 42 slots",
+				Data: &RPCErrorData{
+					NumSlotsBehind: u64(42),
+					Raw:            json.RawMessage(`{"numSlotsBehind":42}`),
+				},
+			},
+		},
+		{
+			name:       "data is not an object",
+			statusCode: http.StatusOK,
+			body:       `{"jsonrpc":"2.0","id":0,"error":{"code":-32602,"message":"Invalid params","data":"invalid base58"}}`,
+			want: &RPCError{
+				Code:    RPCErrorCodeInvalidParams,
+				Message: "Invalid params",
+				Data:    &RPCErrorData{Raw: json.RawMessage(`"invalid base58"`)},
+			},
+		},
+		{
+			name:       "no data",
+			statusCode: http.StatusOK,
+			body:       `{"jsonrpc":"2.0","id":0,"error":{"code":-32601,"message":"Method not found"}}`,
+			want: &RPCError{
+				Code:    RPCErrorCodeMethodNotFound,
+				Message: "Method not found",
+			},
+		},
+		{
+			name:       "json-rpc error in a non-2xx response",
+			statusCode: http.StatusInternalServerError,
+			body:       `{"jsonrpc":"2.0","id":0,"error":{"code":-32603,"message":"Internal error"}}`,
+			want: &RPCError{
+				Code:    RPCErrorCodeInternalError,
+				Message: "Internal error",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			_, err := NewClient(srv.URL).GetBalance(context.Background(), "11111111111111111111111111111111")
+			var rpcErr *RPCError
+			if !errors.As(err, &rpcErr) {
+				t.Fatalf("GetBalance() error = %v, want *RPCError", err)
+			}
+			if !reflect.DeepEqual(rpcErr, tt.want) {
+				t.Errorf("GetBalance() error = %+v, want %+v", rpcErr, tt.want)
+			}
+			if got := rpcErr.IsPreflightFailure(); got != tt.wantPreflight {
+				t.Errorf("IsPreflightFailure() = %v, want %v", got, tt.wantPreflight)
+			}
+		})
+	}
+}
+
+func TestHTTPStatusErrorWithoutRPCError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(`bad gateway`))
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL).GetBalance(context.Background(), "11111111111111111111111111111111")
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		t.Fatalf("GetBalance() error = %v, want no *RPCError", err)
+	}
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("GetBalance() error = %v, want *HTTPStatusError with status 502", err)
+	}
+}
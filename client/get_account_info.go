@@ -3,7 +3,6 @@ package client
 import (
 	"context"
 	"encoding/json"
-	"errors"
 )
 
 type GetAccountInfoConfigEncoding string
@@ -80,9 +79,6 @@ func (s *Client) GetAccountInfo(ctx context.Context, account string, cfg GetAcco
 	if err != nil {
 		return GetAccountInfoResponse{}, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return GetAccountInfoResponse{}, errors.New(res.Error.Message)
-	}
 	return res.Result.Value, nil
 }
 func (s *Client) GetAccountInfoParsed(ctx context.Context, account string) (GetAccountInfoParsedResponse, error) {
@@ -97,8 +93,5 @@ func (s *Client) GetAccountInfoParsed(ctx context.Context, account string) (GetA
 	if err != nil {
 		return GetAccountInfoParsedResponse{}, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return GetAccountInfoParsedResponse{}, errors.New(res.Error.Message)
-	}
 	return res.Result.Value, nil
 }
@@ -1,9 +1,6 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 func (s *Client) GetBalance(ctx context.Context, base58Addr string) (uint64, error) {
 	res := struct {
@@ -17,8 +14,5 @@ func (s *Client) GetBalance(ctx context.Context, base58Addr string) (uint64, err
 	if err != nil {
 		return 0, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return 0, errors.New(res.Error.Message)
-	}
 	return res.Result.Value, nil
 }
@@ -1,9 +1,6 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type GetBlockCommitmentResponse struct {
 	Commitment []uint64 `json:"commitment"`
@@ -19,8 +16,5 @@ func (s *Client) GetBlockCommitment(ctx context.Context, slot uint64) (GetBlockC
 	if err != nil {
 		return GetBlockCommitmentResponse{}, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return GetBlockCommitmentResponse{}, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
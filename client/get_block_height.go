@@ -0,0 +1,15 @@
+package client
+
+import "context"
+
+func (s *Client) GetBlockHeight(ctx context.Context, commitment Commitment) (uint64, error) {
+	res := struct {
+		GeneralResponse
+		Result uint64 `json:"result"`
+	}{}
+	err := s.request(ctx, "getBlockHeight", []interface{}{map[string]interface{}{"commitment": commitment}}, &res)
+	if err != nil {
+		return 0, err
+	}
+	return res.Result, nil
+}
@@ -1,9 +1,6 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type GetClusterNodesResponse struct {
 	FeatureSet   *uint64 `json:"featureSet"`
@@ -24,8 +21,5 @@ func (s *Client) GetClusterNodes(ctx context.Context) ([]GetClusterNodesResponse
 	if err != nil {
 		return []GetClusterNodesResponse{}, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return []GetClusterNodesResponse{}, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
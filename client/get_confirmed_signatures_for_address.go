@@ -1,16 +1,13 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type GetConfirmedSignaturesForAddress struct {
-	BlockTime *int64      `json:"blockTime"`
-	Err       interface{} `json:"err"`
-	Memo      *string     `json:"memo"`
-	Signature string      `json:"signature"`
-	Slot      int64       `json:"slot"`
+	BlockTime *int64            `json:"blockTime"`
+	Err       *TransactionError `json:"err"`
+	Memo      *string           `json:"memo"`
+	Signature string            `json:"signature"`
+	Slot      int64             `json:"slot"`
 }
 
 type GetConfirmedSignaturesForAddressConfig struct {
@@ -31,8 +28,5 @@ func (s *Client) GetConfirmedSignaturesForAddress(ctx context.Context, base58Add
 	if err != nil {
 		return []GetConfirmedSignaturesForAddress{}, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return []GetConfirmedSignaturesForAddress{}, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
@@ -1,9 +1,6 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 func (s *Client) GetGenesisHash(ctx context.Context) (string, error) {
 	res := struct {
@@ -14,9 +11,6 @@ func (s *Client) GetGenesisHash(ctx context.Context) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return "", errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
 func (s *Client) GetFirstAvailableBlock(ctx context.Context) (uint64, error) {
@@ -28,9 +22,6 @@ func (s *Client) GetFirstAvailableBlock(ctx context.Context) (uint64, error) {
 	if err != nil {
 		return 0, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return 0, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
 
@@ -43,8 +34,5 @@ func (s *Client) GetSlot(ctx context.Context) (uint64, error) {
 	if err != nil {
 		return 0, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return 0, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
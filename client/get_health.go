@@ -0,0 +1,17 @@
+package client
+
+import "context"
+
+// GetHealth returns the health of the node, "ok" when healthy.
+// An unhealthy node returns an RPCError with code RPCErrorCodeNodeUnhealthy.
+func (s *Client) GetHealth(ctx context.Context) (string, error) {
+	res := struct {
+		GeneralResponse
+		Result string `json:"result"`
+	}{}
+	err := s.request(ctx, "getHealth", []interface{}{}, &res)
+	if err != nil {
+		return "", err
+	}
+	return res.Result, nil
+}
@@ -0,0 +1,23 @@
+package client
+
+import "context"
+
+type GetLatestBlockhashResponse struct {
+	Blockhash            string `json:"blockhash"`
+	LastValidBlockHeight uint64 `json:"lastValidBlockHeight"`
+}
+
+func (s *Client) GetLatestBlockhash(ctx context.Context, commitment Commitment) (GetLatestBlockhashResponse, error) {
+	res := struct {
+		GeneralResponse
+		Result struct {
+			Context Context                    `json:"context"`
+			Value   GetLatestBlockhashResponse `json:"value"`
+		} `json:"result"`
+	}{}
+	err := s.request(ctx, "getLatestBlockhash", []interface{}{map[string]interface{}{"commitment": commitment}}, &res)
+	if err != nil {
+		return GetLatestBlockhashResponse{}, err
+	}
+	return res.Result.Value, nil
+}
@@ -3,7 +3,6 @@ package client
 import (
 	"context"
 	"encoding/json"
-	"errors"
 )
 
 type GetProgramAccountsConfig struct {
@@ -87,8 +86,5 @@ func (s *Client) GetProgramAccounts(ctx context.Context, base58Addr string, cfg
 	if err != nil {
 		return []GetProgramAccounts{}, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return []GetProgramAccounts{}, errors.New(res.Error.Message)
-	}
 	return res.Result.Value, nil
 }
@@ -1,9 +1,6 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type GetRecentBlockHashResponse struct {
 	Blockhash     string        `json:"blockhash"`
@@ -22,8 +19,5 @@ func (s *Client) GetRecentBlockhash(ctx context.Context) (GetRecentBlockHashResp
 	if err != nil {
 		return GetRecentBlockHashResponse{}, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return GetRecentBlockHashResponse{}, errors.New(res.Error.Message)
-	}
 	return res.Result.Value, nil
 }
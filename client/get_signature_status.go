@@ -1,15 +1,12 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type GetSignatureStatusesResponse struct {
-	Slot               uint64      `json:"slot"`
-	Confirmations      *uint64     `json:"confirmations"`
-	ConfirmationStatus *Commitment `json:"confirmationStatus"`
-	Err                interface{} `json:"err"`
+	Slot               uint64            `json:"slot"`
+	Confirmations      *uint64           `json:"confirmations"`
+	ConfirmationStatus *Commitment       `json:"confirmationStatus"`
+	Err                *TransactionError `json:"err"`
 }
 
 func (s *Client) GetSignatureStatuses(ctx context.Context, signatures []string) ([]GetSignatureStatusesResponse, error) {
@@ -24,8 +21,5 @@ func (s *Client) GetSignatureStatuses(ctx context.Context, signatures []string)
 	if err != nil {
 		return nil, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return nil, errors.New(res.Error.Message)
-	}
 	return res.Result.Value, nil
 }
@@ -1,9 +1,6 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type StakeActivationState string
 
@@ -35,8 +32,5 @@ func (s *Client) GetStakeActivation(ctx context.Context, address string, cfg Get
 	if err != nil {
 		return GetStakeActivationResponse{}, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return GetStakeActivationResponse{}, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
@@ -1,9 +1,6 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type GetTokenSupply struct {
 	Amount         string `json:"amount"`
@@ -24,8 +21,5 @@ func (s *Client) GetTokenSupply(ctx context.Context, mintBase58Addr string, comm
 	if err != nil {
 		return GetTokenSupply{}, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return GetTokenSupply{}, errors.New(res.Error.Message)
-	}
 	return res.Result.Value, nil
 }
@@ -1,9 +1,6 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 func (s *Client) MinimumLedgerSlot(ctx context.Context) (uint64, error) {
 	res := struct {
@@ -14,8 +11,5 @@ func (s *Client) MinimumLedgerSlot(ctx context.Context) (uint64, error) {
 	if err != nil {
 		return 0, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return 0, errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+type BalanceStrategy int
+
+const (
+	// BalanceStrategyRoundRobin spreads requests over all healthy endpoints
+	BalanceStrategyRoundRobin BalanceStrategy = iota
+	// BalanceStrategyHighestSlot sends requests to the healthy endpoint with the highest slot
+	BalanceStrategyHighestSlot
+)
+
+type MultiClientConfig struct {
+	Strategy BalanceStrategy
+	// MaxSlotLag marks an endpoint unhealthy if its slot is more than MaxSlotLag behind the highest one, 0 means no limit
+	MaxSlotLag uint64
+}
+
+// EndpointStatus is the state of an endpoint after the last health check or request
+type EndpointStatus struct {
+	Endpoint string
+	Healthy  bool
+	Slot     uint64
+	Err      error
+}
+
+// MultiClient is a Client backed by several endpoints. Requests go to the endpoint picked by
+// the strategy and fail over to the next one on transport errors, http 429 and 5xx.
+// sendTransaction and requestAirdrop only fail over if the retry policy allows re-sending them.
+type MultiClient struct {
+	*Client
+	nodes      []*Client
+	maxSlotLag uint64
+}
+
+// NewMultiClient creates a client for the endpoints, all options apply to every endpoint.
+// All endpoints are considered healthy until CheckHealth runs.
+func NewMultiClient(endpoints []string, cfg MultiClientConfig, opts ...Option) (*MultiClient, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("no endpoints provided")
+	}
+
+	c := NewClient(endpoints[0], opts...)
+	c.pool = newEndpointPool(endpoints, cfg.Strategy)
+
+	nodes := make([]*Client, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		node := *c
+		node.endpoint = endpoint
+		node.pool = nil
+		node.retry = RetryPolicy{}
+		nodes = append(nodes, &node)
+	}
+
+	return &MultiClient{
+		Client:     c,
+		nodes:      nodes,
+		maxSlotLag: cfg.MaxSlotLag,
+	}, nil
+}
+
+// CheckHealth calls getHealth and getSlot on every endpoint and updates their state
+func (m *MultiClient) CheckHealth(ctx context.Context) []EndpointStatus {
+	statuses := make([]EndpointStatus, len(m.nodes))
+	var wg sync.WaitGroup
+	for i, node := range m.nodes {
+		wg.Add(1)
+		go func(i int, node *Client) {
+			defer wg.Done()
+			status := EndpointStatus{Endpoint: node.endpoint}
+			if _, status.Err = node.GetHealth(ctx); status.Err == nil {
+				status.Slot, status.Err = node.GetSlot(ctx)
+			}
+			status.Healthy = status.Err == nil
+			statuses[i] = status
+		}(i, node)
+	}
+	wg.Wait()
+
+	if m.maxSlotLag > 0 {
+		var highest uint64
+		for _, status := range statuses {
+			if status.Healthy && status.Slot > highest {
+				highest = status.Slot
+			}
+		}
+		for i := range statuses {
+			if statuses[i].Healthy && statuses[i].Slot+m.maxSlotLag < highest {
+				statuses[i].Healthy = false
+				statuses[i].Err = errors.New("endpoint is behind")
+			}
+		}
+	}
+
+	m.pool.update(statuses)
+	return statuses
+}
+
+// RunHealthCheck calls CheckHealth every interval until ctx is done
+func (m *MultiClient) RunHealthCheck(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		m.CheckHealth(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Endpoints returns the current state of all endpoints
+func (m *MultiClient) Endpoints() []EndpointStatus {
+	return m.pool.statuses()
+}
+
+type endpointPool struct {
+	mu       sync.Mutex
+	strategy BalanceStrategy
+	nodes    []EndpointStatus
+	next     int
+}
+
+func newEndpointPool(endpoints []string, strategy BalanceStrategy) *endpointPool {
+	nodes := make([]EndpointStatus, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		nodes = append(nodes, EndpointStatus{Endpoint: endpoint, Healthy: true})
+	}
+	return &endpointPool{strategy: strategy, nodes: nodes}
+}
+
+// order returns the endpoints in the order they should be tried.
+// Healthy ones come first, unhealthy ones are kept as the last resort.
+func (p *endpointPool) order() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	healthy := make([]EndpointStatus, 0, len(p.nodes))
+	unhealthy := make([]EndpointStatus, 0, len(p.nodes))
+	for i := range p.nodes {
+		// rotate so that round robin starts from a different endpoint each time
+		node := p.nodes[(p.next+i)%len(p.nodes)]
+		if node.Healthy {
+			healthy = append(healthy, node)
+		} else {
+			unhealthy = append(unhealthy, node)
+		}
+	}
+	p.next = (p.next + 1) % len(p.nodes)
+
+	if p.strategy == BalanceStrategyHighestSlot {
+		sort.SliceStable(healthy, func(i, j int) bool {
+			return healthy[i].Slot > healthy[j].Slot
+		})
+	}
+
+	endpoints := make([]string, 0, len(p.nodes))
+	for _, node := range healthy {
+		endpoints = append(endpoints, node.Endpoint)
+	}
+	for _, node := range unhealthy {
+		endpoints = append(endpoints, node.Endpoint)
+	}
+	return endpoints
+}
+
+// markFailed marks the endpoint unhealthy until the next health check
+func (p *endpointPool) markFailed(endpoint string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.nodes {
+		if p.nodes[i].Endpoint == endpoint {
+			p.nodes[i].Healthy = false
+			p.nodes[i].Err = err
+		}
+	}
+}
+
+func (p *endpointPool) update(statuses []EndpointStatus) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	copy(p.nodes, statuses)
+}
+
+func (p *endpointPool) statuses() []EndpointStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]EndpointStatus{}, p.nodes...)
+}
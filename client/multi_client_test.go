@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newSlotServer(slot uint64, count *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*count++
+		body, _ := ioutil.ReadAll(r.Body)
+		var req struct {
+			Method string `json:"method"`
+		}
+		json.Unmarshal(body, &req)
+		if req.Method == "getHealth" {
+			w.Write([]byte(`{"jsonrpc":"2.0","id":0,"result":"ok"}`))
+			return
+		}
+		w.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":0,"result":%d}`, slot)))
+	}))
+}
+
+func TestMultiClientFailover(t *testing.T) {
+	var downCount, upCount int
+	down := newSlotServer(0, &downCount)
+	down.Close()
+	up := newSlotServer(100, &upCount)
+	defer up.Close()
+
+	c, err := NewMultiClient([]string{down.URL, up.URL}, MultiClientConfig{})
+	if err != nil {
+		t.Fatalf("NewMultiClient() error = %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if slot, err := c.GetSlot(context.Background()); err != nil || slot != 100 {
+			t.Fatalf("GetSlot() = %v, %v, want 100", slot, err)
+		}
+	}
+	if upCount != 3 {
+		t.Errorf("up endpoint got %d requests, want 3", upCount)
+	}
+	for _, status := range c.Endpoints() {
+		if status.Healthy != (status.Endpoint == up.URL) {
+			t.Errorf("endpoint %v healthy = %v", status.Endpoint, status.Healthy)
+		}
+	}
+
+	// a non idempotent method doesn't fail over
+	c.pool.update([]EndpointStatus{{Endpoint: down.URL, Healthy: true}, {Endpoint: up.URL, Healthy: true}})
+	upCount = 0
+	c.pool.next = 0
+	if _, err := c.RequestAirdrop(context.Background(), "", 1); err == nil || upCount != 0 {
+		t.Errorf("RequestAirdrop() error = %v, up endpoint got %d requests", err, upCount)
+	}
+}
+
+func TestMultiClientHighestSlot(t *testing.T) {
+	var count1, count2, count3 int
+	srv1 := newSlotServer(100, &count1)
+	defer srv1.Close()
+	srv2 := newSlotServer(200, &count2)
+	defer srv2.Close()
+	srv3 := newSlotServer(150, &count3)
+	defer srv3.Close()
+
+	c, err := NewMultiClient([]string{srv1.URL, srv2.URL, srv3.URL}, MultiClientConfig{
+		Strategy:   BalanceStrategyHighestSlot,
+		MaxSlotLag: 60,
+	})
+	if err != nil {
+		t.Fatalf("NewMultiClient() error = %v", err)
+	}
+	statuses := c.CheckHealth(context.Background())
+	wantHealthy := []bool{false, true, true}
+	for i, status := range statuses {
+		if status.Healthy != wantHealthy[i] {
+			t.Errorf("endpoint #%d healthy = %v, want %v", i, status.Healthy, wantHealthy[i])
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if slot, err := c.GetSlot(context.Background()); err != nil || slot != 200 {
+			t.Errorf("GetSlot() = %v, %v, want 200", slot, err)
+		}
+	}
+}
+
+func TestMultiClientNodeUnhealthy(t *testing.T) {
+	var behindCount, upCount int
+	behind := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		behindCount++
+		w.Write([]byte(`{"jsonrpc":"2.0","id":0,"error":{"code":-32005,"message":"Node is behind by 42 slots","data":{"numSlotsBehind":42}}}`))
+	}))
+	defer behind.Close()
+	up := newSlotServer(100, &upCount)
+	defer up.Close()
+
+	c, err := NewMultiClient([]string{behind.URL, up.URL}, MultiClientConfig{})
+	if err != nil {
+		t.Fatalf("NewMultiClient() error = %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if slot, err := c.GetSlot(context.Background()); err != nil || slot != 100 {
+			t.Fatalf("GetSlot() = %v, %v, want 100", slot, err)
+		}
+	}
+	if behindCount != 1 || upCount != 3 {
+		t.Errorf("behind endpoint got %d requests, up endpoint got %d, want 1 and 3", behindCount, upCount)
+	}
+	for _, status := range c.Endpoints() {
+		if status.Healthy != (status.Endpoint == up.URL) {
+			t.Errorf("endpoint %v healthy = %v", status.Endpoint, status.Healthy)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures a Client, pass them to NewClient
+type Option func(*clientConfig)
+
+type clientConfig struct {
+	httpClient *http.Client
+	transport  http.RoundTripper
+	headers    http.Header
+	timeout    time.Duration
+	userAgent  string
+	retry      RetryPolicy
+	limiter    *rateLimiter
+}
+
+// WithHTTPClient uses the given http client for all requests. The client is not modified.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(cfg *clientConfig) {
+		cfg.httpClient = httpClient
+	}
+}
+
+// WithTransport uses the given round tripper for all requests
+func WithTransport(transport http.RoundTripper) Option {
+	return func(cfg *clientConfig) {
+		cfg.transport = transport
+	}
+}
+
+// WithHeader adds a header to every request, e.g. an auth token required by the rpc provider
+func WithHeader(key, value string) Option {
+	return func(cfg *clientConfig) {
+		cfg.headers.Add(key, value)
+	}
+}
+
+// WithHeaders adds all headers to every request
+func WithHeaders(headers map[string]string) Option {
+	return func(cfg *clientConfig) {
+		for k, v := range headers {
+			cfg.headers.Add(k, v)
+		}
+	}
+}
+
+// WithTimeout limits the duration of each request, 0 means no limit
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *clientConfig) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithUserAgent sets the User-Agent header of every request
+func WithUserAgent(userAgent string) Option {
+	return func(cfg *clientConfig) {
+		cfg.userAgent = userAgent
+	}
+}
+
+// WithRetry retries failed requests according to the policy, see RetryPolicy
+func WithRetry(policy RetryPolicy) Option {
+	return func(cfg *clientConfig) {
+		cfg.retry = policy
+	}
+}
+
+// WithRateLimit limits the client to rps requests per second with bursts of up to burst requests.
+// Every http request, including each retry, takes a token.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(cfg *clientConfig) {
+		if rps <= 0 {
+			cfg.limiter = nil
+			return
+		}
+		cfg.limiter = newRateLimiter(rps, burst)
+	}
+}
+
+func (cfg *clientConfig) buildHTTPClient() *http.Client {
+	if cfg.httpClient == nil {
+		return &http.Client{Transport: cfg.transport}
+	}
+	if cfg.transport == nil {
+		return cfg.httpClient
+	}
+	httpClient := *cfg.httpClient
+	httpClient.Transport = cfg.transport
+	return &httpClient
+}
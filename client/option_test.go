@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type countTransport struct {
+	count int
+}
+
+func (t *countTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.count++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestNewClientOptions(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{"jsonrpc":"2.0","id":0,"result":100}`))
+	}))
+	defer srv.Close()
+
+	transport := &countTransport{}
+	c := NewClient(
+		srv.URL,
+		WithTransport(transport),
+		WithHeader("Authorization", "Bearer token"),
+		WithHeaders(map[string]string{"X-Api-Key": "key"}),
+		WithUserAgent("my-agent"),
+		WithTimeout(time.Second),
+	)
+	for i := 0; i < 2; i++ {
+		slot, err := c.GetSlot(context.Background())
+		if err != nil || slot != 100 {
+			t.Fatalf("GetSlot() = %v, %v, want 100", slot, err)
+		}
+	}
+
+	if transport.count != 2 {
+		t.Errorf("transport called %d times, want 2", transport.count)
+	}
+	for k, v := range map[string]string{
+		"Authorization": "Bearer token",
+		"X-Api-Key":     "key",
+		"User-Agent":    "my-agent",
+		"Content-Type":  "application/json",
+	} {
+		if got.Get(k) != v {
+			t.Errorf("header %s = %v, want %v", k, got.Get(k), v)
+		}
+	}
+}
+
+func TestNewClientTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, WithTimeout(10*time.Millisecond))
+	if _, err := c.GetSlot(context.Background()); err == nil {
+		t.Errorf("GetSlot() error = nil, want timeout")
+	}
+}
@@ -1,9 +1,6 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 // RequestAirdrop Requests an airdrop of lamports to a Pubkey, return string is Transaction Signature of airdrop, as base-58 encoded
 func (s *Client) RequestAirdrop(ctx context.Context, base58Addr string, lamport uint64) (string, error) {
@@ -15,8 +12,5 @@ func (s *Client) RequestAirdrop(ctx context.Context, base58Addr string, lamport
 	if err != nil {
 		return "", err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return "", errors.New(res.Error.Message)
-	}
 	return res.Result, nil
 }
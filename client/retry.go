@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// HTTPStatusError is returned when the node responds with a non 2xx status code and no json-rpc error
+type HTTPStatusError struct {
+	StatusCode int
+	// RetryAfter is parsed from the Retry-After header, 0 if absent
+	RetryAfter time.Duration
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("get status code: %d", e.StatusCode)
+}
+
+// RetryPolicy controls how a failed request is retried.
+// Only requests which hit http 429, 5xx, a transport error or a node behind the cluster are retried,
+// and only for read methods unless RetrySendTransaction is set.
+type RetryPolicy struct {
+	// MaxAttempts includes the first attempt, a value <= 1 disables retry
+	MaxAttempts int
+	// MinBackoff is the base of the exponential backoff, default: 100ms
+	MinBackoff time.Duration
+	// MaxBackoff caps the backoff and the Retry-After value, default: 10s
+	MaxBackoff time.Duration
+	// RetrySendTransaction allows re-sending sendTransaction and requestAirdrop.
+	// A signed tx can't land twice, but each requestAirdrop retry may create a new airdrop.
+	RetrySendTransaction bool
+}
+
+// DefaultRetryPolicy is a reasonable policy for public endpoints
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	MinBackoff:  100 * time.Millisecond,
+	MaxBackoff:  10 * time.Second,
+}
+
+// nonIdempotentMethods are never retried unless RetryPolicy.RetrySendTransaction is set
+var nonIdempotentMethods = map[string]bool{
+	"sendTransaction": true,
+	"requestAirdrop":  true,
+}
+
+func (p RetryPolicy) allow(methods ...string) bool {
+	return p.MaxAttempts > 1 && p.idempotent(methods...)
+}
+
+// idempotent reports whether methods may be sent more than once
+func (p RetryPolicy) idempotent(methods ...string) bool {
+	if p.RetrySendTransaction {
+		return true
+	}
+	for _, method := range methods {
+		if nonIdempotentMethods[method] {
+			return false
+		}
+	}
+	return true
+}
+
+// backoff returns the wait before the attempt-th retry (start from 1), using full jitter
+func (p RetryPolicy) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	minBackoff, maxBackoff := p.MinBackoff, p.MaxBackoff
+	if minBackoff <= 0 {
+		minBackoff = DefaultRetryPolicy.MinBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryPolicy.MaxBackoff
+	}
+	if retryAfter > 0 {
+		if retryAfter > maxBackoff {
+			return maxBackoff
+		}
+		return retryAfter
+	}
+	d := minBackoff
+	for i := 1; i < attempt && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return time.Duration(rand.Int63n(int64(d)) + 1)
+}
+
+func isRetryable(ctx context.Context, err error) (bool, time.Duration) {
+	if ctx.Err() != nil {
+		return false, 0
+	}
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		switch {
+		case statusErr.StatusCode == http.StatusTooManyRequests,
+			statusErr.StatusCode >= 500 && statusErr.StatusCode != http.StatusNotImplemented:
+			return true, statusErr.RetryAfter
+		}
+		return false, 0
+	}
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		return isNodeBehind(rpcErr), 0
+	}
+	// transport error
+	return true, 0
+}
+
+// isNodeBehind reports whether the node rejected the request because it lags behind the cluster,
+// another node may serve it
+func isNodeBehind(err *RPCError) bool {
+	switch err.Code {
+	case RPCErrorCodeNodeUnhealthy, RPCErrorCodeMinContextSlotNotReached:
+		return true
+	}
+	return false
+}
+
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if sec, err := strconv.Atoi(v); err == nil && sec > 0 {
+		return time.Duration(sec) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// rateLimiter is a token bucket which refills rate tokens per second up to burst
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is available or ctx is done
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	// take the token now, a negative balance reserves a future token
+	l.tokens--
+	var d time.Duration
+	if l.tokens < 0 {
+		d = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if d == 0 {
+		return nil
+	}
+	if err := sleep(ctx, d); err != nil {
+		// give the reserved token back
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
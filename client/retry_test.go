@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	count := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		switch count {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.Write([]byte(`{"jsonrpc":"2.0","id":0,"result":"sig"}`))
+		}
+	}))
+	defer srv.Close()
+
+	policy := RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+	c := NewClient(srv.URL, WithRetry(policy))
+	if got, err := c.GetGenesisHash(context.Background()); err != nil || got != "sig" || count != 3 {
+		t.Errorf("GetGenesisHash() = %v, %v, count = %v", got, err, count)
+	}
+
+	// sendTransaction is not retried by default
+	count = 0
+	var statusErr *HTTPStatusError
+	if _, err := c.SendTransaction(context.Background(), "", SendTransactionConfig{}); !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusTooManyRequests || count != 1 {
+		t.Errorf("SendTransaction() error = %v, count = %v", err, count)
+	}
+
+	count = 0
+	policy.RetrySendTransaction = true
+	c = NewClient(srv.URL, WithRetry(policy))
+	if got, err := c.SendTransaction(context.Background(), "", SendTransactionConfig{}); err != nil || got != "sig" || count != 3 {
+		t.Errorf("SendTransaction() = %v, %v, count = %v", got, err, count)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, MinBackoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+	for attempt := 1; attempt < 10; attempt++ {
+		if d := p.backoff(attempt, 0); d <= 0 || d > p.MaxBackoff {
+			t.Errorf("backoff(%d) = %v, want in (0, %v]", attempt, d, p.MaxBackoff)
+		}
+	}
+	if d := p.backoff(1, 20*time.Millisecond); d != 20*time.Millisecond {
+		t.Errorf("backoff() with retry after = %v, want 20ms", d)
+	}
+	if d := p.backoff(1, time.Minute); d != p.MaxBackoff {
+		t.Errorf("backoff() with long retry after = %v, want %v", d, p.MaxBackoff)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	l := newRateLimiter(100, 2)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatalf("wait() error = %v", err)
+		}
+	}
+	// 2 tokens from the burst, 2 more need ~20ms
+	if d := time.Since(start); d < 15*time.Millisecond {
+		t.Errorf("4 waits took %v, want >= 15ms", d)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := newRateLimiter(0.001, 1).wait(ctx); err != nil {
+		t.Errorf("wait() with burst error = %v", err)
+	}
+	l = newRateLimiter(0.001, 1)
+	l.wait(context.Background())
+	if err := l.wait(ctx); err == nil {
+		t.Errorf("wait() on canceled context error = nil")
+	}
+}
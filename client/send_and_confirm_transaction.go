@@ -0,0 +1,216 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+// MaxProcessingAge is the number of blocks a blockhash stays valid for
+const MaxProcessingAge = 150
+
+const (
+	defaultConfirmPollInterval = 500 * time.Millisecond
+	defaultRebroadcastInterval = 2 * time.Second
+	// maxConfirmRPCErrors is how many polls in a row may fail before the rpc error is returned
+	maxConfirmRPCErrors = 10
+)
+
+type SendAndConfirmTransactionConfig struct {
+	// Commitment to wait for, default: finalized
+	Commitment          Commitment
+	SkipPreflight       bool
+	PreflightCommitment Commitment // default: finalized
+	// LastValidBlockHeight is the one returned by GetLatestBlockhash together with the tx's blockhash.
+	// If it is 0, the current block height + MaxProcessingAge is used, which may wait longer than needed.
+	LastValidBlockHeight uint64
+	// PollInterval is how often the signature status is checked, default: 500ms
+	PollInterval time.Duration
+	// RebroadcastInterval is how often the tx is sent again until it lands, default: 2s
+	RebroadcastInterval time.Duration
+	// Timeout limits the whole call, 0 means only ctx applies
+	Timeout time.Duration
+}
+
+// TransactionFailedError means the tx landed but failed on chain
+type TransactionFailedError struct {
+	Signature string
+	Slot      uint64
+	Err       *TransactionError
+}
+
+func (e *TransactionFailedError) Error() string {
+	return fmt.Sprintf("transaction %s failed: %v", e.Signature, e.Err)
+}
+
+func (e *TransactionFailedError) Unwrap() error {
+	return e.Err
+}
+
+// TransactionExpiredError means the tx's blockhash expired before the tx landed,
+// the tx will never be processed and it is safe to sign it again with a new blockhash
+type TransactionExpiredError struct {
+	Signature            string
+	LastValidBlockHeight uint64
+}
+
+func (e *TransactionExpiredError) Error() string {
+	return fmt.Sprintf("transaction %s expired: block height exceeded %d", e.Signature, e.LastValidBlockHeight)
+}
+
+// TransactionTimeoutError means the timeout or ctx ended before the tx reached the commitment.
+// The tx may still land.
+type TransactionTimeoutError struct {
+	Signature string
+	Err       error
+}
+
+func (e *TransactionTimeoutError) Error() string {
+	return fmt.Sprintf("transaction %s is not confirmed: %v", e.Signature, e.Err)
+}
+
+func (e *TransactionTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// SendAndConfirmTransaction sends the serialized tx, rebroadcasts it until it lands or its blockhash
+// expires and waits until it reaches the commitment. It returns the signature, on failure the error is
+// a *TransactionFailedError, *TransactionExpiredError, *TransactionTimeoutError, the error of the first send,
+// or the last rpc error if polling keeps failing or fails after the blockhash expired.
+func (s *Client) SendAndConfirmTransaction(ctx context.Context, tx []byte, cfg SendAndConfirmTransactionConfig) (string, error) {
+	if cfg.Commitment == "" {
+		cfg.Commitment = CommitmentFinalized
+	}
+	if cfg.PreflightCommitment == "" {
+		cfg.PreflightCommitment = CommitmentFinalized
+	}
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = defaultConfirmPollInterval
+	}
+	if cfg.RebroadcastInterval == 0 {
+		cfg.RebroadcastInterval = defaultRebroadcastInterval
+	}
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+
+	lastValidBlockHeight := cfg.LastValidBlockHeight
+	if lastValidBlockHeight == 0 {
+		blockHeight, err := s.GetBlockHeight(ctx, CommitmentConfirmed)
+		if err != nil {
+			return "", err
+		}
+		lastValidBlockHeight = blockHeight + MaxProcessingAge
+	}
+
+	rawTx := base64.StdEncoding.EncodeToString(tx)
+	signature, err := s.SendTransaction(ctx, rawTx, SendTransactionConfig{
+		SkipPreflight:       cfg.SkipPreflight,
+		PreflightCommitment: cfg.PreflightCommitment,
+		Encoding:            "base64",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	poll := time.NewTicker(cfg.PollInterval)
+	defer poll.Stop()
+	lastSent := time.Now()
+	var failures int
+	var expired bool
+	for {
+		select {
+		case <-ctx.Done():
+			return signature, &TransactionTimeoutError{Signature: signature, Err: ctx.Err()}
+		case <-poll.C:
+		}
+
+		// get the block height before the status, so a tx which lands in between is not reported as expired
+		blockHeight, err := s.GetBlockHeight(ctx, CommitmentConfirmed)
+		var status *GetSignatureStatusesResponse
+		if err == nil {
+			expired = blockHeight > lastValidBlockHeight
+			status, err = s.getSignatureStatus(ctx, signature)
+		}
+		if err != nil {
+			// keep polling through a flaky node, but not forever
+			failures++
+			if failures >= maxConfirmRPCErrors || expired {
+				return signature, err
+			}
+			continue
+		}
+		failures = 0
+
+		if status != nil {
+			if status.Err != nil {
+				return signature, &TransactionFailedError{Signature: signature, Slot: status.Slot, Err: status.Err}
+			}
+			if reachCommitment(status, cfg.Commitment) {
+				return signature, nil
+			}
+			continue
+		}
+
+		// a tx seen before may be gone with a dropped fork, it expires like a tx which never landed
+		if expired {
+			return signature, &TransactionExpiredError{Signature: signature, LastValidBlockHeight: lastValidBlockHeight}
+		}
+
+		if time.Since(lastSent) >= cfg.RebroadcastInterval {
+			// the tx already passed preflight, a rebroadcast fails it with "already processed" if it landed meanwhile
+			s.SendTransaction(ctx, rawTx, SendTransactionConfig{
+				SkipPreflight:       true,
+				PreflightCommitment: cfg.PreflightCommitment,
+				Encoding:            "base64",
+			})
+			lastSent = time.Now()
+		}
+	}
+}
+
+// getSignatureStatus returns nil if the node doesn't know the signature
+func (s *Client) getSignatureStatus(ctx context.Context, signature string) (*GetSignatureStatusesResponse, error) {
+	res := struct {
+		GeneralResponse
+		Result struct {
+			Context Context                         `json:"context"`
+			Value   []*GetSignatureStatusesResponse `json:"value"`
+		} `json:"result"`
+	}{}
+	err := s.request(ctx, "getSignatureStatuses", []interface{}{[]string{signature}}, &res)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Result.Value) == 0 {
+		return nil, nil
+	}
+	return res.Result.Value[0], nil
+}
+
+func reachCommitment(status *GetSignatureStatusesResponse, commitment Commitment) bool {
+	current := Commitment(CommitmentProcessed)
+	switch {
+	case status.ConfirmationStatus != nil:
+		current = *status.ConfirmationStatus
+	case status.Confirmations == nil:
+		// old nodes only report confirmations, which is null once the tx is finalized
+		current = CommitmentFinalized
+	}
+	return commitmentLevel(current) >= commitmentLevel(commitment)
+}
+
+func commitmentLevel(commitment Commitment) int {
+	switch commitment {
+	case CommitmentProcessed:
+		return 0
+	case CommitmentConfirmed:
+		return 1
+	case CommitmentFinalized:
+		return 2
+	}
+	return 2
+}
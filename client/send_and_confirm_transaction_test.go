@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newConfirmServer returns "sig" for sendTransaction, a block height increasing by one per request
+// and the status returned by status for the n-th getSignatureStatuses request
+func newConfirmServer(status func(n int) string) (*httptest.Server, *int) {
+	var mu sync.Mutex
+	var sends, statuses int
+	var blockHeight uint64 = 100
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		body, _ := ioutil.ReadAll(r.Body)
+		var req struct {
+			Method string `json:"method"`
+		}
+		json.Unmarshal(body, &req)
+		switch req.Method {
+		case "sendTransaction":
+			sends++
+			w.Write([]byte(`{"jsonrpc":"2.0","id":0,"result":"sig"}`))
+		case "getBlockHeight":
+			blockHeight++
+			w.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":0,"result":%d}`, blockHeight)))
+		case "getSignatureStatuses":
+			statuses++
+			w.Write([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":0,"result":{"context":{"slot":1},"value":[%s]}}`, status(statuses))))
+		}
+	}))
+	return srv, &sends
+}
+
+func TestSendAndConfirmTransaction(t *testing.T) {
+	cfg := SendAndConfirmTransactionConfig{
+		Commitment:          CommitmentConfirmed,
+		PollInterval:        time.Millisecond,
+		RebroadcastInterval: time.Nanosecond,
+	}
+
+	t.Run("confirmed", func(t *testing.T) {
+		srv, sends := newConfirmServer(func(n int) string {
+			switch {
+			case n < 3:
+				return `null`
+			case n < 5:
+				return `{"slot":10,"confirmations":0,"confirmationStatus":"processed","err":null}`
+			}
+			return `{"slot":10,"confirmations":1,"confirmationStatus":"confirmed","err":null}`
+		})
+		defer srv.Close()
+
+		sig, err := NewClient(srv.URL).SendAndConfirmTransaction(context.Background(), []byte{1}, cfg)
+		if err != nil || sig != "sig" {
+			t.Fatalf("SendAndConfirmTransaction() = %v, %v", sig, err)
+		}
+		// the first send and a rebroadcast for each poll before the tx landed
+		if *sends != 3 {
+			t.Errorf("sendTransaction called %d times, want 3", *sends)
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		srv, _ := newConfirmServer(func(n int) string {
+			return `{"slot":10,"confirmations":0,"confirmationStatus":"processed","err":{"InstructionError":[0,{"Custom":1}]}}`
+		})
+		defer srv.Close()
+
+		_, err := NewClient(srv.URL).SendAndConfirmTransaction(context.Background(), []byte{1}, cfg)
+		var failedErr *TransactionFailedError
+		if !errors.As(err, &failedErr) || failedErr.Slot != 10 || failedErr.Err.Kind != TransactionErrorKindInstructionError {
+			t.Errorf("SendAndConfirmTransaction() error = %v, want TransactionFailedError", err)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		srv, _ := newConfirmServer(func(n int) string { return `null` })
+		defer srv.Close()
+
+		cfg := cfg
+		cfg.LastValidBlockHeight = 105
+		_, err := NewClient(srv.URL).SendAndConfirmTransaction(context.Background(), []byte{1}, cfg)
+		var expiredErr *TransactionExpiredError
+		if !errors.As(err, &expiredErr) || expiredErr.LastValidBlockHeight != 105 {
+			t.Errorf("SendAndConfirmTransaction() error = %v, want TransactionExpiredError", err)
+		}
+	})
+
+	t.Run("dropped after processed", func(t *testing.T) {
+		srv, _ := newConfirmServer(func(n int) string {
+			if n < 3 {
+				return `{"slot":10,"confirmations":0,"confirmationStatus":"processed","err":null}`
+			}
+			return `null`
+		})
+		defer srv.Close()
+
+		cfg := cfg
+		cfg.LastValidBlockHeight = 105
+		_, err := NewClient(srv.URL).SendAndConfirmTransaction(context.Background(), []byte{1}, cfg)
+		var expiredErr *TransactionExpiredError
+		if !errors.As(err, &expiredErr) || expiredErr.LastValidBlockHeight != 105 {
+			t.Errorf("SendAndConfirmTransaction() error = %v, want TransactionExpiredError", err)
+		}
+	})
+
+	t.Run("rpc always fails", func(t *testing.T) {
+		var polls int
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			var req struct {
+				Method string `json:"method"`
+			}
+			json.Unmarshal(body, &req)
+			if req.Method == "sendTransaction" {
+				w.Write([]byte(`{"jsonrpc":"2.0","id":0,"result":"sig"}`))
+				return
+			}
+			polls++
+			w.Write([]byte(`{"jsonrpc":"2.0","id":0,"error":{"code":-32603,"message":"Internal error"}}`))
+		}))
+		defer srv.Close()
+
+		cfg := cfg
+		cfg.LastValidBlockHeight = 1000
+		cfg.Timeout = 5 * time.Second
+		_, err := NewClient(srv.URL).SendAndConfirmTransaction(context.Background(), []byte{1}, cfg)
+		var rpcErr *RPCError
+		if !errors.As(err, &rpcErr) || rpcErr.Code != RPCErrorCodeInternalError {
+			t.Errorf("SendAndConfirmTransaction() error = %v, want the rpc error", err)
+		}
+		if polls != maxConfirmRPCErrors {
+			t.Errorf("polled %d times, want %d", polls, maxConfirmRPCErrors)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		srv, _ := newConfirmServer(func(n int) string {
+			return `{"slot":10,"confirmations":0,"confirmationStatus":"processed","err":null}`
+		})
+		defer srv.Close()
+
+		cfg := cfg
+		cfg.Commitment = CommitmentFinalized
+		cfg.Timeout = 50 * time.Millisecond
+		_, err := NewClient(srv.URL).SendAndConfirmTransaction(context.Background(), []byte{1}, cfg)
+		var timeoutErr *TransactionTimeoutError
+		if !errors.As(err, &timeoutErr) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("SendAndConfirmTransaction() error = %v, want TransactionTimeoutError", err)
+		}
+	})
+}
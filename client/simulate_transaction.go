@@ -1,9 +1,6 @@
 package client
 
-import (
-	"context"
-	"errors"
-)
+import "context"
 
 type SimulateTransactionConfig struct {
 	SigVerify           bool       `json:"sigVerify"`           // default: false
@@ -12,8 +9,8 @@ type SimulateTransactionConfig struct {
 }
 
 type SimulateTransactionResponse struct {
-	Err  interface{} `json:"err"`
-	Logs []string    `json:"logs"`
+	Err  *TransactionError `json:"err"`
+	Logs []string          `json:"logs"`
 }
 
 func (s *Client) SimulateTransaction(ctx context.Context, rawTx string, cfg SimulateTransactionConfig) (SimulateTransactionResponse, error) {
@@ -28,8 +25,5 @@ func (s *Client) SimulateTransaction(ctx context.Context, rawTx string, cfg Simu
 	if err != nil {
 		return SimulateTransactionResponse{}, err
 	}
-	if res.Error != (ErrorResponse{}) {
-		return SimulateTransactionResponse{}, errors.New(res.Error.Message)
-	}
 	return res.Result.Value, nil
 }
@@ -0,0 +1,277 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/tokenprog"
+)
+
+type TransactionErrorKind string
+
+const (
+	TransactionErrorKindAccountInUse                          TransactionErrorKind = "AccountInUse"
+	TransactionErrorKindAccountLoadedTwice                    TransactionErrorKind = "AccountLoadedTwice"
+	TransactionErrorKindAccountNotFound                       TransactionErrorKind = "AccountNotFound"
+	TransactionErrorKindProgramAccountNotFound                TransactionErrorKind = "ProgramAccountNotFound"
+	TransactionErrorKindInsufficientFundsForFee               TransactionErrorKind = "InsufficientFundsForFee"
+	TransactionErrorKindInvalidAccountForFee                  TransactionErrorKind = "InvalidAccountForFee"
+	TransactionErrorKindAlreadyProcessed                      TransactionErrorKind = "AlreadyProcessed"
+	TransactionErrorKindBlockhashNotFound                     TransactionErrorKind = "BlockhashNotFound"
+	TransactionErrorKindInstructionError                      TransactionErrorKind = "InstructionError"
+	TransactionErrorKindCallChainTooDeep                      TransactionErrorKind = "CallChainTooDeep"
+	TransactionErrorKindMissingSignatureForFee                TransactionErrorKind = "MissingSignatureForFee"
+	TransactionErrorKindInvalidAccountIndex                   TransactionErrorKind = "InvalidAccountIndex"
+	TransactionErrorKindSignatureFailure                      TransactionErrorKind = "SignatureFailure"
+	TransactionErrorKindInvalidProgramForExecution            TransactionErrorKind = "InvalidProgramForExecution"
+	TransactionErrorKindSanitizeFailure                       TransactionErrorKind = "SanitizeFailure"
+	TransactionErrorKindClusterMaintenance                    TransactionErrorKind = "ClusterMaintenance"
+	TransactionErrorKindAccountBorrowOutstanding              TransactionErrorKind = "AccountBorrowOutstanding"
+	TransactionErrorKindWouldExceedMaxBlockCostLimit          TransactionErrorKind = "WouldExceedMaxBlockCostLimit"
+	TransactionErrorKindUnsupportedVersion                    TransactionErrorKind = "UnsupportedVersion"
+	TransactionErrorKindInvalidWritableAccount                TransactionErrorKind = "InvalidWritableAccount"
+	TransactionErrorKindWouldExceedMaxAccountCostLimit        TransactionErrorKind = "WouldExceedMaxAccountCostLimit"
+	TransactionErrorKindWouldExceedAccountDataBlockLimit      TransactionErrorKind = "WouldExceedAccountDataBlockLimit"
+	TransactionErrorKindTooManyAccountLocks                   TransactionErrorKind = "TooManyAccountLocks"
+	TransactionErrorKindAddressLookupTableNotFound            TransactionErrorKind = "AddressLookupTableNotFound"
+	TransactionErrorKindInvalidAddressLookupTableOwner        TransactionErrorKind = "InvalidAddressLookupTableOwner"
+	TransactionErrorKindInvalidAddressLookupTableData         TransactionErrorKind = "InvalidAddressLookupTableData"
+	TransactionErrorKindInvalidAddressLookupTableIndex        TransactionErrorKind = "InvalidAddressLookupTableIndex"
+	TransactionErrorKindInvalidRentPayingAccount              TransactionErrorKind = "InvalidRentPayingAccount"
+	TransactionErrorKindWouldExceedMaxVoteCostLimit           TransactionErrorKind = "WouldExceedMaxVoteCostLimit"
+	TransactionErrorKindWouldExceedAccountDataTotalLimit      TransactionErrorKind = "WouldExceedAccountDataTotalLimit"
+	TransactionErrorKindDuplicateInstruction                  TransactionErrorKind = "DuplicateInstruction"
+	TransactionErrorKindInsufficientFundsForRent              TransactionErrorKind = "InsufficientFundsForRent"
+	TransactionErrorKindMaxLoadedAccountsDataSizeExceeded     TransactionErrorKind = "MaxLoadedAccountsDataSizeExceeded"
+	TransactionErrorKindInvalidLoadedAccountsDataSizeLimit    TransactionErrorKind = "InvalidLoadedAccountsDataSizeLimit"
+	TransactionErrorKindResanitizationNeeded                  TransactionErrorKind = "ResanitizationNeeded"
+	TransactionErrorKindProgramExecutionTemporarilyRestricted TransactionErrorKind = "ProgramExecutionTemporarilyRestricted"
+	TransactionErrorKindUnbalancedTransaction                 TransactionErrorKind = "UnbalancedTransaction"
+)
+
+type InstructionErrorKind string
+
+const (
+	InstructionErrorKindGenericError                           InstructionErrorKind = "GenericError"
+	InstructionErrorKindInvalidArgument                        InstructionErrorKind = "InvalidArgument"
+	InstructionErrorKindInvalidInstructionData                 InstructionErrorKind = "InvalidInstructionData"
+	InstructionErrorKindInvalidAccountData                     InstructionErrorKind = "InvalidAccountData"
+	InstructionErrorKindAccountDataTooSmall                    InstructionErrorKind = "AccountDataTooSmall"
+	InstructionErrorKindInsufficientFunds                      InstructionErrorKind = "InsufficientFunds"
+	InstructionErrorKindIncorrectProgramId                     InstructionErrorKind = "IncorrectProgramId"
+	InstructionErrorKindMissingRequiredSignature               InstructionErrorKind = "MissingRequiredSignature"
+	InstructionErrorKindAccountAlreadyInitialized              InstructionErrorKind = "AccountAlreadyInitialized"
+	InstructionErrorKindUninitializedAccount                   InstructionErrorKind = "UninitializedAccount"
+	InstructionErrorKindUnbalancedInstruction                  InstructionErrorKind = "UnbalancedInstruction"
+	InstructionErrorKindModifiedProgramId                      InstructionErrorKind = "ModifiedProgramId"
+	InstructionErrorKindExternalAccountLamportSpend            InstructionErrorKind = "ExternalAccountLamportSpend"
+	InstructionErrorKindExternalAccountDataModified            InstructionErrorKind = "ExternalAccountDataModified"
+	InstructionErrorKindReadonlyLamportChange                  InstructionErrorKind = "ReadonlyLamportChange"
+	InstructionErrorKindReadonlyDataModified                   InstructionErrorKind = "ReadonlyDataModified"
+	InstructionErrorKindDuplicateAccountIndex                  InstructionErrorKind = "DuplicateAccountIndex"
+	InstructionErrorKindExecutableModified                     InstructionErrorKind = "ExecutableModified"
+	InstructionErrorKindRentEpochModified                      InstructionErrorKind = "RentEpochModified"
+	InstructionErrorKindNotEnoughAccountKeys                   InstructionErrorKind = "NotEnoughAccountKeys"
+	InstructionErrorKindAccountDataSizeChanged                 InstructionErrorKind = "AccountDataSizeChanged"
+	InstructionErrorKindAccountNotExecutable                   InstructionErrorKind = "AccountNotExecutable"
+	InstructionErrorKindAccountBorrowFailed                    InstructionErrorKind = "AccountBorrowFailed"
+	InstructionErrorKindAccountBorrowOutstanding               InstructionErrorKind = "AccountBorrowOutstanding"
+	InstructionErrorKindDuplicateAccountOutOfSync              InstructionErrorKind = "DuplicateAccountOutOfSync"
+	InstructionErrorKindCustom                                 InstructionErrorKind = "Custom"
+	InstructionErrorKindInvalidError                           InstructionErrorKind = "InvalidError"
+	InstructionErrorKindExecutableDataModified                 InstructionErrorKind = "ExecutableDataModified"
+	InstructionErrorKindExecutableLamportChange                InstructionErrorKind = "ExecutableLamportChange"
+	InstructionErrorKindExecutableAccountNotRentExempt         InstructionErrorKind = "ExecutableAccountNotRentExempt"
+	InstructionErrorKindUnsupportedProgramId                   InstructionErrorKind = "UnsupportedProgramId"
+	InstructionErrorKindCallDepth                              InstructionErrorKind = "CallDepth"
+	InstructionErrorKindMissingAccount                         InstructionErrorKind = "MissingAccount"
+	InstructionErrorKindReentrancyNotAllowed                   InstructionErrorKind = "ReentrancyNotAllowed"
+	InstructionErrorKindMaxSeedLengthExceeded                  InstructionErrorKind = "MaxSeedLengthExceeded"
+	InstructionErrorKindInvalidSeeds                           InstructionErrorKind = "InvalidSeeds"
+	InstructionErrorKindInvalidRealloc                         InstructionErrorKind = "InvalidRealloc"
+	InstructionErrorKindComputationalBudgetExceeded            InstructionErrorKind = "ComputationalBudgetExceeded"
+	InstructionErrorKindPrivilegeEscalation                    InstructionErrorKind = "PrivilegeEscalation"
+	InstructionErrorKindProgramEnvironmentSetupFailure         InstructionErrorKind = "ProgramEnvironmentSetupFailure"
+	InstructionErrorKindProgramFailedToComplete                InstructionErrorKind = "ProgramFailedToComplete"
+	InstructionErrorKindProgramFailedToCompile                 InstructionErrorKind = "ProgramFailedToCompile"
+	InstructionErrorKindImmutable                              InstructionErrorKind = "Immutable"
+	InstructionErrorKindIncorrectAuthority                     InstructionErrorKind = "IncorrectAuthority"
+	InstructionErrorKindBorshIoError                           InstructionErrorKind = "BorshIoError"
+	InstructionErrorKindAccountNotRentExempt                   InstructionErrorKind = "AccountNotRentExempt"
+	InstructionErrorKindInvalidAccountOwner                    InstructionErrorKind = "InvalidAccountOwner"
+	InstructionErrorKindArithmeticOverflow                     InstructionErrorKind = "ArithmeticOverflow"
+	InstructionErrorKindUnsupportedSysvar                      InstructionErrorKind = "UnsupportedSysvar"
+	InstructionErrorKindIllegalOwner                           InstructionErrorKind = "IllegalOwner"
+	InstructionErrorKindMaxAccountsDataAllocationsExceeded     InstructionErrorKind = "MaxAccountsDataAllocationsExceeded"
+	InstructionErrorKindMaxAccountsExceeded                    InstructionErrorKind = "MaxAccountsExceeded"
+	InstructionErrorKindMaxInstructionTraceLengthExceeded      InstructionErrorKind = "MaxInstructionTraceLengthExceeded"
+	InstructionErrorKindBuiltinProgramsMustConsumeComputeUnits InstructionErrorKind = "BuiltinProgramsMustConsumeComputeUnits"
+)
+
+// TransactionError is the decoded `err` of a transaction, e.g.
+// "BlockhashNotFound" or {"InstructionError":[0,{"Custom":1}]}
+type TransactionError struct {
+	Kind TransactionErrorKind
+
+	// set when Kind is InstructionError
+	InstructionError *InstructionError
+	// set when Kind is DuplicateInstruction
+	InstructionIndex *uint8
+	// set when Kind is InsufficientFundsForRent or ProgramExecutionTemporarilyRestricted
+	AccountIndex *uint8
+
+	Raw json.RawMessage
+}
+
+// InstructionError is the error of the instruction at Index
+type InstructionError struct {
+	Index uint8
+	Kind  InstructionErrorKind
+
+	// set when Kind is Custom
+	Custom *uint32
+	// set when Kind is BorshIoError
+	BorshIoError string
+}
+
+func (e *TransactionError) Error() string {
+	switch {
+	case e.InstructionError != nil:
+		return fmt.Sprintf("%s: %s", e.Kind, e.InstructionError.Error())
+	case e.InstructionIndex != nil:
+		return fmt.Sprintf("%s: instruction index %d", e.Kind, *e.InstructionIndex)
+	case e.AccountIndex != nil:
+		return fmt.Sprintf("%s: account index %d", e.Kind, *e.AccountIndex)
+	}
+	return string(e.Kind)
+}
+
+func (e *InstructionError) Error() string {
+	switch {
+	case e.Custom != nil:
+		return fmt.Sprintf("instruction #%d: %s(%d)", e.Index, e.Kind, *e.Custom)
+	case e.BorshIoError != "":
+		return fmt.Sprintf("instruction #%d: %s(%s)", e.Index, e.Kind, e.BorshIoError)
+	}
+	return fmt.Sprintf("instruction #%d: %s", e.Index, e.Kind)
+}
+
+// CustomCode returns the program error code if the instruction failed with a custom error
+func (e *TransactionError) CustomCode() (uint32, bool) {
+	if e.InstructionError == nil || e.InstructionError.Custom == nil {
+		return 0, false
+	}
+	return *e.InstructionError.Custom, true
+}
+
+// TokenError maps the custom error code to tokenprog.TokenError.
+// It is only meaningful when the failed instruction belongs to the token program.
+func (e *TransactionError) TokenError() (tokenprog.TokenError, bool) {
+	code, ok := e.CustomCode()
+	if !ok {
+		return 0, false
+	}
+	return tokenprog.TokenErrorFromCode(code)
+}
+
+func (e *TransactionError) UnmarshalJSON(b []byte) error {
+	*e = TransactionError{Raw: append(json.RawMessage{}, b...)}
+
+	// unit variant
+	var kind string
+	if err := json.Unmarshal(b, &kind); err == nil {
+		e.Kind = TransactionErrorKind(kind)
+		return nil
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return fmt.Errorf("failed to parse transaction error: %v", err)
+	}
+	if len(m) != 1 {
+		return fmt.Errorf("failed to parse transaction error: %s", string(b))
+	}
+	for k, v := range m {
+		e.Kind = TransactionErrorKind(k)
+		switch e.Kind {
+		case TransactionErrorKindInstructionError:
+			var tuple []json.RawMessage
+			if err := json.Unmarshal(v, &tuple); err != nil || len(tuple) != 2 {
+				return fmt.Errorf("failed to parse instruction error: %s", string(v))
+			}
+			var insErr InstructionError
+			if err := json.Unmarshal(tuple[0], &insErr.Index); err != nil {
+				return fmt.Errorf("failed to parse instruction index: %v", err)
+			}
+			if err := insErr.unmarshalKind(tuple[1]); err != nil {
+				return err
+			}
+			e.InstructionError = &insErr
+		case TransactionErrorKindDuplicateInstruction:
+			var idx uint8
+			if err := json.Unmarshal(v, &idx); err != nil {
+				return fmt.Errorf("failed to parse instruction index: %v", err)
+			}
+			e.InstructionIndex = &idx
+		case TransactionErrorKindInsufficientFundsForRent, TransactionErrorKindProgramExecutionTemporarilyRestricted:
+			var s struct {
+				AccountIndex uint8 `json:"account_index"`
+			}
+			if err := json.Unmarshal(v, &s); err != nil {
+				return fmt.Errorf("failed to parse account index: %v", err)
+			}
+			e.AccountIndex = &s.AccountIndex
+		}
+	}
+	return nil
+}
+
+func (e TransactionError) MarshalJSON() ([]byte, error) {
+	if len(e.Raw) != 0 {
+		return e.Raw, nil
+	}
+	switch {
+	case e.InstructionError != nil:
+		var inner interface{} = e.InstructionError.Kind
+		if e.InstructionError.Custom != nil {
+			inner = map[string]interface{}{string(e.InstructionError.Kind): *e.InstructionError.Custom}
+		} else if e.InstructionError.BorshIoError != "" {
+			inner = map[string]interface{}{string(e.InstructionError.Kind): e.InstructionError.BorshIoError}
+		}
+		return json.Marshal(map[string]interface{}{string(e.Kind): []interface{}{e.InstructionError.Index, inner}})
+	case e.InstructionIndex != nil:
+		return json.Marshal(map[string]interface{}{string(e.Kind): *e.InstructionIndex})
+	case e.AccountIndex != nil:
+		return json.Marshal(map[string]interface{}{string(e.Kind): map[string]interface{}{"account_index": *e.AccountIndex}})
+	}
+	return json.Marshal(string(e.Kind))
+}
+
+func (e *InstructionError) unmarshalKind(b []byte) error {
+	var kind string
+	if err := json.Unmarshal(b, &kind); err == nil {
+		e.Kind = InstructionErrorKind(kind)
+		return nil
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil || len(m) != 1 {
+		return fmt.Errorf("failed to parse instruction error: %s", string(b))
+	}
+	for k, v := range m {
+		e.Kind = InstructionErrorKind(k)
+		switch e.Kind {
+		case InstructionErrorKindCustom:
+			var code uint32
+			if err := json.Unmarshal(v, &code); err != nil {
+				return fmt.Errorf("failed to parse custom error code: %v", err)
+			}
+			e.Custom = &code
+		case InstructionErrorKindBorshIoError:
+			if err := json.Unmarshal(v, &e.BorshIoError); err != nil {
+				return fmt.Errorf("failed to parse borsh io error: %v", err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/tokenprog"
+)
+
+func TestTransactionErrorUnmarshalJSON(t *testing.T) {
+	u8 := func(v uint8) *uint8 { return &v }
+	u32 := func(v uint32) *uint32 { return &v }
+	tests := []struct {
+		name    string
+		data    string
+		want    TransactionError
+		wantErr bool
+	}{
+		{
+			data: `"BlockhashNotFound"`,
+			want: TransactionError{Kind: TransactionErrorKindBlockhashNotFound},
+		},
+		{
+			data: `{"InstructionError":[0,{"Custom":1}]}`,
+			want: TransactionError{
+				Kind:             TransactionErrorKindInstructionError,
+				InstructionError: &InstructionError{Index: 0, Kind: InstructionErrorKindCustom, Custom: u32(1)},
+			},
+		},
+		{
+			data: `{"InstructionError":[2,"InvalidAccountData"]}`,
+			want: TransactionError{
+				Kind:             TransactionErrorKindInstructionError,
+				InstructionError: &InstructionError{Index: 2, Kind: InstructionErrorKindInvalidAccountData},
+			},
+		},
+		{
+			data: `{"InstructionError":[1,{"BorshIoError":"Unknown"}]}`,
+			want: TransactionError{
+				Kind:             TransactionErrorKindInstructionError,
+				InstructionError: &InstructionError{Index: 1, Kind: InstructionErrorKindBorshIoError, BorshIoError: "Unknown"},
+			},
+		},
+		{
+			data: `{"DuplicateInstruction":3}`,
+			want: TransactionError{Kind: TransactionErrorKindDuplicateInstruction, InstructionIndex: u8(3)},
+		},
+		{
+			data: `{"InsufficientFundsForRent":{"account_index":4}}`,
+			want: TransactionError{Kind: TransactionErrorKindInsufficientFundsForRent, AccountIndex: u8(4)},
+		},
+		{
+			data:    `{"InstructionError":[0]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got TransactionError
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			tt.want.Raw = json.RawMessage(tt.data)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnmarshalJSON() = %+v, want %+v", got, tt.want)
+			}
+			b, err := json.Marshal(got)
+			if err != nil || string(b) != tt.data {
+				t.Errorf("MarshalJSON() = %s, %v, want %s", string(b), err, tt.data)
+			}
+			got.Raw = nil
+			b, err = json.Marshal(got)
+			if err != nil || string(b) != tt.data {
+				t.Errorf("MarshalJSON() without raw = %s, %v, want %s", string(b), err, tt.data)
+			}
+		})
+	}
+}
+
+func TestTransactionErrorTokenError(t *testing.T) {
+	var meta TransactionMeta
+	err := json.Unmarshal([]byte(`{"err":{"InstructionError":[0,{"Custom":1}]}}`), &meta)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	got, ok := meta.Err.TokenError()
+	if !ok || got != tokenprog.TokenErrorInsufficientFunds {
+		t.Errorf("TokenError() = %v, %v, want %v", got, ok, tokenprog.TokenErrorInsufficientFunds)
+	}
+	if got.String() != "InsufficientFunds" {
+		t.Errorf("String() = %v, want InsufficientFunds", got.String())
+	}
+
+	meta = TransactionMeta{}
+	if err := json.Unmarshal([]byte(`{"err":null}`), &meta); err != nil || meta.Err != nil {
+		t.Errorf("Unmarshal() = %v, %v, want nil err", meta.Err, err)
+	}
+}
@@ -0,0 +1,737 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/portto/solana-go-sdk/anchor"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+// Config is the package to generate
+type Config struct {
+	// Package is the package name, it is the lower case name of the program if it is empty
+	Package string
+	// ProgramID is the program id, the address of the IDL is used if it is empty
+	ProgramID string
+}
+
+// Generate returns the formatted go source of the package of the program
+func Generate(idl *anchor.IDL, config Config) ([]byte, error) {
+	g := generator{
+		idl:     idl,
+		prefix:  pascalCase(idl.Name),
+		names:   map[string]string{},
+		types:   map[string]*anchor.IDLTypeDef{},
+		imports: map[string]bool{},
+	}
+	if g.prefix == "" {
+		return nil, fmt.Errorf("idl has no name")
+	}
+	pkg := config.Package
+	if pkg == "" {
+		pkg = strings.ToLower(g.prefix)
+	}
+	programID := config.ProgramID
+	if programID == "" {
+		programID = idl.Address
+	}
+	if programID == "" {
+		return nil, fmt.Errorf("idl has no address, a program id is needed")
+	}
+	if common.PublicKeyFromString(programID).ToBase58() != programID {
+		return nil, fmt.Errorf("invalid program id %v", programID)
+	}
+
+	for i := range idl.Types {
+		g.types[idl.Types[i].Name] = &idl.Types[i]
+	}
+	for i := range idl.Accounts {
+		if _, ok := g.types[idl.Accounts[i].Name]; !ok {
+			g.types[idl.Accounts[i].Name] = &idl.Accounts[i]
+		}
+	}
+
+	steps := []func() error{
+		g.programID(programID),
+		g.instructions,
+		g.typeDefs,
+		g.accounts,
+		g.errors,
+		g.builders,
+		g.parser,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by anchorgen from the IDL of %s. DO NOT EDIT.\n\n", idl.Name)
+	fmt.Fprintf(&src, "// Package %s builds and parses the instructions of the %s program\n", pkg, idl.Name)
+	fmt.Fprintf(&src, "package %s\n\n", pkg)
+	imports := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+	src.WriteString("import (\n")
+	for _, path := range imports {
+		if !strings.Contains(path, ".") {
+			fmt.Fprintf(&src, "%q\n", path)
+		}
+	}
+	src.WriteString("\n")
+	for _, path := range imports {
+		if strings.Contains(path, ".") {
+			fmt.Fprintf(&src, "%q\n", path)
+		}
+	}
+	src.WriteString(")\n")
+	src.Write(g.buf.Bytes())
+
+	out, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format the generated source, err: %v", err)
+	}
+	return out, nil
+}
+
+// generator writes the declarations of the package and makes sure their names don't collide
+type generator struct {
+	idl     *anchor.IDL
+	prefix  string
+	buf     bytes.Buffer
+	names   map[string]string
+	types   map[string]*anchor.IDLTypeDef
+	imports map[string]bool
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) use(path string) {
+	g.imports[path] = true
+}
+
+// declare reserves a package level name, what is the thing in the IDL which needs it
+func (g *generator) declare(name, what string) error {
+	if other, ok := g.names[name]; ok {
+		return fmt.Errorf("%v and %v are both named %v", other, what, name)
+	}
+	g.names[name] = what
+	return nil
+}
+
+func (g *generator) docs(docs []string) {
+	for _, doc := range docs {
+		g.printf("// %s\n", doc)
+	}
+}
+
+func (g *generator) programID(programID string) func() error {
+	return func() error {
+		if err := g.declare("ProgramID", "the program id"); err != nil {
+			return err
+		}
+		g.use("github.com/portto/solana-go-sdk/common")
+		g.use("github.com/portto/solana-go-sdk/types")
+		g.printf("\n// ProgramID is the program id of %s\n", g.idl.Name)
+		g.printf("var ProgramID = common.PublicKeyFromString(%q)\n\n", programID)
+		g.printf("func init() {\n")
+		g.printf("types.RegisterInstructionParser(ProgramID, Parse%s)\n", g.prefix)
+		g.printf("}\n")
+		return nil
+	}
+}
+
+func (g *generator) instructions() error {
+	if err := g.declare("Instruction", "the instruction type"); err != nil {
+		return err
+	}
+	g.printf("\n// Instruction is the discriminator of an instruction\n")
+	g.printf("type Instruction [%d]byte\n\n", anchor.DiscriminatorSize)
+
+	g.printf("var (\n")
+	for _, ins := range g.idl.Instructions {
+		name := "Instruction" + pascalCase(ins.Name)
+		if err := g.declare(name, "instruction "+ins.Name); err != nil {
+			return err
+		}
+		g.printf("%s = Instruction{%s}\n", name, byteList(ins.Discriminator))
+	}
+	g.printf(")\n")
+
+	for _, ins := range g.idl.Instructions {
+		name := pascalCase(ins.Name) + "Instruction"
+		if err := g.declare(name, "instruction "+ins.Name); err != nil {
+			return err
+		}
+		g.printf("\ntype %s struct {\n", name)
+		g.printf("Instruction Instruction\n")
+		fields := map[string]bool{"Instruction": true}
+		for _, arg := range ins.Args {
+			if err := g.field(fields, arg.Name, arg.Type); err != nil {
+				return fmt.Errorf("instruction %v: %v", ins.Name, err)
+			}
+		}
+		g.printf("}\n")
+	}
+	return nil
+}
+
+// field writes a struct field, a coption is only supported as a field since it needs the tag
+func (g *generator) field(fields map[string]bool, name string, t anchor.IDLType) error {
+	fieldName := pascalCase(name)
+	if fields[fieldName] {
+		return fmt.Errorf("duplicate field %v", fieldName)
+	}
+	fields[fieldName] = true
+
+	if t.Kind == anchor.TypeCOption {
+		elem, err := g.goType(*t.Elem)
+		if err != nil {
+			return fmt.Errorf("field %v: %v", name, err)
+		}
+		g.printf("%s *%s `borsh:\"coption\"`\n", fieldName, elem)
+		return nil
+	}
+	typ, err := g.goType(t)
+	if err != nil {
+		return fmt.Errorf("field %v: %v", name, err)
+	}
+	g.printf("%s %s\n", fieldName, typ)
+	return nil
+}
+
+// goType returns the go type of the IDL type in the borsh layout
+func (g *generator) goType(t anchor.IDLType) (string, error) {
+	switch t.Kind {
+	case anchor.TypeBool, anchor.TypeString:
+		return t.Kind, nil
+	case anchor.TypeF32, anchor.TypeF64:
+		return "float" + strings.TrimPrefix(t.Kind, "f"), nil
+	case anchor.TypeU8, anchor.TypeU16, anchor.TypeU32, anchor.TypeU64:
+		return "uint" + strings.TrimPrefix(t.Kind, "u"), nil
+	case anchor.TypeI8, anchor.TypeI16, anchor.TypeI32, anchor.TypeI64:
+		return "int" + strings.TrimPrefix(t.Kind, "i"), nil
+	case anchor.TypeU128:
+		g.use("github.com/portto/solana-go-sdk/borsh")
+		return "borsh.Uint128", nil
+	case anchor.TypeI128:
+		g.use("github.com/portto/solana-go-sdk/borsh")
+		return "borsh.Int128", nil
+	case anchor.TypeBytes:
+		return "[]byte", nil
+	case anchor.TypePubkey:
+		return "common.PublicKey", nil
+	case anchor.TypeVec, anchor.TypeOption, anchor.TypeArray:
+		elem, err := g.goType(*t.Elem)
+		if err != nil {
+			return "", err
+		}
+		switch t.Kind {
+		case anchor.TypeVec:
+			return "[]" + elem, nil
+		case anchor.TypeOption:
+			return "*" + elem, nil
+		}
+		return fmt.Sprintf("[%d]%s", t.Len, elem), nil
+	case anchor.TypeDefined:
+		if _, ok := g.types[t.Defined]; !ok {
+			return "", fmt.Errorf("undefined type %v", t.Defined)
+		}
+		return pascalCase(t.Defined), nil
+	}
+	return "", fmt.Errorf("unsupported type %v", t)
+}
+
+// typeDefs writes the types of the IDL and the accounts of a legacy IDL, which are not in the types
+func (g *generator) typeDefs() error {
+	defs := make([]*anchor.IDLTypeDef, 0, len(g.types))
+	for i := range g.idl.Types {
+		defs = append(defs, &g.idl.Types[i])
+	}
+	for i := range g.idl.Accounts {
+		if g.types[g.idl.Accounts[i].Name] == &g.idl.Accounts[i] {
+			defs = append(defs, &g.idl.Accounts[i])
+		}
+	}
+	for _, def := range defs {
+		if err := g.typeDef(def); err != nil {
+			return fmt.Errorf("type %v: %v", def.Name, err)
+		}
+	}
+	return nil
+}
+
+func (g *generator) typeDef(def *anchor.IDLTypeDef) error {
+	name := pascalCase(def.Name)
+	if err := g.declare(name, "type "+def.Name); err != nil {
+		return err
+	}
+	switch def.Type.Kind {
+	case "struct":
+		g.printf("\n")
+		g.docs(def.Docs)
+		return g.structFields(name, def.Type.Fields)
+	case "alias":
+		if def.Type.Value == nil {
+			return fmt.Errorf("alias has no value")
+		}
+		typ, err := g.goType(*def.Type.Value)
+		if err != nil {
+			return err
+		}
+		g.printf("\n")
+		g.docs(def.Docs)
+		g.printf("type %s %s\n", name, typ)
+		return nil
+	case "enum":
+		return g.enum(name, def)
+	}
+	return fmt.Errorf("unsupported type kind %v", def.Type.Kind)
+}
+
+// structFields writes a struct, the fields of a tuple struct are named by their index
+func (g *generator) structFields(name string, fields anchor.IDLFields) error {
+	g.printf("type %s struct {\n", name)
+	declared := map[string]bool{}
+	for _, field := range fields.Named {
+		g.docs(field.Docs)
+		if err := g.field(declared, field.Name, field.Type); err != nil {
+			return err
+		}
+	}
+	for i, t := range fields.Tuple {
+		if err := g.field(declared, fmt.Sprintf("Field%d", i), t); err != nil {
+			return err
+		}
+	}
+	g.printf("}\n")
+	return nil
+}
+
+// enum writes the borsh enum of the variants, a variant with fields has a struct named <Enum><Variant>Fields
+func (g *generator) enum(name string, def *anchor.IDLTypeDef) error {
+	if len(def.Type.Variants) > 256 {
+		return fmt.Errorf("too many variants")
+	}
+	g.use("github.com/portto/solana-go-sdk/borsh")
+
+	variantTypes := make([]string, 0, len(def.Type.Variants))
+	for _, variant := range def.Type.Variants {
+		if variant.Fields.Len() == 0 {
+			variantTypes = append(variantTypes, "struct{}")
+			continue
+		}
+		variantTypes = append(variantTypes, name+pascalCase(variant.Name)+"Fields")
+	}
+
+	g.printf("\n")
+	g.docs(def.Docs)
+	g.printf("type %s struct {\n", name)
+	g.printf("Enum borsh.Enum\n")
+	fields := map[string]bool{"Enum": true}
+	for i, variant := range def.Type.Variants {
+		fieldName := pascalCase(variant.Name)
+		if fields[fieldName] {
+			return fmt.Errorf("duplicate variant %v", fieldName)
+		}
+		fields[fieldName] = true
+		g.printf("%s %s\n", fieldName, variantTypes[i])
+	}
+	g.printf("}\n\n")
+
+	g.printf("const (\n")
+	for i, variant := range def.Type.Variants {
+		constName := name + pascalCase(variant.Name)
+		if err := g.declare(constName, "variant "+variant.Name+" of "+def.Name); err != nil {
+			return err
+		}
+		if i == 0 {
+			g.printf("%s borsh.Enum = iota\n", constName)
+			continue
+		}
+		g.printf("%s\n", constName)
+	}
+	g.printf(")\n")
+
+	for i, variant := range def.Type.Variants {
+		if variant.Fields.Len() == 0 {
+			continue
+		}
+		if err := g.declare(variantTypes[i], "fields of variant "+variant.Name+" of "+def.Name); err != nil {
+			return err
+		}
+		g.printf("\n")
+		if err := g.structFields(variantTypes[i], variant.Fields); err != nil {
+			return fmt.Errorf("variant %v: %v", variant.Name, err)
+		}
+	}
+	return nil
+}
+
+func (g *generator) accounts() error {
+	for _, account := range g.idl.Accounts {
+		if account.Type.Kind != "struct" {
+			return fmt.Errorf("account %v is not a struct", account.Name)
+		}
+		name := pascalCase(account.Name)
+		discriminator := name + "Discriminator"
+		fromData := name + "FromData"
+		for _, n := range []string{discriminator, fromData} {
+			if err := g.declare(n, "account "+account.Name); err != nil {
+				return err
+			}
+		}
+		g.use("bytes")
+		g.use("fmt")
+		g.use("github.com/portto/solana-go-sdk/borsh")
+
+		g.printf("\n// %s is the discriminator of the %s account\n", discriminator, account.Name)
+		g.printf("var %s = [%d]byte{%s}\n\n", discriminator, anchor.DiscriminatorSize, byteList(account.Discriminator))
+
+		g.printf("// %s decodes the %s account, it fails if the discriminator doesn't match\n", fromData, account.Name)
+		g.printf("func %s(data []byte) (*%s, error) {\n", fromData, name)
+		g.printf("if len(data) < len(%[1]s) || !bytes.Equal(data[:len(%[1]s)], %[1]s[:]) {\n", discriminator)
+		g.printf("return nil, fmt.Errorf(\"discriminator not match\")\n")
+		g.printf("}\n")
+		g.printf("var a %s\n", name)
+		g.printf("if err := borsh.Unmarshal(data[len(%s):], &a); err != nil {\n", discriminator)
+		g.printf("return nil, err\n")
+		g.printf("}\n")
+		g.printf("return &a, nil\n")
+		g.printf("}\n\n")
+
+		g.printf("// Serialize encodes the account after its discriminator\n")
+		g.printf("func (a %s) Serialize() []byte {\n", name)
+		g.printf("return append(%s[:], borsh.MustMarshal(a)...)\n", discriminator)
+		g.printf("}\n")
+	}
+	return nil
+}
+
+func (g *generator) errors() error {
+	if len(g.idl.Errors) == 0 {
+		return nil
+	}
+	g.use("fmt")
+	typeName := g.prefix + "Error"
+	for _, n := range []string{typeName, typeName + "FromCode"} {
+		if err := g.declare(n, "the error type"); err != nil {
+			return err
+		}
+	}
+	table := lowerCamelCase(g.prefix) + "Errors"
+	if err := g.declare(table, "the error table"); err != nil {
+		return err
+	}
+
+	g.printf("\n// %s is the custom program error code returned by the %s program\n", typeName, g.idl.Name)
+	g.printf("type %s uint32\n\n", typeName)
+	g.printf("const (\n")
+	for _, e := range g.idl.Errors {
+		name := typeName + pascalCase(e.Name)
+		if err := g.declare(name, "error "+e.Name); err != nil {
+			return err
+		}
+		g.printf("%s %s = %d\n", name, typeName, e.Code)
+	}
+	g.printf(")\n\n")
+
+	g.printf("var %s = map[%s]struct {\nname string\nmessage string\n}{\n", table, typeName)
+	for _, e := range g.idl.Errors {
+		g.printf("%s: {%q, %q},\n", typeName+pascalCase(e.Name), e.Name, e.Msg)
+	}
+	g.printf("}\n\n")
+
+	g.printf("// %[1]sFromCode converts a custom program error code to %[1]s, ok is false if the code is unknown\n", typeName)
+	g.printf("func %[1]sFromCode(code uint32) (%[1]s, bool) {\n", typeName)
+	g.printf("_, ok := %s[%s(code)]\n", table, typeName)
+	g.printf("return %s(code), ok\n", typeName)
+	g.printf("}\n\n")
+
+	g.printf("// String returns the name of the error, e.g. %q\n", g.idl.Errors[0].Name)
+	g.printf("func (e %s) String() string {\n", typeName)
+	g.printf("if v, ok := %s[e]; ok {\nreturn v.name\n}\n", table)
+	g.printf("return fmt.Sprintf(\"%s(%%d)\", uint32(e))\n", typeName)
+	g.printf("}\n\n")
+
+	g.printf("func (e %s) Error() string {\n", typeName)
+	g.printf("if v, ok := %s[e]; ok {\nreturn v.message\n}\n", table)
+	g.printf("return fmt.Sprintf(\"unknown %s error: %%d\", uint32(e))\n", g.idl.Name)
+	g.printf("}\n")
+	return nil
+}
+
+// builderParam is a parameter of an instruction builder
+type builderParam struct {
+	name string
+	typ  string
+}
+
+func (g *generator) builders() error {
+	optional := false
+	for _, ins := range g.idl.Instructions {
+		name := pascalCase(ins.Name)
+		if err := g.declare(name, "instruction "+ins.Name); err != nil {
+			return err
+		}
+
+		accounts := flattenAccounts(ins.Accounts, "")
+		// the locals of the builder
+		used := map[string]bool{"data": true, "err": true, "accounts": true}
+		params := make([]builderParam, 0, len(accounts)+len(ins.Args))
+		accountParams := make([]string, len(accounts))
+		for i, account := range accounts {
+			if account.Address != "" {
+				continue
+			}
+			param := lowerCamelCase(account.Name) + "Pubkey"
+			if used[param] {
+				return fmt.Errorf("instruction %v: duplicate parameter %v", ins.Name, param)
+			}
+			used[param] = true
+			accountParams[i] = param
+			typ := "common.PublicKey"
+			if account.Optional {
+				typ = "*common.PublicKey"
+				optional = true
+			}
+			params = append(params, builderParam{name: param, typ: typ})
+		}
+		argParams := make([]string, 0, len(ins.Args))
+		for _, arg := range ins.Args {
+			param := paramName(arg.Name)
+			if used[param] {
+				return fmt.Errorf("instruction %v: duplicate parameter %v", ins.Name, param)
+			}
+			used[param] = true
+			argParams = append(argParams, param)
+			typ, err := g.goType(arg.Type)
+			if arg.Type.Kind == anchor.TypeCOption {
+				typ, err = g.goType(anchor.IDLType{Kind: anchor.TypeOption, Elem: arg.Type.Elem})
+			}
+			if err != nil {
+				return fmt.Errorf("instruction %v: arg %v: %v", ins.Name, arg.Name, err)
+			}
+			params = append(params, builderParam{name: param, typ: typ})
+		}
+
+		g.printf("\n// %s builds the %s instruction", name, ins.Name)
+		if hasOptional(accounts) {
+			g.printf(", a nil optional account is none")
+		}
+		g.printf("\n")
+		g.docs(ins.Docs)
+		g.printf("func %s(%s) types.Instruction {\n", name, joinParams(params))
+		g.printf("data, err := borsh.Marshal(%sInstruction{\n", name)
+		g.printf("Instruction: Instruction%s,\n", name)
+		for i, arg := range ins.Args {
+			g.printf("%s: %s,\n", pascalCase(arg.Name), argParams[i])
+		}
+		g.printf("})\n")
+		g.printf("if err != nil {\npanic(err)\n}\n\n")
+
+		g.printf("accounts := make([]types.AccountMeta, 0, %d)\n", len(accounts))
+		for i, account := range accounts {
+			switch {
+			case account.Address != "":
+				g.printf("accounts = append(accounts, types.AccountMeta{PubKey: common.PublicKeyFromString(%q), IsSigner: %v, IsWritable: %v})\n",
+					account.Address, account.Signer, account.Writable)
+			case account.Optional:
+				g.printf("accounts = append(accounts, optionalAccount(%s, %v, %v))\n", accountParams[i], account.Signer, account.Writable)
+			default:
+				g.printf("accounts = append(accounts, types.AccountMeta{PubKey: %s, IsSigner: %v, IsWritable: %v})\n",
+					accountParams[i], account.Signer, account.Writable)
+			}
+		}
+		g.printf("\nreturn types.Instruction{\nProgramID: ProgramID,\nAccounts: accounts,\nData: data,\n}\n")
+		g.printf("}\n")
+	}
+
+	if optional {
+		if err := g.declare("optionalAccount", "the optional account helper"); err != nil {
+			return err
+		}
+		g.printf("\n// optionalAccount returns the account meta of an optional account, Anchor takes the program id as none\n")
+		g.printf("func optionalAccount(pubkey *common.PublicKey, isSigner, isWritable bool) types.AccountMeta {\n")
+		g.printf("if pubkey == nil {\n")
+		g.printf("return types.AccountMeta{PubKey: ProgramID, IsSigner: false, IsWritable: false}\n")
+		g.printf("}\n")
+		g.printf("return types.AccountMeta{PubKey: *pubkey, IsSigner: isSigner, IsWritable: isWritable}\n")
+		g.printf("}\n")
+	}
+	return nil
+}
+
+func (g *generator) parser() error {
+	name := "Parse" + g.prefix
+	for _, n := range []string{name, "parsedAccount", "parseAccounts"} {
+		if err := g.declare(n, "the parser"); err != nil {
+			return err
+		}
+	}
+	g.use("fmt")
+	g.use("github.com/portto/solana-go-sdk/borsh")
+
+	g.printf("\n// %s decodes instructions of the %s program\n", name, g.idl.Name)
+	g.printf("func %s(ins types.Instruction) (types.ParsedInstruction, error) {\n", name)
+	g.printf("var parsedInstruction types.ParsedInstruction\n")
+	g.printf("var err error\n")
+	g.printf("var s struct {\nInstruction Instruction\n}\n")
+	g.printf("err = borsh.Unmarshal(ins.Data, &s)\n")
+	g.printf("if err != nil {\nreturn parsedInstruction, err\n}\n")
+	g.printf("var instructionType string\n")
+	g.printf("var parsedInfo map[string]interface{}\n")
+	g.printf("var accounts []parsedAccount\n")
+	g.printf("switch s.Instruction {\n")
+	for _, ins := range g.idl.Instructions {
+		insName := pascalCase(ins.Name)
+		g.printf("case Instruction%s:\n", insName)
+		g.printf("var a %sInstruction\n", insName)
+		g.printf("err = borsh.Unmarshal(ins.Data, &a)\n")
+		g.printf("instructionType = %q\n", ins.Name)
+		g.printf("parsedInfo = map[string]interface{}{\n")
+		for _, arg := range ins.Args {
+			value := "a." + pascalCase(arg.Name)
+			if arg.Type.Kind == anchor.TypePubkey {
+				value += ".ToBase58()"
+			}
+			g.printf("%q: %s,\n", arg.Name, value)
+		}
+		g.printf("}\n")
+		g.printf("accounts = []parsedAccount{\n")
+		for _, account := range flattenAccounts(ins.Accounts, "") {
+			g.printf("{%q, %v},\n", account.Name, account.Optional)
+		}
+		g.printf("}\n")
+	}
+	g.printf("default:\n")
+	g.printf("return parsedInstruction, fmt.Errorf(\"unknown %s instruction %%v\", s.Instruction)\n", g.idl.Name)
+	g.printf("}\n")
+	g.printf("if err != nil {\nreturn parsedInstruction, err\n}\n")
+	g.printf("parseAccounts(parsedInfo, accounts, ins.Accounts)\n")
+	g.printf("parsedInstruction.Program = %q\n", g.idl.Name)
+	g.printf("parsedInstruction.Parsed = &types.InstructionInfo{\n")
+	g.printf("Info: parsedInfo,\n")
+	g.printf("InstructionType: instructionType,\n")
+	g.printf("}\n")
+	g.printf("return parsedInstruction, nil\n")
+	g.printf("}\n\n")
+
+	g.printf("type parsedAccount struct {\nname string\noptional bool\n}\n\n")
+	g.printf("// parseAccounts adds the accounts to the info by their names, an optional account which is the program id is none\n")
+	g.printf("// and the accounts after the ones of the instruction are in remainingAccounts\n")
+	g.printf("func parseAccounts(info map[string]interface{}, accounts []parsedAccount, metas []types.AccountMeta) {\n")
+	g.printf("for i, meta := range metas {\n")
+	g.printf("if i >= len(accounts) {\n")
+	g.printf("remaining := make([]string, 0, len(metas)-i)\n")
+	g.printf("for _, meta := range metas[i:] {\nremaining = append(remaining, meta.PubKey.ToBase58())\n}\n")
+	g.printf("info[\"remainingAccounts\"] = remaining\n")
+	g.printf("return\n")
+	g.printf("}\n")
+	g.printf("if accounts[i].optional && meta.PubKey == ProgramID {\ncontinue\n}\n")
+	g.printf("info[accounts[i].name] = meta.PubKey.ToBase58()\n")
+	g.printf("}\n")
+	g.printf("}\n")
+	return nil
+}
+
+// flattenAccounts lists the accounts of the groups in order and names them by their path
+func flattenAccounts(items []anchor.IDLAccountItem, prefix string) []anchor.IDLAccountItem {
+	accounts := make([]anchor.IDLAccountItem, 0, len(items))
+	for _, item := range items {
+		item.Name = prefix + item.Name
+		if len(item.Accounts) > 0 {
+			accounts = append(accounts, flattenAccounts(item.Accounts, item.Name+".")...)
+			continue
+		}
+		accounts = append(accounts, item)
+	}
+	return accounts
+}
+
+func hasOptional(accounts []anchor.IDLAccountItem) bool {
+	for _, account := range accounts {
+		if account.Optional && account.Address == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// joinParams groups the consecutive parameters of the same type, like "a, b common.PublicKey, c uint64"
+func joinParams(params []builderParam) string {
+	var parts []string
+	for i, param := range params {
+		if i+1 < len(params) && params[i+1].typ == param.typ {
+			parts = append(parts, param.name)
+			continue
+		}
+		parts = append(parts, param.name+" "+param.typ)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func byteList(b []byte) string {
+	s := make([]string, 0, len(b))
+	for _, v := range b {
+		s = append(s, fmt.Sprint(v))
+	}
+	return strings.Join(s, ", ")
+}
+
+// paramName is the lower camel case name of an arg, it can't be a keyword, a package or a local of the builder
+func paramName(name string) string {
+	param := lowerCamelCase(name)
+	switch {
+	case token.Lookup(param).IsKeyword(), param == "borsh", param == "common", param == "types",
+		param == "data", param == "err", param == "accounts":
+		return param + "Param"
+	}
+	return param
+}
+
+// pascalCase converts a snake case, camel case or dotted name to an exported go name,
+// "set_config", "setConfig" and "set.config" are all "SetConfig"
+func pascalCase(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if r == '_' || r == '.' || r == '-' || r == ' ' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// lowerCamelCase converts a name to an unexported go name, a leading acronym is lower case, "URIValue" is "uriValue"
+func lowerCamelCase(name string) string {
+	runes := []rune(pascalCase(name))
+	for i := range runes {
+		if !unicode.IsUpper(runes[i]) {
+			break
+		}
+		// the last upper case letter of an acronym starts the next word
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
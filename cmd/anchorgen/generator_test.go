@@ -0,0 +1,323 @@
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	gotypes "go/types"
+	"io/ioutil"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/anchor"
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/cmd/anchorgen/internal/counter"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name   string
+		idl    string
+		config Config
+		golden string
+	}{
+		{
+			name:   "anchor 0.30 idl",
+			idl:    "testdata/counter.json",
+			golden: "internal/counter/counter.go",
+		},
+		{
+			name:   "legacy idl",
+			idl:    "testdata/counter_legacy.json",
+			config: Config{Package: "legacy"},
+			golden: "internal/legacy/legacy.go",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idl, err := anchor.LoadIDL(tt.idl)
+			if err != nil {
+				t.Fatalf("failed to load idl, err: %v", err)
+			}
+			got, err := Generate(idl, tt.config)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			typeCheck(t, tt.golden, got)
+			if *update {
+				if err := ioutil.WriteFile(tt.golden, got, 0644); err != nil {
+					t.Fatalf("failed to update golden file, err: %v", err)
+				}
+			}
+			want, err := ioutil.ReadFile(tt.golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file, err: %v", err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("Generate() doesn't match %v, run go test with -update to update it", tt.golden)
+			}
+		})
+	}
+}
+
+// typeCheck parses and type-checks the generated package, the imports are resolved from the source of the module
+func typeCheck(t *testing.T, filename string, src []byte) {
+	t.Helper()
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, 0)
+	if err != nil {
+		t.Fatalf("failed to parse the generated code, err: %v", err)
+	}
+	config := gotypes.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := config.Check(f.Name.Name, fset, []*ast.File{f}, nil); err != nil {
+		t.Fatalf("failed to type-check the generated code, err: %v", err)
+	}
+}
+
+func TestGenerateError(t *testing.T) {
+	tests := []struct {
+		name    string
+		idl     string
+		config  Config
+		wantErr string
+	}{
+		{
+			name:    "no program id",
+			idl:     `{"metadata": {"name": "counter"}, "instructions": []}`,
+			wantErr: "a program id is needed",
+		},
+		{
+			name:    "invalid program id",
+			idl:     `{"metadata": {"name": "counter"}, "instructions": []}`,
+			config:  Config{ProgramID: "0OIl"},
+			wantErr: "invalid program id",
+		},
+		{
+			name: "name collision",
+			idl: `{
+				"address": "Fg6PaFpoGXkYsidMpWTK6W2BeZ7FEfcYkg476zPFsLnS",
+				"metadata": {"name": "counter"},
+				"instructions": [{"name": "initialize", "discriminator": [1, 2, 3, 4, 5, 6, 7, 8], "accounts": [], "args": []}],
+				"types": [{"name": "Initialize", "type": {"kind": "struct", "fields": []}}]
+			}`,
+			wantErr: "are both named Initialize",
+		},
+		{
+			name: "undefined type",
+			idl: `{
+				"address": "Fg6PaFpoGXkYsidMpWTK6W2BeZ7FEfcYkg476zPFsLnS",
+				"metadata": {"name": "counter"},
+				"instructions": [{"name": "initialize", "accounts": [], "args": [{"name": "config", "type": {"defined": {"name": "Config"}}}]}]
+			}`,
+			wantErr: "undefined type Config",
+		},
+		{
+			name: "nested coption",
+			idl: `{
+				"address": "Fg6PaFpoGXkYsidMpWTK6W2BeZ7FEfcYkg476zPFsLnS",
+				"metadata": {"name": "counter"},
+				"instructions": [{"name": "initialize", "accounts": [], "args": [{"name": "owners", "type": {"vec": {"coption": "pubkey"}}}]}]
+			}`,
+			wantErr: "unsupported type",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idl, err := anchor.ParseIDL([]byte(tt.idl))
+			if err != nil {
+				t.Fatalf("failed to parse idl, err: %v", err)
+			}
+			_, err = Generate(idl, tt.config)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Generate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		name      string
+		pascal    string
+		lower     string
+		parameter string
+	}{
+		{name: "set_config", pascal: "SetConfig", lower: "setConfig", parameter: "setConfig"},
+		{name: "setConfig", pascal: "SetConfig", lower: "setConfig", parameter: "setConfig"},
+		{name: "pool.vault", pascal: "PoolVault", lower: "poolVault", parameter: "poolVault"},
+		{name: "URIValue", pascal: "URIValue", lower: "uriValue", parameter: "uriValue"},
+		{name: "type", pascal: "Type", lower: "type", parameter: "typeParam"},
+		{name: "data", pascal: "Data", lower: "data", parameter: "dataParam"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pascalCase(tt.name); got != tt.pascal {
+				t.Errorf("pascalCase() = %v, want %v", got, tt.pascal)
+			}
+			if got := lowerCamelCase(tt.name); got != tt.lower {
+				t.Errorf("lowerCamelCase() = %v, want %v", got, tt.lower)
+			}
+			if got := paramName(tt.name); got != tt.parameter {
+				t.Errorf("paramName() = %v, want %v", got, tt.parameter)
+			}
+		})
+	}
+}
+
+// TestGeneratedPackage checks the generated counter package against anchor.Program on the same IDL
+func TestGeneratedPackage(t *testing.T) {
+	idl, err := anchor.LoadIDL("testdata/counter.json")
+	if err != nil {
+		t.Fatalf("failed to load idl, err: %v", err)
+	}
+	p, err := anchor.NewProgram(idl, common.PublicKey{})
+	if err != nil {
+		t.Fatalf("failed to create program, err: %v", err)
+	}
+
+	counterPubkey := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	authorityPubkey := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvk7")
+	referrerPubkey := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUJfe1X9K")
+	label := "hi"
+	config := counter.Config{Step: 2, Limit: borsh.Uint128{Lo: 1, Hi: 64}}
+	limit, _ := new(big.Int).SetString("1180591620717411303425", 10)
+
+	instructionTests := []struct {
+		name     string
+		ins      string
+		got      types.Instruction
+		args     map[string]interface{}
+		accounts map[string]common.PublicKey
+		// decoded is the generated instruction struct the data decodes to
+		decoded interface{}
+	}{
+		{
+			name: "initialize",
+			ins:  "initialize",
+			got:  counter.Initialize(counterPubkey, authorityPubkey, 10),
+			args: map[string]interface{}{"start": uint64(10)},
+			accounts: map[string]common.PublicKey{
+				"counter":   counterPubkey,
+				"authority": authorityPubkey,
+			},
+			decoded: &counter.InitializeInstruction{Instruction: counter.InstructionInitialize, Start: 10},
+		},
+		{
+			name: "set_config",
+			ins:  "set_config",
+			got:  counter.SetConfig(counterPubkey, authorityPubkey, nil, config, nil),
+			args: map[string]interface{}{
+				"config": map[string]interface{}{"step": uint16(2), "limit": limit},
+				"label":  nil,
+			},
+			accounts: map[string]common.PublicKey{
+				"counter":   counterPubkey,
+				"authority": authorityPubkey,
+			},
+			decoded: &counter.SetConfigInstruction{Instruction: counter.InstructionSetConfig, Config: config},
+		},
+		{
+			name: "set_config with options",
+			ins:  "set_config",
+			got:  counter.SetConfig(counterPubkey, authorityPubkey, &referrerPubkey, config, &label),
+			args: map[string]interface{}{
+				"config": map[string]interface{}{"step": uint16(2), "limit": limit},
+				"label":  label,
+			},
+			accounts: map[string]common.PublicKey{
+				"counter":   counterPubkey,
+				"authority": authorityPubkey,
+				"referrer":  referrerPubkey,
+			},
+			decoded: &counter.SetConfigInstruction{Instruction: counter.InstructionSetConfig, Config: config, Label: &label},
+		},
+	}
+	for _, tt := range instructionTests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := p.BuildInstruction(tt.ins, tt.args, tt.accounts)
+			if err != nil {
+				t.Fatalf("BuildInstruction() error = %v", err)
+			}
+			if !reflect.DeepEqual(tt.got, want) {
+				t.Errorf("generated builder = %v, anchor.Program = %v", tt.got, want)
+			}
+
+			_, args, err := p.DecodeInstruction(tt.got.Data)
+			if err != nil {
+				t.Fatalf("DecodeInstruction() error = %v", err)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("DecodeInstruction() = %v, want %v", args, tt.args)
+			}
+
+			decoded := reflect.New(reflect.TypeOf(tt.decoded).Elem()).Interface()
+			if err := borsh.Unmarshal(want.Data, decoded); err != nil {
+				t.Fatalf("failed to decode the data with the generated struct, err: %v", err)
+			}
+			if !reflect.DeepEqual(decoded, tt.decoded) {
+				t.Errorf("generated struct = %+v, want %+v", decoded, tt.decoded)
+			}
+
+			if _, err := counter.ParseCounter(want); err != nil {
+				t.Errorf("ParseCounter() error = %v", err)
+			}
+		})
+	}
+
+	t.Run("account", func(t *testing.T) {
+		account := counter.Counter{
+			Authority: authorityPubkey,
+			Count:     7,
+			Mode:      counter.Mode{Enum: counter.ModeCustom, Custom: counter.ModeCustomFields{Delta: -3}},
+			History:   []int32{1, -2},
+			Seed:      [4]uint8{1, 2, 3, 4},
+		}
+		fields := map[string]interface{}{
+			"authority": authorityPubkey,
+			"count":     uint64(7),
+			"mode":      map[string]interface{}{"Custom": map[string]interface{}{"delta": int64(-3)}},
+			"history":   []interface{}{int32(1), int32(-2)},
+			"seed":      []interface{}{uint8(1), uint8(2), uint8(3), uint8(4)},
+		}
+
+		data, err := p.EncodeAccount("Counter", fields)
+		if err != nil {
+			t.Fatalf("EncodeAccount() error = %v", err)
+		}
+		if got := account.Serialize(); !reflect.DeepEqual(got, data) {
+			t.Errorf("Serialize() = %v, anchor.Program = %v", got, data)
+		}
+
+		got, err := counter.CounterFromData(data)
+		if err != nil {
+			t.Fatalf("CounterFromData() error = %v", err)
+		}
+		if !reflect.DeepEqual(*got, account) {
+			t.Errorf("CounterFromData() = %+v, want %+v", *got, account)
+		}
+
+		_, decoded, err := p.DecodeAccount(account.Serialize())
+		if err != nil {
+			t.Fatalf("DecodeAccount() error = %v", err)
+		}
+		if !reflect.DeepEqual(decoded, fields) {
+			t.Errorf("DecodeAccount() = %v, want %v", decoded, fields)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		want, _ := p.Error(6000)
+		got, ok := counter.CounterErrorFromCode(6000)
+		if !ok || got.String() != want.Name || got.Error() != want.Msg {
+			t.Errorf("CounterErrorFromCode() = %v, %v, want %v", got, ok, want)
+		}
+	})
+}
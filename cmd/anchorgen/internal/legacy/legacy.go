@@ -0,0 +1,256 @@
+// Code generated by anchorgen from the IDL of counter. DO NOT EDIT.
+
+// Package legacy builds and parses the instructions of the counter program
+package legacy
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// ProgramID is the program id of counter
+var ProgramID = common.PublicKeyFromString("Fg6PaFpoGXkYsidMpWTK6W2BeZ7FEfcYkg476zPFsLnS")
+
+func init() {
+	types.RegisterInstructionParser(ProgramID, ParseCounter)
+}
+
+// Instruction is the discriminator of an instruction
+type Instruction [8]byte
+
+var (
+	InstructionInitialize = Instruction{175, 175, 109, 31, 13, 152, 155, 237}
+	InstructionSetConfig  = Instruction{108, 158, 154, 175, 212, 98, 52, 66}
+)
+
+type InitializeInstruction struct {
+	Instruction Instruction
+	Start       uint64
+}
+
+type SetConfigInstruction struct {
+	Instruction Instruction
+	Config      Config
+	Label       *string
+}
+
+type Config struct {
+	Step  uint16
+	Limit borsh.Uint128
+}
+
+type Mode struct {
+	Enum   borsh.Enum
+	Up     struct{}
+	Down   struct{}
+	Custom ModeCustomFields
+}
+
+const (
+	ModeUp borsh.Enum = iota
+	ModeDown
+	ModeCustom
+)
+
+type ModeCustomFields struct {
+	Delta int64
+}
+
+type Counter struct {
+	Authority common.PublicKey
+	Count     uint64
+	Mode      Mode
+	History   []int32
+	Seed      [4]uint8
+}
+
+// CounterDiscriminator is the discriminator of the Counter account
+var CounterDiscriminator = [8]byte{255, 176, 4, 245, 188, 253, 124, 25}
+
+// CounterFromData decodes the Counter account, it fails if the discriminator doesn't match
+func CounterFromData(data []byte) (*Counter, error) {
+	if len(data) < len(CounterDiscriminator) || !bytes.Equal(data[:len(CounterDiscriminator)], CounterDiscriminator[:]) {
+		return nil, fmt.Errorf("discriminator not match")
+	}
+	var a Counter
+	if err := borsh.Unmarshal(data[len(CounterDiscriminator):], &a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// Serialize encodes the account after its discriminator
+func (a Counter) Serialize() []byte {
+	return append(CounterDiscriminator[:], borsh.MustMarshal(a)...)
+}
+
+// CounterError is the custom program error code returned by the counter program
+type CounterError uint32
+
+const (
+	CounterErrorOverflow CounterError = 6000
+)
+
+var counterErrors = map[CounterError]struct {
+	name    string
+	message string
+}{
+	CounterErrorOverflow: {"Overflow", "count overflows"},
+}
+
+// CounterErrorFromCode converts a custom program error code to CounterError, ok is false if the code is unknown
+func CounterErrorFromCode(code uint32) (CounterError, bool) {
+	_, ok := counterErrors[CounterError(code)]
+	return CounterError(code), ok
+}
+
+// String returns the name of the error, e.g. "Overflow"
+func (e CounterError) String() string {
+	if v, ok := counterErrors[e]; ok {
+		return v.name
+	}
+	return fmt.Sprintf("CounterError(%d)", uint32(e))
+}
+
+func (e CounterError) Error() string {
+	if v, ok := counterErrors[e]; ok {
+		return v.message
+	}
+	return fmt.Sprintf("unknown counter error: %d", uint32(e))
+}
+
+// Initialize builds the initialize instruction
+func Initialize(counterPubkey, authorityPubkey, systemProgramPubkey common.PublicKey, start uint64) types.Instruction {
+	data, err := borsh.Marshal(InitializeInstruction{
+		Instruction: InstructionInitialize,
+		Start:       start,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 3)
+	accounts = append(accounts, types.AccountMeta{PubKey: counterPubkey, IsSigner: true, IsWritable: true})
+	accounts = append(accounts, types.AccountMeta{PubKey: authorityPubkey, IsSigner: true, IsWritable: true})
+	accounts = append(accounts, types.AccountMeta{PubKey: systemProgramPubkey, IsSigner: false, IsWritable: false})
+
+	return types.Instruction{
+		ProgramID: ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// SetConfig builds the setConfig instruction, a nil optional account is none
+func SetConfig(counterPubkey, authorityPubkey common.PublicKey, referrerPubkey *common.PublicKey, config Config, label *string) types.Instruction {
+	data, err := borsh.Marshal(SetConfigInstruction{
+		Instruction: InstructionSetConfig,
+		Config:      config,
+		Label:       label,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 3)
+	accounts = append(accounts, types.AccountMeta{PubKey: counterPubkey, IsSigner: false, IsWritable: true})
+	accounts = append(accounts, types.AccountMeta{PubKey: authorityPubkey, IsSigner: true, IsWritable: false})
+	accounts = append(accounts, optionalAccount(referrerPubkey, false, false))
+
+	return types.Instruction{
+		ProgramID: ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// optionalAccount returns the account meta of an optional account, Anchor takes the program id as none
+func optionalAccount(pubkey *common.PublicKey, isSigner, isWritable bool) types.AccountMeta {
+	if pubkey == nil {
+		return types.AccountMeta{PubKey: ProgramID, IsSigner: false, IsWritable: false}
+	}
+	return types.AccountMeta{PubKey: *pubkey, IsSigner: isSigner, IsWritable: isWritable}
+}
+
+// ParseCounter decodes instructions of the counter program
+func ParseCounter(ins types.Instruction) (types.ParsedInstruction, error) {
+	var parsedInstruction types.ParsedInstruction
+	var err error
+	var s struct {
+		Instruction Instruction
+	}
+	err = borsh.Unmarshal(ins.Data, &s)
+	if err != nil {
+		return parsedInstruction, err
+	}
+	var instructionType string
+	var parsedInfo map[string]interface{}
+	var accounts []parsedAccount
+	switch s.Instruction {
+	case InstructionInitialize:
+		var a InitializeInstruction
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "initialize"
+		parsedInfo = map[string]interface{}{
+			"start": a.Start,
+		}
+		accounts = []parsedAccount{
+			{"counter", false},
+			{"authority", false},
+			{"systemProgram", false},
+		}
+	case InstructionSetConfig:
+		var a SetConfigInstruction
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "setConfig"
+		parsedInfo = map[string]interface{}{
+			"config": a.Config,
+			"label":  a.Label,
+		}
+		accounts = []parsedAccount{
+			{"counter", false},
+			{"authority", false},
+			{"referrer", true},
+		}
+	default:
+		return parsedInstruction, fmt.Errorf("unknown counter instruction %v", s.Instruction)
+	}
+	if err != nil {
+		return parsedInstruction, err
+	}
+	parseAccounts(parsedInfo, accounts, ins.Accounts)
+	parsedInstruction.Program = "counter"
+	parsedInstruction.Parsed = &types.InstructionInfo{
+		Info:            parsedInfo,
+		InstructionType: instructionType,
+	}
+	return parsedInstruction, nil
+}
+
+type parsedAccount struct {
+	name     string
+	optional bool
+}
+
+// parseAccounts adds the accounts to the info by their names, an optional account which is the program id is none
+// and the accounts after the ones of the instruction are in remainingAccounts
+func parseAccounts(info map[string]interface{}, accounts []parsedAccount, metas []types.AccountMeta) {
+	for i, meta := range metas {
+		if i >= len(accounts) {
+			remaining := make([]string, 0, len(metas)-i)
+			for _, meta := range metas[i:] {
+				remaining = append(remaining, meta.PubKey.ToBase58())
+			}
+			info["remainingAccounts"] = remaining
+			return
+		}
+		if accounts[i].optional && meta.PubKey == ProgramID {
+			continue
+		}
+		info[accounts[i].name] = meta.PubKey.ToBase58()
+	}
+}
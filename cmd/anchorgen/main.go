@@ -0,0 +1,52 @@
+// Command anchorgen generates a go package from the IDL of an Anchor program. Like tokenprog, the package has
+// the instruction builders, the account structs with their decoders, the custom errors and a parser
+// registered to types.ParseInstruction.
+//
+// Usage:
+//
+//	anchorgen -idl target/idl/counter.json -out counter/counter.go [-pkg counter] [-program-id <address>]
+//
+// The package is written to stdout if -out is empty.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/portto/solana-go-sdk/anchor"
+)
+
+func main() {
+	idlPath := flag.String("idl", "", "path of the IDL json")
+	out := flag.String("out", "", "path of the generated go file, stdout if it is empty")
+	pkg := flag.String("pkg", "", "package name, the lower case program name if it is empty")
+	programID := flag.String("program-id", "", "program id, the address in the IDL if it is empty")
+	flag.Parse()
+
+	if *idlPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(*idlPath, *out, Config{Package: *pkg, ProgramID: *programID}); err != nil {
+		fmt.Fprintf(os.Stderr, "anchorgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(idlPath, out string, config Config) error {
+	idl, err := anchor.LoadIDL(idlPath)
+	if err != nil {
+		return err
+	}
+	src, err := Generate(idl, config)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(out, src, 0644)
+}
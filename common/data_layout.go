@@ -6,6 +6,9 @@ import (
 	"reflect"
 )
 
+// SerializeData encodes fixed size integers, bools, byte slices, strings and structs without any length prefix.
+//
+// Deprecated: use borsh.Marshal, which supports all borsh types and the bincode lengths of the native programs.
 func SerializeData(data interface{}) ([]byte, error) {
 	return serializeData(reflect.ValueOf(data))
 }
@@ -6,9 +6,16 @@ var (
 	StakeProgramID                     = PublicKeyFromString("Stake11111111111111111111111111111111111111")
 	VoteProgramID                      = PublicKeyFromString("Vote111111111111111111111111111111111111111")
 	BPFLoaderProgramID                 = PublicKeyFromString("BPFLoader1111111111111111111111111111111111")
+	BPFLoaderUpgradeableProgramID      = PublicKeyFromString("BPFLoaderUpgradeab1e11111111111111111111111")
 	Secp256k1ProgramID                 = PublicKeyFromString("KeccakSecp256k11111111111111111111111111111")
+	Ed25519ProgramID                   = PublicKeyFromString("Ed25519SigVerify111111111111111111111111111")
 	TokenProgramID                     = PublicKeyFromString("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	Token2022ProgramID                 = PublicKeyFromString("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
 	SPLAssociatedTokenAccountProgramID = PublicKeyFromString("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+	AddressLookupTableProgramID        = PublicKeyFromString("AddressLookupTab1e1111111111111111111111111")
+	MemoProgramID                      = PublicKeyFromString("MemoSq4gqABAXKb96qnH8TysNcWxMyWCqXgDLGmfcHr")
+	MemoV1ProgramID                    = PublicKeyFromString("Memo1UhkJRfHyvLMcVucJwxXeuD728EqVDDwQDxFMNo")
+	ComputeBudgetProgramID             = PublicKeyFromString("ComputeBudget111111111111111111111111111111")
 )
 
 func GetProgramName(programId PublicKey) string {
@@ -29,15 +36,33 @@ func GetProgramName(programId PublicKey) string {
 	case BPFLoaderProgramID:
 		name = "bpf-loader"
 		break
+	case BPFLoaderUpgradeableProgramID:
+		name = "bpf-upgradeable-loader"
+		break
 	case Secp256k1ProgramID:
 		name = "secp256k1"
 		break
+	case Ed25519ProgramID:
+		name = "ed25519"
+		break
 	case TokenProgramID:
 		name = "spl-token"
 		break
+	case Token2022ProgramID:
+		name = "spl-token-2022"
+		break
 	case SPLAssociatedTokenAccountProgramID:
 		name = "spl-associated-token-account"
 		break
+	case AddressLookupTableProgramID:
+		name = "address-lookup-table"
+		break
+	case MemoProgramID, MemoV1ProgramID:
+		name = "spl-memo"
+		break
+	case ComputeBudgetProgramID:
+		name = "compute-budget"
+		break
 	}
 	return name
 }
@@ -77,10 +77,12 @@ func CreateWithSeed(from PublicKey, seed string, programID PublicKey) PublicKey
 	return PublicKeyFromBytes(hash[:])
 }
 
-func FindAssociatedTokenAddress(walletAddress, tokenMintAddress PublicKey) (PublicKey, int, error) {
+// FindAssociatedTokenAddress derives the associated token account of the wallet,
+// tokenProgramID is the owner of the mint, TokenProgramID or Token2022ProgramID
+func FindAssociatedTokenAddress(walletAddress, tokenMintAddress, tokenProgramID PublicKey) (PublicKey, int, error) {
 	seeds := [][]byte{}
 	seeds = append(seeds, walletAddress.Bytes())
-	seeds = append(seeds, TokenProgramID.Bytes())
+	seeds = append(seeds, tokenProgramID.Bytes())
 	seeds = append(seeds, tokenMintAddress.Bytes())
 
 	return FindProgramAddress(seeds, SPLAssociatedTokenAccountProgramID)
@@ -122,6 +122,7 @@ func TestFindAssociatedTokenAddress(t *testing.T) {
 	type args struct {
 		walletAddress    PublicKey
 		tokenMintAddress PublicKey
+		tokenProgramID   PublicKey
 	}
 	tests := []struct {
 		name    string
@@ -134,15 +135,26 @@ func TestFindAssociatedTokenAddress(t *testing.T) {
 			args: args{
 				walletAddress:    PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
 				tokenMintAddress: PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH"),
+				tokenProgramID:   TokenProgramID,
 			},
 			want:    PublicKeyFromString("HLzppk6ohPg9Ab99XTFhsa6FcG14Au3rTijGe9c8QHp1"),
 			want1:   254,
 			wantErr: false,
 		},
+		{
+			args: args{
+				walletAddress:    PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				tokenMintAddress: PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH"),
+				tokenProgramID:   Token2022ProgramID,
+			},
+			want:    PublicKeyFromString("Zt9aHhoLTH4d4MBacxVEkXscdXrA4pof2vvsnmDaWRL"),
+			want1:   254,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, got1, err := FindAssociatedTokenAddress(tt.args.walletAddress, tt.args.tokenMintAddress)
+			got, got1, err := FindAssociatedTokenAddress(tt.args.walletAddress, tt.args.tokenMintAddress, tt.args.tokenProgramID)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("FindAssociatedTokenAddress() error = %v, wantErr %v", err, tt.wantErr)
 				return
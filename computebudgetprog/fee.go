@@ -0,0 +1,92 @@
+package computebudgetprog
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+const (
+	// DefaultInstructionComputeUnitLimit is the compute units an instruction gets when no limit is set
+	DefaultInstructionComputeUnitLimit uint32 = 200000
+	// MaxComputeUnitLimit is the compute units a transaction is allowed to consume at most
+	MaxComputeUnitLimit uint32 = 1400000
+	// MicroLamportsPerLamport is the unit the compute unit price is in
+	MicroLamportsPerLamport uint64 = 1000000
+)
+
+// PrioritizationFee returns the lamports charged on top of the signature fees for the compute unit limit
+// at the compute unit price, it rounds up like the runtime does
+func PrioritizationFee(computeUnitLimit uint32, microLamports uint64) uint64 {
+	hi, lo := bits.Mul64(uint64(computeUnitLimit), microLamports)
+	lo, carry := bits.Add64(lo, MicroLamportsPerLamport-1, 0)
+	hi += carry
+	if hi >= MicroLamportsPerLamport {
+		return math.MaxUint64
+	}
+	fee, _ := bits.Div64(hi, lo, MicroLamportsPerLamport)
+	return fee
+}
+
+// DefaultComputeUnitLimit returns the compute unit limit the runtime gives the instructions
+// when the transaction doesn't set one
+func DefaultComputeUnitLimit(instructions []types.Instruction) uint32 {
+	limit := uint64(0)
+	for _, instruction := range instructions {
+		if instruction.ProgramID == common.ComputeBudgetProgramID {
+			continue
+		}
+		limit += uint64(DefaultInstructionComputeUnitLimit)
+	}
+	if limit > uint64(MaxComputeUnitLimit) {
+		return MaxComputeUnitLimit
+	}
+	return uint32(limit)
+}
+
+// WithComputeBudget prepends SetComputeUnitLimit and SetComputeUnitPrice to the instructions and returns
+// the prioritization fee the transaction will be charged. A zero computeUnitLimit keeps the default limit
+// and a zero microLamports adds no priority fee, the corresponding instruction is omitted.
+// SetComputeUnitLimit and SetComputeUnitPrice already in the instructions are replaced, since the runtime
+// rejects a transaction with both, but they are kept if the corresponding argument is zero.
+// The limit is capped at MaxComputeUnitLimit like the runtime does.
+func WithComputeBudget(instructions []types.Instruction, computeUnitLimit uint32, microLamports uint64) ([]types.Instruction, uint64) {
+	rest := make([]types.Instruction, 0, len(instructions))
+	for _, instruction := range instructions {
+		if instruction.ProgramID == common.ComputeBudgetProgramID && len(instruction.Data) > 0 {
+			switch Instruction(instruction.Data[0]) {
+			case InstructionSetComputeUnitLimit:
+				var a SetComputeUnitLimitInstruction
+				if computeUnitLimit == 0 && borsh.Unmarshal(instruction.Data, &a) == nil {
+					computeUnitLimit = a.Units
+				}
+				continue
+			case InstructionSetComputeUnitPrice:
+				var a SetComputeUnitPriceInstruction
+				if microLamports == 0 && borsh.Unmarshal(instruction.Data, &a) == nil {
+					microLamports = a.MicroLamports
+				}
+				continue
+			}
+		}
+		rest = append(rest, instruction)
+	}
+
+	budget := make([]types.Instruction, 0, 2+len(rest))
+	limit := computeUnitLimit
+	if limit > MaxComputeUnitLimit {
+		limit = MaxComputeUnitLimit
+	}
+	if limit > 0 {
+		budget = append(budget, SetComputeUnitLimit(limit))
+	} else {
+		limit = DefaultComputeUnitLimit(rest)
+	}
+	if microLamports > 0 {
+		budget = append(budget, SetComputeUnitPrice(microLamports))
+	}
+	return append(budget, rest...), PrioritizationFee(limit, microLamports)
+}
@@ -0,0 +1,123 @@
+package computebudgetprog
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/sysprog"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func TestPrioritizationFee(t *testing.T) {
+	type args struct {
+		computeUnitLimit uint32
+		microLamports    uint64
+	}
+	tests := []struct {
+		name string
+		args args
+		want uint64
+	}{
+		{
+			args: args{computeUnitLimit: 200000, microLamports: 10000},
+			want: 2000,
+		},
+		{
+			name: "round up",
+			args: args{computeUnitLimit: 1, microLamports: 1},
+			want: 1,
+		},
+		{
+			name: "no price",
+			args: args{computeUnitLimit: 1400000, microLamports: 0},
+			want: 0,
+		},
+		{
+			name: "overflow",
+			args: args{computeUnitLimit: math.MaxUint32, microLamports: math.MaxUint64},
+			want: math.MaxUint64,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PrioritizationFee(tt.args.computeUnitLimit, tt.args.microLamports); got != tt.want {
+				t.Errorf("PrioritizationFee() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithComputeBudget(t *testing.T) {
+	transfer := sysprog.Transfer(
+		common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+		common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+		1,
+	)
+	type args struct {
+		instructions     []types.Instruction
+		computeUnitLimit uint32
+		microLamports    uint64
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []types.Instruction
+		wantFee uint64
+	}{
+		{
+			name:    "limit and price",
+			args:    args{instructions: []types.Instruction{transfer}, computeUnitLimit: 1000, microLamports: 5000},
+			want:    []types.Instruction{SetComputeUnitLimit(1000), SetComputeUnitPrice(5000), transfer},
+			wantFee: 5,
+		},
+		{
+			name:    "default limit",
+			args:    args{instructions: []types.Instruction{transfer, transfer}, microLamports: 1000},
+			want:    []types.Instruction{SetComputeUnitPrice(1000), transfer, transfer},
+			wantFee: 400,
+		},
+		{
+			name:    "limit only",
+			args:    args{instructions: []types.Instruction{transfer}, computeUnitLimit: 1000},
+			want:    []types.Instruction{SetComputeUnitLimit(1000), transfer},
+			wantFee: 0,
+		},
+		{
+			name:    "limit above the max",
+			args:    args{instructions: []types.Instruction{transfer}, computeUnitLimit: 2000000, microLamports: 1000},
+			want:    []types.Instruction{SetComputeUnitLimit(MaxComputeUnitLimit), SetComputeUnitPrice(1000), transfer},
+			wantFee: 1400,
+		},
+		{
+			name: "replace compute budget instructions",
+			args: args{
+				instructions:     []types.Instruction{SetComputeUnitLimit(500), RequestHeapFrame(64 * 1024), SetComputeUnitPrice(1), transfer},
+				computeUnitLimit: 1000,
+				microLamports:    5000,
+			},
+			want:    []types.Instruction{SetComputeUnitLimit(1000), SetComputeUnitPrice(5000), RequestHeapFrame(64 * 1024), transfer},
+			wantFee: 5,
+		},
+		{
+			name: "keep compute budget instructions",
+			args: args{
+				instructions: []types.Instruction{SetComputeUnitPrice(5000), SetComputeUnitLimit(1000), transfer},
+			},
+			want:    []types.Instruction{SetComputeUnitLimit(1000), SetComputeUnitPrice(5000), transfer},
+			wantFee: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotFee := WithComputeBudget(tt.args.instructions, tt.args.computeUnitLimit, tt.args.microLamports)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WithComputeBudget() got = %v, want %v", got, tt.want)
+			}
+			if gotFee != tt.wantFee {
+				t.Errorf("WithComputeBudget() fee = %v, want %v", gotFee, tt.wantFee)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package computebudgetprog
+
+import (
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+type Instruction uint8
+
+const (
+	InstructionRequestUnitsDeprecated Instruction = iota
+	InstructionRequestHeapFrame
+	InstructionSetComputeUnitLimit
+	InstructionSetComputeUnitPrice
+	InstructionSetLoadedAccountsDataSizeLimit
+)
+
+type RequestUnitsDeprecatedInstruction struct {
+	Instruction   Instruction
+	Units         uint32
+	AdditionalFee uint32
+}
+
+type RequestHeapFrameInstruction struct {
+	Instruction Instruction
+	Bytes       uint32
+}
+
+type SetComputeUnitLimitInstruction struct {
+	Instruction Instruction
+	Units       uint32
+}
+
+type SetComputeUnitPriceInstruction struct {
+	Instruction   Instruction
+	MicroLamports uint64
+}
+
+type SetLoadedAccountsDataSizeLimitInstruction struct {
+	Instruction Instruction
+	Bytes       uint32
+}
+
+// RequestHeapFrame requests a heap of bytes for each program of the transaction,
+// bytes must be a multiple of 1024 and no more than 256k
+func RequestHeapFrame(bytes uint32) types.Instruction {
+	data, err := borsh.Marshal(RequestHeapFrameInstruction{
+		Instruction: InstructionRequestHeapFrame,
+		Bytes:       bytes,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.ComputeBudgetProgramID,
+		Accounts:  []types.AccountMeta{},
+		Data:      data,
+	}
+}
+
+// SetComputeUnitLimit sets the compute units the whole transaction is allowed to consume
+func SetComputeUnitLimit(units uint32) types.Instruction {
+	data, err := borsh.Marshal(SetComputeUnitLimitInstruction{
+		Instruction: InstructionSetComputeUnitLimit,
+		Units:       units,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.ComputeBudgetProgramID,
+		Accounts:  []types.AccountMeta{},
+		Data:      data,
+	}
+}
+
+// SetComputeUnitPrice sets the price of a compute unit in micro-lamports, it is what the priority fee is charged by
+func SetComputeUnitPrice(microLamports uint64) types.Instruction {
+	data, err := borsh.Marshal(SetComputeUnitPriceInstruction{
+		Instruction:   InstructionSetComputeUnitPrice,
+		MicroLamports: microLamports,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.ComputeBudgetProgramID,
+		Accounts:  []types.AccountMeta{},
+		Data:      data,
+	}
+}
+
+// SetLoadedAccountsDataSizeLimit sets the total bytes of account data the transaction is allowed to load
+func SetLoadedAccountsDataSizeLimit(bytes uint32) types.Instruction {
+	data, err := borsh.Marshal(SetLoadedAccountsDataSizeLimitInstruction{
+		Instruction: InstructionSetLoadedAccountsDataSizeLimit,
+		Bytes:       bytes,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.ComputeBudgetProgramID,
+		Accounts:  []types.AccountMeta{},
+		Data:      data,
+	}
+}
@@ -0,0 +1,50 @@
+package computebudgetprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func TestInstructions(t *testing.T) {
+	tests := []struct {
+		name string
+		got  types.Instruction
+		want []byte
+	}{
+		{
+			name: "RequestHeapFrame",
+			got:  RequestHeapFrame(256 * 1024),
+			want: []byte{1, 0, 0, 4, 0},
+		},
+		{
+			name: "SetComputeUnitLimit",
+			got:  SetComputeUnitLimit(300000),
+			want: []byte{2, 224, 147, 4, 0},
+		},
+		{
+			name: "SetComputeUnitPrice",
+			got:  SetComputeUnitPrice(10000),
+			want: []byte{3, 16, 39, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name: "SetLoadedAccountsDataSizeLimit",
+			got:  SetLoadedAccountsDataSizeLimit(64 * 1024 * 1024),
+			want: []byte{4, 0, 0, 0, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := types.Instruction{
+				ProgramID: common.ComputeBudgetProgramID,
+				Accounts:  []types.AccountMeta{},
+				Data:      tt.want,
+			}
+			if !reflect.DeepEqual(tt.got, want) {
+				t.Errorf("%s() = %v, want %v", tt.name, tt.got, want)
+			}
+		})
+	}
+}
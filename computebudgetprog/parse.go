@@ -0,0 +1,81 @@
+package computebudgetprog
+
+import (
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func init() {
+	types.RegisterInstructionParser(common.ComputeBudgetProgramID, ParseComputeBudget)
+}
+
+// ParseComputeBudget decodes instructions of the compute budget program
+func ParseComputeBudget(ins types.Instruction) (types.ParsedInstruction, error) {
+	var parsedInstruction types.ParsedInstruction
+	var err error
+	var s struct {
+		Instruction Instruction
+	}
+	err = borsh.Unmarshal(ins.Data, &s)
+	if err != nil {
+		return parsedInstruction, err
+	}
+	var instructionType string
+	var parsedInfo map[string]interface{}
+	switch s.Instruction {
+	case InstructionRequestUnitsDeprecated:
+		var a RequestUnitsDeprecatedInstruction
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "requestUnits"
+		parsedInfo = map[string]interface{}{
+			"units":         a.Units,
+			"additionalFee": a.AdditionalFee,
+		}
+		break
+	case InstructionRequestHeapFrame:
+		var a RequestHeapFrameInstruction
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "requestHeapFrame"
+		parsedInfo = map[string]interface{}{
+			"bytes": a.Bytes,
+		}
+		break
+	case InstructionSetComputeUnitLimit:
+		var a SetComputeUnitLimitInstruction
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "setComputeUnitLimit"
+		parsedInfo = map[string]interface{}{
+			"units": a.Units,
+		}
+		break
+	case InstructionSetComputeUnitPrice:
+		var a SetComputeUnitPriceInstruction
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "setComputeUnitPrice"
+		parsedInfo = map[string]interface{}{
+			"microLamports": a.MicroLamports,
+		}
+		break
+	case InstructionSetLoadedAccountsDataSizeLimit:
+		var a SetLoadedAccountsDataSizeLimitInstruction
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "setLoadedAccountsDataSizeLimit"
+		parsedInfo = map[string]interface{}{
+			"bytes": a.Bytes,
+		}
+		break
+	default:
+		return parsedInstruction, fmt.Errorf("unknown compute budget instruction %d", s.Instruction)
+	}
+	if err != nil {
+		return parsedInstruction, err
+	}
+	parsedInstruction.Parsed = &types.InstructionInfo{
+		Info:            parsedInfo,
+		InstructionType: instructionType,
+	}
+	return parsedInstruction, nil
+}
@@ -0,0 +1,83 @@
+package computebudgetprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func TestParseComputeBudget(t *testing.T) {
+	tests := []struct {
+		ins      types.Instruction
+		wantType string
+		wantInfo map[string]interface{}
+		wantErr  bool
+	}{
+		{
+			ins: types.Instruction{
+				ProgramID: common.ComputeBudgetProgramID,
+				Data:      []byte{0, 64, 13, 3, 0, 232, 3, 0, 0},
+			},
+			wantType: "requestUnits",
+			wantInfo: map[string]interface{}{
+				"units":         uint32(200000),
+				"additionalFee": uint32(1000),
+			},
+		},
+		{
+			ins:      RequestHeapFrame(64 * 1024),
+			wantType: "requestHeapFrame",
+			wantInfo: map[string]interface{}{
+				"bytes": uint32(64 * 1024),
+			},
+		},
+		{
+			ins:      SetComputeUnitLimit(300000),
+			wantType: "setComputeUnitLimit",
+			wantInfo: map[string]interface{}{
+				"units": uint32(300000),
+			},
+		},
+		{
+			ins:      SetComputeUnitPrice(10000),
+			wantType: "setComputeUnitPrice",
+			wantInfo: map[string]interface{}{
+				"microLamports": uint64(10000),
+			},
+		},
+		{
+			ins:      SetLoadedAccountsDataSizeLimit(1024),
+			wantType: "setLoadedAccountsDataSizeLimit",
+			wantInfo: map[string]interface{}{
+				"bytes": uint32(1024),
+			},
+		},
+		{
+			ins: types.Instruction{
+				ProgramID: common.ComputeBudgetProgramID,
+				Data:      []byte{5},
+			},
+			wantType: "unknown",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantType, func(t *testing.T) {
+			got, err := ParseComputeBudget(tt.ins)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseComputeBudget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Parsed.InstructionType != tt.wantType {
+				t.Errorf("ParseComputeBudget() type = %v, want %v", got.Parsed.InstructionType, tt.wantType)
+			}
+			if !reflect.DeepEqual(got.Parsed.Info, tt.wantInfo) {
+				t.Errorf("ParseComputeBudget() info = %v, want %v", got.Parsed.Info, tt.wantInfo)
+			}
+		})
+	}
+}
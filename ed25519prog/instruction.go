@@ -0,0 +1,85 @@
+package ed25519prog
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+const (
+	// SignatureOffsetsSize is the size of the offsets of a signature
+	SignatureOffsetsSize = 14
+	// SignatureOffsetsStart is where the offsets start, after the number of signatures and a padding byte
+	SignatureOffsetsStart = 2
+	// CurrentInstructionIndex means the data is in the instruction the offsets are in
+	CurrentInstructionIndex = 0xffff
+)
+
+// SignatureOffsets locates a signature, the public key of its signer and the message it signs
+// in the data of the instructions of the transaction
+type SignatureOffsets struct {
+	SignatureOffset           uint16
+	SignatureInstructionIndex uint16
+	PublicKeyOffset           uint16
+	PublicKeyInstructionIndex uint16
+	MessageDataOffset         uint16
+	MessageDataSize           uint16
+	MessageInstructionIndex   uint16
+}
+
+// NewEd25519InstructionWithPrivateKey signs the message with the private key and verifies it
+func NewEd25519InstructionWithPrivateKey(privateKey ed25519.PrivateKey, message []byte) (types.Instruction, error) {
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return types.Instruction{}, fmt.Errorf("private key size %d is invalid", len(privateKey))
+	}
+	publicKey := common.PublicKeyFromBytes(privateKey.Public().(ed25519.PublicKey))
+	return NewEd25519Instruction(publicKey, ed25519.Sign(privateKey, message), message)
+}
+
+// NewEd25519Instruction verifies a signature of the message by the public key,
+// the data is all in the instruction so it can be anywhere in the transaction
+func NewEd25519Instruction(publicKey common.PublicKey, signature, message []byte) (types.Instruction, error) {
+	if len(signature) != ed25519.SignatureSize {
+		return types.Instruction{}, fmt.Errorf("signature size %d is invalid", len(signature))
+	}
+
+	publicKeyOffset := SignatureOffsetsStart + SignatureOffsetsSize
+	signatureOffset := publicKeyOffset + ed25519.PublicKeySize
+	messageDataOffset := signatureOffset + ed25519.SignatureSize
+	if messageDataOffset+len(message) > 0xffff {
+		return types.Instruction{}, errors.New("message is too long")
+	}
+
+	data := make([]byte, 0, messageDataOffset+len(message))
+	data = append(data, 1, 0)
+	data = append(data, SignatureOffsets{
+		SignatureOffset:           uint16(signatureOffset),
+		SignatureInstructionIndex: CurrentInstructionIndex,
+		PublicKeyOffset:           uint16(publicKeyOffset),
+		PublicKeyInstructionIndex: CurrentInstructionIndex,
+		MessageDataOffset:         uint16(messageDataOffset),
+		MessageDataSize:           uint16(len(message)),
+		MessageInstructionIndex:   CurrentInstructionIndex,
+	}.Serialize()...)
+	data = append(data, publicKey.Bytes()...)
+	data = append(data, signature...)
+	data = append(data, message...)
+
+	return types.Instruction{
+		ProgramID: common.Ed25519ProgramID,
+		Accounts:  []types.AccountMeta{},
+		Data:      data,
+	}, nil
+}
+
+func (o SignatureOffsets) Serialize() []byte {
+	data, err := borsh.Marshal(o)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
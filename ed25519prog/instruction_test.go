@@ -0,0 +1,52 @@
+package ed25519prog
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+func TestNewEd25519InstructionWithPrivateKey(t *testing.T) {
+	privateKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, 32))
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	message := []byte("hello world")
+
+	ins, err := NewEd25519InstructionWithPrivateKey(privateKey, message)
+	if err != nil {
+		t.Fatalf("NewEd25519InstructionWithPrivateKey() error = %v", err)
+	}
+	if ins.ProgramID != common.Ed25519ProgramID || len(ins.Accounts) != 0 {
+		t.Fatalf("NewEd25519InstructionWithPrivateKey() = %v", ins)
+	}
+
+	want := []byte{1, 0, 48, 0, 255, 255, 16, 0, 255, 255, 112, 0, 11, 0, 255, 255}
+	want = append(want, publicKey...)
+	want = append(want, ed25519.Sign(privateKey, message)...)
+	want = append(want, message...)
+	if !bytes.Equal(ins.Data, want) {
+		t.Errorf("NewEd25519InstructionWithPrivateKey() data = %v, want %v", ins.Data, want)
+	}
+
+	if _, err := NewEd25519InstructionWithPrivateKey(privateKey[:32], message); err == nil {
+		t.Errorf("NewEd25519InstructionWithPrivateKey() with a short private key error = nil")
+	}
+	if _, err := NewEd25519InstructionWithPrivateKey(privateKey, make([]byte, 0xffff)); err == nil {
+		t.Errorf("NewEd25519InstructionWithPrivateKey() with a long message error = nil")
+	}
+}
+
+func TestNewEd25519Instruction(t *testing.T) {
+	publicKey := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	if _, err := NewEd25519Instruction(publicKey, make([]byte, 63), nil); err == nil {
+		t.Errorf("NewEd25519Instruction() with a short signature error = nil")
+	}
+	ins, err := NewEd25519Instruction(publicKey, make([]byte, 64), nil)
+	if err != nil {
+		t.Fatalf("NewEd25519Instruction() error = %v", err)
+	}
+	if len(ins.Data) != 112 {
+		t.Errorf("NewEd25519Instruction() data size = %v, want 112", len(ins.Data))
+	}
+}
@@ -0,0 +1,119 @@
+package ed25519prog
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func init() {
+	types.RegisterInstructionParser(common.Ed25519ProgramID, ParseEd25519)
+}
+
+// VerifiedSignature is a signature the program verifies
+type VerifiedSignature struct {
+	PublicKey common.PublicKey
+	Signature []byte
+	Message   []byte
+}
+
+// ParseSignatureOffsets decodes the offsets of all signatures in the instruction data
+func ParseSignatureOffsets(data []byte) ([]SignatureOffsets, error) {
+	if len(data) < SignatureOffsetsStart {
+		return nil, errors.New("ed25519 instruction data size is not enough")
+	}
+	n := int(data[0])
+	if len(data) < SignatureOffsetsStart+n*SignatureOffsetsSize {
+		return nil, fmt.Errorf("ed25519 instruction data size is not enough for %d signatures", n)
+	}
+	offsets := make([]SignatureOffsets, 0, n)
+	for i := 0; i < n; i++ {
+		var o SignatureOffsets
+		if err := borsh.Unmarshal(data[SignatureOffsetsStart+i*SignatureOffsetsSize:], &o); err != nil {
+			return nil, err
+		}
+		offsets = append(offsets, o)
+	}
+	return offsets, nil
+}
+
+// Resolve reads the signature, the public key and the message from the data of the instruction the offsets are in,
+// or from the data of the instructions of the transaction if an index is not CurrentInstructionIndex
+func (o SignatureOffsets) Resolve(current []byte, instructionsData [][]byte) (VerifiedSignature, error) {
+	signature, err := slice(current, instructionsData, o.SignatureInstructionIndex, o.SignatureOffset, ed25519.SignatureSize)
+	if err != nil {
+		return VerifiedSignature{}, fmt.Errorf("invalid signature offsets: %w", err)
+	}
+	publicKey, err := slice(current, instructionsData, o.PublicKeyInstructionIndex, o.PublicKeyOffset, ed25519.PublicKeySize)
+	if err != nil {
+		return VerifiedSignature{}, fmt.Errorf("invalid public key offsets: %w", err)
+	}
+	message, err := slice(current, instructionsData, o.MessageInstructionIndex, o.MessageDataOffset, int(o.MessageDataSize))
+	if err != nil {
+		return VerifiedSignature{}, fmt.Errorf("invalid message offsets: %w", err)
+	}
+	return VerifiedSignature{
+		PublicKey: common.PublicKeyFromBytes(publicKey),
+		Signature: signature,
+		Message:   message,
+	}, nil
+}
+
+func slice(current []byte, instructionsData [][]byte, index, offset uint16, size int) ([]byte, error) {
+	data := current
+	if index != CurrentInstructionIndex {
+		if int(index) >= len(instructionsData) {
+			return nil, fmt.Errorf("instruction index %d is out of range", index)
+		}
+		data = instructionsData[index]
+	}
+	if int(offset)+size > len(data) {
+		return nil, fmt.Errorf("offset %d and size %d are out of the data", offset, size)
+	}
+	return data[int(offset) : int(offset)+size], nil
+}
+
+// ParseEd25519 decodes the offsets of the signatures, the signature, the public key and the message are
+// added if they are in the instruction itself
+func ParseEd25519(ins types.Instruction) (types.ParsedInstruction, error) {
+	var parsedInstruction types.ParsedInstruction
+	offsets, err := ParseSignatureOffsets(ins.Data)
+	if err != nil {
+		return parsedInstruction, err
+	}
+
+	signatures := make([]map[string]interface{}, 0, len(offsets))
+	for _, o := range offsets {
+		info := map[string]interface{}{
+			"signatureOffset":           o.SignatureOffset,
+			"signatureInstructionIndex": o.SignatureInstructionIndex,
+			"publicKeyOffset":           o.PublicKeyOffset,
+			"publicKeyInstructionIndex": o.PublicKeyInstructionIndex,
+			"messageDataOffset":         o.MessageDataOffset,
+			"messageDataSize":           o.MessageDataSize,
+			"messageInstructionIndex":   o.MessageInstructionIndex,
+		}
+		if o.SignatureInstructionIndex == CurrentInstructionIndex && o.PublicKeyInstructionIndex == CurrentInstructionIndex && o.MessageInstructionIndex == CurrentInstructionIndex {
+			if signature, err := o.Resolve(ins.Data, nil); err == nil {
+				info["publicKey"] = signature.PublicKey.ToBase58()
+				info["signature"] = base58.Encode(signature.Signature)
+				info["message"] = hex.EncodeToString(signature.Message)
+			}
+		}
+		signatures = append(signatures, info)
+	}
+
+	parsedInstruction.Parsed = &types.InstructionInfo{
+		Info: map[string]interface{}{
+			"signatures": signatures,
+		},
+		InstructionType: "verify",
+	}
+	return parsedInstruction, nil
+}
@@ -0,0 +1,84 @@
+package ed25519prog
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"reflect"
+	"testing"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+func TestParseEd25519(t *testing.T) {
+	privateKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, 32))
+	publicKey := common.PublicKeyFromBytes(privateKey.Public().(ed25519.PublicKey))
+	signature := ed25519.Sign(privateKey, []byte("hi"))
+	ins, err := NewEd25519InstructionWithPrivateKey(privateKey, []byte("hi"))
+	if err != nil {
+		t.Fatalf("NewEd25519InstructionWithPrivateKey() error = %v", err)
+	}
+
+	got, err := ParseEd25519(ins)
+	if err != nil {
+		t.Fatalf("ParseEd25519() error = %v", err)
+	}
+	want := map[string]interface{}{
+		"signatures": []map[string]interface{}{
+			{
+				"signatureOffset":           uint16(48),
+				"signatureInstructionIndex": uint16(CurrentInstructionIndex),
+				"publicKeyOffset":           uint16(16),
+				"publicKeyInstructionIndex": uint16(CurrentInstructionIndex),
+				"messageDataOffset":         uint16(112),
+				"messageDataSize":           uint16(2),
+				"messageInstructionIndex":   uint16(CurrentInstructionIndex),
+				"publicKey":                 publicKey.ToBase58(),
+				"signature":                 base58.Encode(signature),
+				"message":                   "6869",
+			},
+		},
+	}
+	if got.Parsed.InstructionType != "verify" || !reflect.DeepEqual(got.Parsed.Info, want) {
+		t.Errorf("ParseEd25519() = %v, want %v", got.Parsed.Info, want)
+	}
+
+	if _, err := ParseSignatureOffsets([]byte{1, 0, 48}); err == nil {
+		t.Errorf("ParseSignatureOffsets() with missing offsets error = nil")
+	}
+}
+
+func TestSignatureOffsetsResolve(t *testing.T) {
+	privateKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{2}, 32))
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	message := []byte("message in another instruction")
+	signature := ed25519.Sign(privateKey, message)
+
+	// the signature, the key and the message are in the instruction at index 1
+	other := append(append(append([]byte{}, publicKey...), signature...), message...)
+	o := SignatureOffsets{
+		SignatureOffset:           32,
+		SignatureInstructionIndex: 1,
+		PublicKeyOffset:           0,
+		PublicKeyInstructionIndex: 1,
+		MessageDataOffset:         96,
+		MessageDataSize:           uint16(len(message)),
+		MessageInstructionIndex:   1,
+	}
+	data := append([]byte{1, 0}, o.Serialize()...)
+
+	offsets, err := ParseSignatureOffsets(data)
+	if err != nil || len(offsets) != 1 || offsets[0] != o {
+		t.Fatalf("ParseSignatureOffsets() = %v, %v", offsets, err)
+	}
+	got, err := offsets[0].Resolve(data, [][]byte{data, other})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if !ed25519.Verify(got.PublicKey.Bytes(), got.Message, got.Signature) {
+		t.Errorf("Resolve() = %v, the signature is not valid", got)
+	}
+	if _, err := offsets[0].Resolve(data, nil); err == nil {
+		t.Errorf("Resolve() with the instruction missing error = nil")
+	}
+}
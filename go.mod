@@ -3,8 +3,9 @@ module github.com/portto/solana-go-sdk
 go 1.16
 
 require (
-	github.com/ghostiam/binstruct v1.0.1
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.0.1
+	github.com/gorilla/websocket v1.5.0
 	github.com/mr-tron/base58 v1.2.0
-	github.com/pkg/errors v0.9.1 // indirect
 	github.com/teserakt-io/golang-ed25519 v0.0.0-20210104091850-3888c087a4c8
+	golang.org/x/crypto v0.1.0
 )
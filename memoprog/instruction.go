@@ -0,0 +1,47 @@
+package memoprog
+
+import (
+	"errors"
+	"unicode/utf8"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+var ErrInvalidUTF8 = errors.New("memo is not a valid utf-8 string")
+
+// ValidateMemo checks the memo is valid utf-8, the memo program fails on any other data
+func ValidateMemo(memo []byte) error {
+	if !utf8.Valid(memo) {
+		return ErrInvalidUTF8
+	}
+	return nil
+}
+
+// BuildMemo creates an instruction of the memo program, every signer pubkey has to sign the transaction.
+// It panics if the memo is not valid utf-8.
+func BuildMemo(memo string, signerPubkeys []common.PublicKey) types.Instruction {
+	return buildMemo(common.MemoProgramID, memo, signerPubkeys)
+}
+
+// BuildMemoV1 is BuildMemo with the first version of the memo program, which doesn't verify signers
+func BuildMemoV1(memo string, signerPubkeys []common.PublicKey) types.Instruction {
+	return buildMemo(common.MemoV1ProgramID, memo, signerPubkeys)
+}
+
+func buildMemo(programID common.PublicKey, memo string, signerPubkeys []common.PublicKey) types.Instruction {
+	if err := ValidateMemo([]byte(memo)); err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, len(signerPubkeys))
+	for _, signerPubkey := range signerPubkeys {
+		accounts = append(accounts, types.AccountMeta{PubKey: signerPubkey, IsSigner: true, IsWritable: false})
+	}
+
+	return types.Instruction{
+		ProgramID: programID,
+		Accounts:  accounts,
+		Data:      []byte(memo),
+	}
+}
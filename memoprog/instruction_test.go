@@ -0,0 +1,66 @@
+package memoprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func TestBuildMemo(t *testing.T) {
+	signer := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	type args struct {
+		memo          string
+		signerPubkeys []common.PublicKey
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			name: "without signer",
+			args: args{memo: "hello"},
+			want: types.Instruction{
+				ProgramID: common.MemoProgramID,
+				Accounts:  []types.AccountMeta{},
+				Data:      []byte("hello"),
+			},
+		},
+		{
+			name: "with signer",
+			args: args{memo: "🦖", signerPubkeys: []common.PublicKey{signer}},
+			want: types.Instruction{
+				ProgramID: common.MemoProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: signer, IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{0xf0, 0x9f, 0xa6, 0x96},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BuildMemo(tt.args.memo, tt.args.signerPubkeys); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BuildMemo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildMemoV1(t *testing.T) {
+	got := BuildMemoV1("hello", nil)
+	if got.ProgramID != common.MemoV1ProgramID || string(got.Data) != "hello" {
+		t.Errorf("BuildMemoV1() = %v", got)
+	}
+}
+
+func TestBuildMemoInvalidUTF8(t *testing.T) {
+	defer func() {
+		if r := recover(); r != ErrInvalidUTF8 {
+			t.Errorf("BuildMemo() panic = %v, want %v", r, ErrInvalidUTF8)
+		}
+	}()
+	BuildMemo(string([]byte{0xff, 0xfe}), nil)
+}
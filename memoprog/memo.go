@@ -0,0 +1,81 @@
+package memoprog
+
+import (
+	"fmt"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func isMemoProgram(programID common.PublicKey) bool {
+	return programID == common.MemoProgramID || programID == common.MemoV1ProgramID
+}
+
+// MemosFromTransaction returns the memos of the transaction in instruction order
+func MemosFromTransaction(tx types.Transaction) []string {
+	memos := []string{}
+	for _, ins := range tx.Message.DecompileInstructions() {
+		if isMemoProgram(ins.ProgramID) {
+			memos = append(memos, string(ins.Data))
+		}
+	}
+	return memos
+}
+
+// MemosFromConfirmedTransaction returns the memos of a confirmed transaction in execution order,
+// memos written by other programs through inner instructions follow the instruction which invoked them.
+func MemosFromConfirmedTransaction(res client.GetConfirmedTransactionResponse) ([]string, error) {
+	return MemosFromTransactionWithMeta(client.TransactionWithMeta{
+		Meta:        res.Meta,
+		Transaction: res.Transaction,
+	})
+}
+
+// MemosFromTransactionWithMeta is MemosFromConfirmedTransaction for a transaction of a confirmed block
+func MemosFromTransactionWithMeta(tx client.TransactionWithMeta) ([]string, error) {
+	accountKeys := tx.Transaction.Message.AccountKeys
+	memoOf := func(ins client.Instruction) (string, bool, error) {
+		if ins.ProgramIDIndex >= uint64(len(accountKeys)) {
+			return "", false, fmt.Errorf("program id index %d is out of accounts", ins.ProgramIDIndex)
+		}
+		if !isMemoProgram(common.PublicKeyFromString(accountKeys[ins.ProgramIDIndex])) {
+			return "", false, nil
+		}
+		if ins.Data == "" {
+			return "", true, nil
+		}
+		data, err := base58.Decode(ins.Data)
+		if err != nil {
+			return "", false, err
+		}
+		return string(data), true, nil
+	}
+
+	memos := []string{}
+	for i, ins := range tx.Transaction.Message.Instructions {
+		memo, ok, err := memoOf(ins)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			memos = append(memos, memo)
+		}
+		for _, inner := range tx.Meta.InnerInstructions {
+			if inner.Index != uint64(i) {
+				continue
+			}
+			for _, innerIns := range inner.Instructions {
+				memo, ok, err := memoOf(innerIns)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					memos = append(memos, memo)
+				}
+			}
+		}
+	}
+	return memos, nil
+}
@@ -0,0 +1,67 @@
+package memoprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/sysprog"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func TestMemosFromTransaction(t *testing.T) {
+	from := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	to := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	message := types.NewMessage(from, []types.Instruction{
+		sysprog.Transfer(from, to, 1),
+		BuildMemo("order 1", []common.PublicKey{from}),
+		BuildMemoV1("order 2", nil),
+	}, "FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+
+	got := MemosFromTransaction(types.Transaction{Message: message})
+	want := []string{"order 1", "order 2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MemosFromTransaction() = %v, want %v", got, want)
+	}
+}
+
+func TestMemosFromConfirmedTransaction(t *testing.T) {
+	res := client.GetConfirmedTransactionResponse{
+		Transaction: client.Transaction{
+			Message: client.Message{
+				AccountKeys: []string{
+					"BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ",
+					common.MemoProgramID.ToBase58(),
+					"FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm",
+				},
+				Instructions: []client.Instruction{
+					{ProgramIDIndex: 2, Accounts: []uint64{0}, Data: base58.Encode([]byte{1})},
+					{ProgramIDIndex: 1, Accounts: []uint64{}, Data: base58.Encode([]byte("outer"))},
+				},
+			},
+		},
+	}
+	res.Meta.InnerInstructions = append(res.Meta.InnerInstructions, struct {
+		Index        uint64               `json:"index"`
+		Instructions []client.Instruction `json:"instructions"`
+	}{
+		Index:        0,
+		Instructions: []client.Instruction{{ProgramIDIndex: 1, Data: base58.Encode([]byte("inner"))}},
+	})
+
+	got, err := MemosFromConfirmedTransaction(res)
+	if err != nil {
+		t.Fatalf("MemosFromConfirmedTransaction() error = %v", err)
+	}
+	want := []string{"inner", "outer"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MemosFromConfirmedTransaction() = %v, want %v", got, want)
+	}
+
+	res.Transaction.Message.Instructions[1].ProgramIDIndex = 3
+	if _, err := MemosFromConfirmedTransaction(res); err == nil {
+		t.Errorf("MemosFromConfirmedTransaction() should fail on an out of range program id index")
+	}
+}
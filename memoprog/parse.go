@@ -0,0 +1,38 @@
+package memoprog
+
+import (
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func init() {
+	types.RegisterInstructionParser(common.MemoProgramID, ParseMemo)
+	types.RegisterInstructionParser(common.MemoV1ProgramID, ParseMemo)
+}
+
+// ParseMemo renders the memo text, the rpc node returns the text itself as the parsed instruction
+// so it is put in the info together with the signers.
+func ParseMemo(ins types.Instruction) (types.ParsedInstruction, error) {
+	var parsedInstruction types.ParsedInstruction
+	if ins.ProgramID != common.MemoProgramID && ins.ProgramID != common.MemoV1ProgramID {
+		return parsedInstruction, fmt.Errorf("%s is not a memo program", ins.ProgramID.ToBase58())
+	}
+	if err := ValidateMemo(ins.Data); err != nil {
+		return parsedInstruction, err
+	}
+
+	signers := make([]string, 0, len(ins.Accounts))
+	for _, account := range ins.Accounts {
+		signers = append(signers, account.PubKey.ToBase58())
+	}
+	parsedInstruction.Parsed = &types.InstructionInfo{
+		Info: map[string]interface{}{
+			"memo":    string(ins.Data),
+			"signers": signers,
+		},
+		InstructionType: "memo",
+	}
+	return parsedInstruction, nil
+}
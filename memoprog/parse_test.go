@@ -0,0 +1,35 @@
+package memoprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func TestParseMemo(t *testing.T) {
+	signer := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	got, err := types.ParseInstruction(BuildMemo("hello", []common.PublicKey{signer}))
+	if err != nil {
+		t.Fatalf("ParseInstruction() error = %v", err)
+	}
+	want := types.ParsedInstruction{
+		Parsed: &types.InstructionInfo{
+			Info: map[string]interface{}{
+				"memo":    "hello",
+				"signers": []string{signer.ToBase58()},
+			},
+			InstructionType: "memo",
+		},
+		Program:   "spl-memo",
+		ProgramID: common.MemoProgramID.ToBase58(),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseInstruction() = %v, want %v", got, want)
+	}
+
+	if _, err := ParseMemo(types.Instruction{ProgramID: common.MemoV1ProgramID, Data: []byte{0xff}}); err != ErrInvalidUTF8 {
+		t.Errorf("ParseMemo() error = %v, want %v", err, ErrInvalidUTF8)
+	}
+}
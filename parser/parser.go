@@ -0,0 +1,102 @@
+// Package parser decodes instructions and transactions with the parsers of all programs the sdk supports.
+// Applications register parsers for their own programs with Register.
+package parser
+
+import (
+	"fmt"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+
+	// program packages register their parsers on import
+	_ "github.com/portto/solana-go-sdk/assotokenprog"
+	_ "github.com/portto/solana-go-sdk/computebudgetprog"
+	_ "github.com/portto/solana-go-sdk/ed25519prog"
+	_ "github.com/portto/solana-go-sdk/memoprog"
+	_ "github.com/portto/solana-go-sdk/secp256k1prog"
+	_ "github.com/portto/solana-go-sdk/stakeprog"
+	_ "github.com/portto/solana-go-sdk/sysprog"
+	_ "github.com/portto/solana-go-sdk/tokenprog"
+	_ "github.com/portto/solana-go-sdk/voteprog"
+)
+
+// Register sets the parser of a program, it replaces the registered one if any
+func Register(programID common.PublicKey, parser types.InstructionParser) {
+	types.RegisterInstructionParser(programID, parser)
+}
+
+// ParseInstruction decodes the instruction, see types.ParseInstruction
+func ParseInstruction(ins types.Instruction) (types.ParsedInstruction, error) {
+	return types.ParseInstruction(ins)
+}
+
+// ParseTransaction decodes all instructions of the transaction, see types.ParseTransaction
+func ParseTransaction(tx types.Transaction) (types.ParsedTransaction, error) {
+	return types.ParseTransaction(tx)
+}
+
+// ParseClientTransaction decodes a transaction returned by the rpc node with the json encoding
+func ParseClientTransaction(tx client.Transaction) (types.ParsedTransaction, error) {
+	t, err := clientTransactionToTypes(tx)
+	if err != nil {
+		return types.ParsedTransaction{}, err
+	}
+	return types.ParseTransaction(t)
+}
+
+func clientTransactionToTypes(tx client.Transaction) (types.Transaction, error) {
+	signatures := make([]types.Signature, 0, len(tx.Signatures))
+	for _, signature := range tx.Signatures {
+		b, err := base58.Decode(signature)
+		if err != nil {
+			return types.Transaction{}, fmt.Errorf("failed to decode signature %s: %v", signature, err)
+		}
+		signatures = append(signatures, b)
+	}
+
+	accounts := make([]common.PublicKey, 0, len(tx.Message.AccountKeys))
+	for _, key := range tx.Message.AccountKeys {
+		b, err := base58.Decode(key)
+		if err != nil || len(b) != 32 {
+			return types.Transaction{}, fmt.Errorf("invalid account key %s", key)
+		}
+		accounts = append(accounts, common.PublicKeyFromBytes(b))
+	}
+
+	instructions := make([]types.CompiledInstruction, 0, len(tx.Message.Instructions))
+	for _, ins := range tx.Message.Instructions {
+		data := []byte{}
+		if ins.Data != "" {
+			var err error
+			data, err = base58.Decode(ins.Data)
+			if err != nil {
+				return types.Transaction{}, fmt.Errorf("failed to decode instruction data: %v", err)
+			}
+		}
+		accountIdx := make([]int, 0, len(ins.Accounts))
+		for _, idx := range ins.Accounts {
+			accountIdx = append(accountIdx, int(idx))
+		}
+		instructions = append(instructions, types.CompiledInstruction{
+			ProgramIDIndex: int(ins.ProgramIDIndex),
+			Accounts:       accountIdx,
+			Data:           data,
+		})
+	}
+
+	return types.Transaction{
+		Signatures: signatures,
+		Message: types.Message{
+			Header: types.MessageHeader{
+				NumRequireSignatures:        tx.Message.Header.NumRequiredSignatures,
+				NumReadonlySignedAccounts:   tx.Message.Header.NumReadonlySignedAccounts,
+				NumReadonlyUnsignedAccounts: tx.Message.Header.NumReadonlyUnsignedAccounts,
+			},
+			Accounts:        accounts,
+			RecentBlockHash: tx.Message.RecentBlockhash,
+			Instructions:    instructions,
+		},
+	}, nil
+}
@@ -0,0 +1,129 @@
+package parser
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/sysprog"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func TestParseTransaction(t *testing.T) {
+	feePayer := types.AccountFromPrivateKeyBytes([]byte{57, 17, 193, 142, 252, 221, 81, 90, 60, 28, 93, 237, 212, 51, 95, 95, 41, 104, 221, 59, 13, 244, 54, 1, 79, 180, 120, 178, 81, 45, 46, 193, 142, 11, 237, 209, 82, 24, 36, 72, 7, 76, 66, 215, 44, 116, 17, 132, 252, 205, 47, 74, 57, 230, 36, 98, 119, 86, 11, 40, 71, 195, 47, 254})
+	to := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+	myProgram := common.PublicKeyFromString("9FfgNAyyvGPMCgjsDYgW9wcWaxzKw5ohtkMudySGU7Pd")
+	unknownProgram := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+
+	Register(myProgram, func(ins types.Instruction) (types.ParsedInstruction, error) {
+		if len(ins.Data) == 0 {
+			return types.ParsedInstruction{}, errors.New("empty data")
+		}
+		return types.ParsedInstruction{
+			Program: "my-program",
+			Parsed: &types.InstructionInfo{
+				Info:            map[string]interface{}{"value": ins.Data[0]},
+				InstructionType: "set",
+			},
+		}, nil
+	})
+
+	rawTx, err := types.CreateRawTransaction(types.CreateRawTransactionParam{
+		Instructions: []types.Instruction{
+			sysprog.Transfer(feePayer.PublicKey, to, 1),
+			{ProgramID: myProgram, Accounts: []types.AccountMeta{{PubKey: to, IsWritable: true}}, Data: []byte{7}},
+			{ProgramID: myProgram, Accounts: []types.AccountMeta{{PubKey: to, IsWritable: true}}, Data: []byte{}},
+			{ProgramID: unknownProgram, Accounts: []types.AccountMeta{{PubKey: to, IsWritable: true}}, Data: []byte{1, 2, 3}},
+		},
+		Signers:         []types.Account{feePayer},
+		FeePayer:        feePayer.PublicKey,
+		RecentBlockHash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+	})
+	if err != nil {
+		t.Fatalf("CreateRawTransaction() error = %v", err)
+	}
+	tx := types.MustTransactionDeserialize(rawTx)
+
+	wantInstructions := []types.ParsedInstruction{
+		{
+			Program:   "system",
+			ProgramID: common.SystemProgramID.ToBase58(),
+			Parsed: &types.InstructionInfo{
+				InstructionType: "transfer",
+				Info: map[string]interface{}{
+					"source":      feePayer.PublicKey.ToBase58(),
+					"destination": to.ToBase58(),
+					"lamports":    uint64(1),
+				},
+			},
+		},
+		{
+			Program:   "my-program",
+			ProgramID: myProgram.ToBase58(),
+			Parsed: &types.InstructionInfo{
+				InstructionType: "set",
+				Info:            map[string]interface{}{"value": byte(7)},
+			},
+		},
+		{
+			ProgramID: myProgram.ToBase58(),
+			Accounts:  []string{to.ToBase58()},
+			Data:      "",
+		},
+		{
+			ProgramID: unknownProgram.ToBase58(),
+			Accounts:  []string{to.ToBase58()},
+			Data:      base58.Encode([]byte{1, 2, 3}),
+		},
+	}
+
+	got, err := ParseTransaction(tx)
+	if err != nil {
+		t.Fatalf("ParseTransaction() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Message.Instructions, wantInstructions) {
+		t.Errorf("ParseTransaction() instructions = %+v, want %+v", got.Message.Instructions, wantInstructions)
+	}
+	if got.Signatures[0] != tx.Signatures[0].ToBase58() || got.Message.AccountKeys[0] != (types.ParsedAccKey{PubKey: feePayer.PublicKey.ToBase58(), IsSigner: true, IsWritable: true}) {
+		t.Errorf("ParseTransaction() = %+v", got)
+	}
+
+	// the same transaction as returned by getConfirmedTransaction
+	clientTx := client.Transaction{Signatures: []string{tx.Signatures[0].ToBase58()}}
+	clientTx.Message.Header = client.MessageHeader{
+		NumRequiredSignatures:       tx.Message.Header.NumRequireSignatures,
+		NumReadonlySignedAccounts:   tx.Message.Header.NumReadonlySignedAccounts,
+		NumReadonlyUnsignedAccounts: tx.Message.Header.NumReadonlyUnsignedAccounts,
+	}
+	clientTx.Message.RecentBlockhash = tx.Message.RecentBlockHash
+	for _, account := range tx.Message.Accounts {
+		clientTx.Message.AccountKeys = append(clientTx.Message.AccountKeys, account.ToBase58())
+	}
+	for _, ins := range tx.Message.Instructions {
+		accounts := []uint64{}
+		for _, idx := range ins.Accounts {
+			accounts = append(accounts, uint64(idx))
+		}
+		clientTx.Message.Instructions = append(clientTx.Message.Instructions, client.Instruction{
+			ProgramIDIndex: uint64(ins.ProgramIDIndex),
+			Accounts:       accounts,
+			Data:           base58.Encode(ins.Data),
+		})
+	}
+	gotClient, err := ParseClientTransaction(clientTx)
+	if err != nil || !reflect.DeepEqual(gotClient, got) {
+		t.Errorf("ParseClientTransaction() = %+v, %v, want %+v", gotClient, err, got)
+	}
+}
+
+func TestParseInstructionPanic(t *testing.T) {
+	ins := sysprog.Transfer(common.SystemProgramID, common.SystemProgramID, 1)
+	ins.Accounts = ins.Accounts[:1]
+	got, err := ParseInstruction(ins)
+	if err == nil || got.Parsed != nil || got.Data == "" {
+		t.Errorf("ParseInstruction() = %+v, %v, want raw instruction and error", got, err)
+	}
+}
@@ -0,0 +1,152 @@
+package secp256k1prog
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// SignatureOffsetsSize is the size of the offsets of a signature
+	SignatureOffsetsSize = 11
+	// SignatureOffsetsStart is where the offsets start, after the number of signatures
+	SignatureOffsetsStart = 1
+	// EthAddressSize is the size of an ethereum address
+	EthAddressSize = 20
+	// SignatureSize is the size of a signature, r || s
+	SignatureSize = 64
+	// SignatureWithRecoveryIDSize is the size of a signature followed by its recovery id
+	SignatureWithRecoveryIDSize = SignatureSize + 1
+	// PrivateKeySize is the size of a secp256k1 private key
+	PrivateKeySize = 32
+)
+
+// SignatureOffsets locates a signature, the ethereum address of its signer and the message it signs
+// in the data of the instructions of the transaction
+type SignatureOffsets struct {
+	SignatureOffset            uint16
+	SignatureInstructionIndex  uint8
+	EthAddressOffset           uint16
+	EthAddressInstructionIndex uint8
+	MessageDataOffset          uint16
+	MessageDataSize            uint16
+	MessageInstructionIndex    uint8
+}
+
+// EthAddressFromPublicKey returns the ethereum address of a 64-byte uncompressed public key,
+// a 65-byte one with the 0x04 prefix is accepted as well
+func EthAddressFromPublicKey(publicKey []byte) ([]byte, error) {
+	if len(publicKey) == 65 && publicKey[0] == 0x04 {
+		publicKey = publicKey[1:]
+	}
+	if len(publicKey) != 64 {
+		return nil, fmt.Errorf("public key size %d is invalid", len(publicKey))
+	}
+	hash := keccak256(publicKey)
+	return hash[32-EthAddressSize:], nil
+}
+
+// EthAddressFromPrivateKey returns the ethereum address of a 32-byte private key
+func EthAddressFromPrivateKey(privateKey []byte) ([]byte, error) {
+	if len(privateKey) != PrivateKeySize {
+		return nil, fmt.Errorf("private key size %d is invalid", len(privateKey))
+	}
+	return EthAddressFromPublicKey(secp256k1.PrivKeyFromBytes(privateKey).PubKey().SerializeUncompressed())
+}
+
+// Sign signs the keccak256 hash of the message and returns the signature followed by its recovery id,
+// which is what the program verifies
+func Sign(privateKey, message []byte) ([]byte, error) {
+	if len(privateKey) != PrivateKeySize {
+		return nil, fmt.Errorf("private key size %d is invalid", len(privateKey))
+	}
+	hash := keccak256(message)
+	// compact signatures are <27 + recovery id><r><s>
+	compact := ecdsa.SignCompact(secp256k1.PrivKeyFromBytes(privateKey), hash[:], false)
+	signature := make([]byte, 0, SignatureWithRecoveryIDSize)
+	signature = append(signature, compact[1:]...)
+	return append(signature, compact[0]-27), nil
+}
+
+// NewSecp256k1InstructionWithPrivateKey signs the message with the ethereum private key and verifies it,
+// instructionIndex is the index of this instruction in the transaction
+func NewSecp256k1InstructionWithPrivateKey(privateKey, message []byte, instructionIndex uint8) (types.Instruction, error) {
+	ethAddress, err := EthAddressFromPrivateKey(privateKey)
+	if err != nil {
+		return types.Instruction{}, err
+	}
+	signature, err := Sign(privateKey, message)
+	if err != nil {
+		return types.Instruction{}, err
+	}
+	return NewSecp256k1Instruction(ethAddress, signature, message, instructionIndex)
+}
+
+// NewSecp256k1Instruction verifies a signature of the message by the ethereum address.
+// The signature is r || s || v, v is the recovery id and may be 27 or 28 as ethereum returns it.
+// instructionIndex is the index of this instruction in the transaction, the program locates the data by it.
+func NewSecp256k1Instruction(ethAddress, signature, message []byte, instructionIndex uint8) (types.Instruction, error) {
+	if len(ethAddress) != EthAddressSize {
+		return types.Instruction{}, fmt.Errorf("eth address size %d is invalid", len(ethAddress))
+	}
+	if len(signature) != SignatureWithRecoveryIDSize {
+		return types.Instruction{}, fmt.Errorf("signature size %d is invalid", len(signature))
+	}
+	recoveryID := signature[SignatureSize]
+	if recoveryID >= 27 {
+		recoveryID -= 27
+	}
+	if recoveryID > 3 {
+		return types.Instruction{}, errors.New("recovery id is invalid")
+	}
+
+	ethAddressOffset := SignatureOffsetsStart + SignatureOffsetsSize
+	signatureOffset := ethAddressOffset + EthAddressSize
+	messageDataOffset := signatureOffset + SignatureWithRecoveryIDSize
+	if messageDataOffset+len(message) > 0xffff {
+		return types.Instruction{}, errors.New("message is too long")
+	}
+
+	data := make([]byte, 0, messageDataOffset+len(message))
+	data = append(data, 1)
+	data = append(data, SignatureOffsets{
+		SignatureOffset:            uint16(signatureOffset),
+		SignatureInstructionIndex:  instructionIndex,
+		EthAddressOffset:           uint16(ethAddressOffset),
+		EthAddressInstructionIndex: instructionIndex,
+		MessageDataOffset:          uint16(messageDataOffset),
+		MessageDataSize:            uint16(len(message)),
+		MessageInstructionIndex:    instructionIndex,
+	}.Serialize()...)
+	data = append(data, ethAddress...)
+	data = append(data, signature[:SignatureSize]...)
+	data = append(data, recoveryID)
+	data = append(data, message...)
+
+	return types.Instruction{
+		ProgramID: common.Secp256k1ProgramID,
+		Accounts:  []types.AccountMeta{},
+		Data:      data,
+	}, nil
+}
+
+func (o SignatureOffsets) Serialize() []byte {
+	data, err := borsh.Marshal(o)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// keccak256 is the legacy keccak hash ethereum uses, it differs from sha3-256 in the padding only
+func keccak256(data []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return h.Sum(nil)
+}
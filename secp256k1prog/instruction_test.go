@@ -0,0 +1,103 @@
+package secp256k1prog
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestKeccak256(t *testing.T) {
+	tests := []struct {
+		data []byte
+		want string
+	}{
+		{data: []byte{}, want: "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{data: []byte("hello"), want: "1c8aff950685c2ed4bc3174f3472287b56d9517b9c948127319a09a7a36deac8"},
+		{data: bytes.Repeat([]byte{'a'}, 200), want: "96ea54061def936c4be90b518992fdc6f12f535068a256229aca54267b4d084d"},
+		{data: bytes.Repeat([]byte{'b'}, 136), want: "121b76d0b19f3c2c7632310b92c54cddd59d16a6b5aafe84696426f10e5733bf"},
+	}
+	for _, tt := range tests {
+		got := keccak256(tt.data)
+		if hex.EncodeToString(got[:]) != tt.want {
+			t.Errorf("keccak256(%d bytes) = %x, want %v", len(tt.data), got, tt.want)
+		}
+	}
+}
+
+func TestEthAddressFromPrivateKey(t *testing.T) {
+	got, err := EthAddressFromPrivateKey(mustDecodeHex("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"))
+	if err != nil {
+		t.Fatalf("EthAddressFromPrivateKey() error = %v", err)
+	}
+	if want := "2c7536e3605d9c16a7a3d7b1898e529396a65c23"; hex.EncodeToString(got) != want {
+		t.Errorf("EthAddressFromPrivateKey() = %x, want %v", got, want)
+	}
+	if _, err := EthAddressFromPrivateKey([]byte{1}); err == nil {
+		t.Errorf("EthAddressFromPrivateKey() with a short key error = nil")
+	}
+}
+
+func TestNewSecp256k1InstructionWithPrivateKey(t *testing.T) {
+	privateKey := mustDecodeHex("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	message := []byte("hello world")
+
+	ins, err := NewSecp256k1InstructionWithPrivateKey(privateKey, message, 1)
+	if err != nil {
+		t.Fatalf("NewSecp256k1InstructionWithPrivateKey() error = %v", err)
+	}
+	if ins.ProgramID != common.Secp256k1ProgramID || len(ins.Accounts) != 0 {
+		t.Fatalf("NewSecp256k1InstructionWithPrivateKey() = %v", ins)
+	}
+	wantHeader := []byte{1, 32, 0, 1, 12, 0, 1, 97, 0, 11, 0, 1}
+	if !bytes.Equal(ins.Data[:12], wantHeader) {
+		t.Errorf("NewSecp256k1InstructionWithPrivateKey() header = %v, want %v", ins.Data[:12], wantHeader)
+	}
+	if len(ins.Data) != 97+len(message) {
+		t.Fatalf("NewSecp256k1InstructionWithPrivateKey() data size = %v, want %v", len(ins.Data), 97+len(message))
+	}
+
+	// the program recovers the address from the signature and compares it
+	signature := ins.Data[32:97]
+	hash := keccak256(message)
+	compact := append([]byte{27 + signature[64]}, signature[:64]...)
+	publicKey, _, err := ecdsa.RecoverCompact(compact, hash[:])
+	if err != nil {
+		t.Fatalf("RecoverCompact() error = %v", err)
+	}
+	ethAddress, _ := EthAddressFromPublicKey(publicKey.SerializeUncompressed())
+	if !bytes.Equal(ethAddress, ins.Data[12:32]) {
+		t.Errorf("recovered address = %x, want %x", ethAddress, ins.Data[12:32])
+	}
+}
+
+func TestNewSecp256k1Instruction(t *testing.T) {
+	ethAddress := mustDecodeHex("2c7536e3605d9c16a7a3d7b1898e529396a65c23")
+	signature := append(bytes.Repeat([]byte{7}, 64), 28)
+
+	ins, err := NewSecp256k1Instruction(ethAddress, signature, []byte{1, 2}, 0)
+	if err != nil {
+		t.Fatalf("NewSecp256k1Instruction() error = %v", err)
+	}
+	// ethereum's v of 28 is the recovery id 1
+	if got := ins.Data[96]; got != 1 {
+		t.Errorf("NewSecp256k1Instruction() recovery id = %v, want 1", got)
+	}
+
+	if _, err := NewSecp256k1Instruction(ethAddress[:19], signature, nil, 0); err == nil {
+		t.Errorf("NewSecp256k1Instruction() with a short address error = nil")
+	}
+	if _, err := NewSecp256k1Instruction(ethAddress, signature[:64], nil, 0); err == nil {
+		t.Errorf("NewSecp256k1Instruction() without recovery id error = nil")
+	}
+}
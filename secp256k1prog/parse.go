@@ -0,0 +1,117 @@
+package secp256k1prog
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func init() {
+	types.RegisterInstructionParser(common.Secp256k1ProgramID, ParseSecp256k1)
+}
+
+// VerifiedSignature is a signature the program verifies
+type VerifiedSignature struct {
+	EthAddress []byte
+	// Signature is r || s || recovery id
+	Signature []byte
+	Message   []byte
+}
+
+// ParseSignatureOffsets decodes the offsets of all signatures in the instruction data
+func ParseSignatureOffsets(data []byte) ([]SignatureOffsets, error) {
+	if len(data) < SignatureOffsetsStart {
+		return nil, errors.New("secp256k1 instruction data is empty")
+	}
+	n := int(data[0])
+	if len(data) < SignatureOffsetsStart+n*SignatureOffsetsSize {
+		return nil, fmt.Errorf("secp256k1 instruction data size is not enough for %d signatures", n)
+	}
+	offsets := make([]SignatureOffsets, 0, n)
+	for i := 0; i < n; i++ {
+		var o SignatureOffsets
+		if err := borsh.Unmarshal(data[SignatureOffsetsStart+i*SignatureOffsetsSize:], &o); err != nil {
+			return nil, err
+		}
+		offsets = append(offsets, o)
+	}
+	return offsets, nil
+}
+
+// Resolve reads the signature, the address and the message from the data of the instructions of the transaction
+func (o SignatureOffsets) Resolve(instructionsData [][]byte) (VerifiedSignature, error) {
+	signature, err := slice(instructionsData, o.SignatureInstructionIndex, o.SignatureOffset, SignatureWithRecoveryIDSize)
+	if err != nil {
+		return VerifiedSignature{}, fmt.Errorf("invalid signature offsets: %w", err)
+	}
+	ethAddress, err := slice(instructionsData, o.EthAddressInstructionIndex, o.EthAddressOffset, EthAddressSize)
+	if err != nil {
+		return VerifiedSignature{}, fmt.Errorf("invalid eth address offsets: %w", err)
+	}
+	message, err := slice(instructionsData, o.MessageInstructionIndex, o.MessageDataOffset, int(o.MessageDataSize))
+	if err != nil {
+		return VerifiedSignature{}, fmt.Errorf("invalid message offsets: %w", err)
+	}
+	return VerifiedSignature{
+		EthAddress: ethAddress,
+		Signature:  signature,
+		Message:    message,
+	}, nil
+}
+
+func slice(instructionsData [][]byte, index uint8, offset uint16, size int) ([]byte, error) {
+	if int(index) >= len(instructionsData) {
+		return nil, fmt.Errorf("instruction index %d is out of range", index)
+	}
+	data := instructionsData[index]
+	if int(offset)+size > len(data) {
+		return nil, fmt.Errorf("offset %d and size %d are out of the data", offset, size)
+	}
+	return data[int(offset) : int(offset)+size], nil
+}
+
+// ParseSecp256k1 decodes the offsets of the signatures. The parser doesn't know the index of the instruction in
+// the transaction, so the signature, the address and the message are read from the instruction itself only if all
+// offsets point to the same instruction, as NewSecp256k1Instruction builds them.
+func ParseSecp256k1(ins types.Instruction) (types.ParsedInstruction, error) {
+	var parsedInstruction types.ParsedInstruction
+	offsets, err := ParseSignatureOffsets(ins.Data)
+	if err != nil {
+		return parsedInstruction, err
+	}
+
+	signatures := make([]map[string]interface{}, 0, len(offsets))
+	for _, o := range offsets {
+		info := map[string]interface{}{
+			"signatureOffset":            o.SignatureOffset,
+			"signatureInstructionIndex":  o.SignatureInstructionIndex,
+			"ethAddressOffset":           o.EthAddressOffset,
+			"ethAddressInstructionIndex": o.EthAddressInstructionIndex,
+			"messageDataOffset":          o.MessageDataOffset,
+			"messageDataSize":            o.MessageDataSize,
+			"messageInstructionIndex":    o.MessageInstructionIndex,
+		}
+		if o.SignatureInstructionIndex == o.EthAddressInstructionIndex && o.SignatureInstructionIndex == o.MessageInstructionIndex {
+			data := make([][]byte, int(o.SignatureInstructionIndex)+1)
+			data[o.SignatureInstructionIndex] = ins.Data
+			if signature, err := o.Resolve(data); err == nil {
+				info["ethAddress"] = "0x" + hex.EncodeToString(signature.EthAddress)
+				info["signature"] = hex.EncodeToString(signature.Signature)
+				info["message"] = hex.EncodeToString(signature.Message)
+			}
+		}
+		signatures = append(signatures, info)
+	}
+
+	parsedInstruction.Parsed = &types.InstructionInfo{
+		Info: map[string]interface{}{
+			"signatures": signatures,
+		},
+		InstructionType: "verify",
+	}
+	return parsedInstruction, nil
+}
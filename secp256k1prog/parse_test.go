@@ -0,0 +1,66 @@
+package secp256k1prog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSecp256k1(t *testing.T) {
+	ethAddress := mustDecodeHex("2c7536e3605d9c16a7a3d7b1898e529396a65c23")
+	signature := make([]byte, 65)
+	signature[0], signature[64] = 9, 1
+	ins, err := NewSecp256k1Instruction(ethAddress, signature, []byte("hi"), 2)
+	if err != nil {
+		t.Fatalf("NewSecp256k1Instruction() error = %v", err)
+	}
+
+	got, err := ParseSecp256k1(ins)
+	if err != nil {
+		t.Fatalf("ParseSecp256k1() error = %v", err)
+	}
+	want := map[string]interface{}{
+		"signatures": []map[string]interface{}{
+			{
+				"signatureOffset":            uint16(32),
+				"signatureInstructionIndex":  uint8(2),
+				"ethAddressOffset":           uint16(12),
+				"ethAddressInstructionIndex": uint8(2),
+				"messageDataOffset":          uint16(97),
+				"messageDataSize":            uint16(2),
+				"messageInstructionIndex":    uint8(2),
+				"ethAddress":                 "0x2c7536e3605d9c16a7a3d7b1898e529396a65c23",
+				"signature":                  "09000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000" + "01",
+				"message":                    "6869",
+			},
+		},
+	}
+	if got.Parsed.InstructionType != "verify" || !reflect.DeepEqual(got.Parsed.Info, want) {
+		t.Errorf("ParseSecp256k1() = %v, want %v", got.Parsed.Info, want)
+	}
+
+	if _, err := ParseSignatureOffsets([]byte{2, 0}); err == nil {
+		t.Errorf("ParseSignatureOffsets() with missing offsets error = nil")
+	}
+}
+
+func TestSignatureOffsetsResolve(t *testing.T) {
+	ethAddress := mustDecodeHex("2c7536e3605d9c16a7a3d7b1898e529396a65c23")
+	signature := make([]byte, 65)
+	ins, _ := NewSecp256k1Instruction(ethAddress, signature, []byte("hi"), 1)
+	offsets, err := ParseSignatureOffsets(ins.Data)
+	if err != nil {
+		t.Fatalf("ParseSignatureOffsets() error = %v", err)
+	}
+
+	got, err := offsets[0].Resolve([][]byte{{}, ins.Data})
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	want := VerifiedSignature{EthAddress: ethAddress, Signature: signature, Message: []byte("hi")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Resolve() = %v, want %v", got, want)
+	}
+	if _, err := offsets[0].Resolve([][]byte{ins.Data}); err == nil {
+		t.Errorf("Resolve() with the instruction missing error = nil")
+	}
+}
@@ -1,6 +1,7 @@
 package stakeprog
 
 import (
+	"github.com/portto/solana-go-sdk/borsh"
 	"github.com/portto/solana-go-sdk/common"
 	"github.com/portto/solana-go-sdk/types"
 )
@@ -19,6 +20,12 @@ const (
 	InstructionSetLockup
 	InstructionMerge
 	InstructionAuthorizeWithSeed
+	InstructionInitializeChecked
+	InstructionAuthorizeChecked
+	InstructionAuthorizeCheckedWithSeed
+	InstructionSetLockupChecked
+	InstructionGetMinimumDelegation
+	InstructionDeactivateDelinquent
 )
 
 type StakeAuthorizationType uint32
@@ -34,13 +41,20 @@ type Lockup struct {
 	Cusodian      common.PublicKey
 }
 
+// LockupParam changes the fields which are not nil
+type LockupParam struct {
+	UnixTimestamp *int64
+	Epoch         *uint64
+	Custodian     *common.PublicKey
+}
+
 type Authorized struct {
 	Staker     common.PublicKey
 	Withdrawer common.PublicKey
 }
 
 func Initialize(initAccount common.PublicKey, auth Authorized, lockup Lockup) types.Instruction {
-	data, err := common.SerializeData(struct {
+	data, err := borsh.Marshal(struct {
 		Instruction Instruction
 		Auth        Authorized
 		Lockup      Lockup
@@ -64,7 +78,7 @@ func Initialize(initAccount common.PublicKey, auth Authorized, lockup Lockup) ty
 }
 
 func Authorize(stakePubkey, authPubkey, newAuthPubkey common.PublicKey, authType StakeAuthorizationType, custodianPubkey common.PublicKey) types.Instruction {
-	data, err := common.SerializeData(struct {
+	data, err := borsh.Marshal(struct {
 		Instruction            Instruction
 		NewAuthorized          common.PublicKey
 		StakeAuthorizationType StakeAuthorizationType
@@ -95,7 +109,7 @@ func Authorize(stakePubkey, authPubkey, newAuthPubkey common.PublicKey, authType
 }
 
 func DelegateStake(stakePubkey, authPubkey, votePubkey common.PublicKey) types.Instruction {
-	data, err := common.SerializeData(struct {
+	data, err := borsh.Marshal(struct {
 		Instruction Instruction
 	}{
 		Instruction: InstructionDelegateStake,
@@ -119,7 +133,7 @@ func DelegateStake(stakePubkey, authPubkey, votePubkey common.PublicKey) types.I
 }
 
 func Split(stakePubkey, authPubkey, splitStakePubkey common.PublicKey, lamports uint64) types.Instruction {
-	data, err := common.SerializeData(struct {
+	data, err := borsh.Marshal(struct {
 		Instruction Instruction
 		Lamports    uint64
 	}{
@@ -142,7 +156,7 @@ func Split(stakePubkey, authPubkey, splitStakePubkey common.PublicKey, lamports
 }
 
 func Withdraw(stakePubkey, authPubkey, toPubkey common.PublicKey, lamports uint64, custodianPubkey common.PublicKey) types.Instruction {
-	data, err := common.SerializeData(struct {
+	data, err := borsh.Marshal(struct {
 		Instruction Instruction
 		Lamports    uint64
 	}{
@@ -173,7 +187,7 @@ func Withdraw(stakePubkey, authPubkey, toPubkey common.PublicKey, lamports uint6
 }
 
 func Deactivate(stakePubkey, authPubkey common.PublicKey) types.Instruction {
-	data, err := common.SerializeData(struct {
+	data, err := borsh.Marshal(struct {
 		Instruction Instruction
 	}{
 		Instruction: InstructionDeactivate,
@@ -193,12 +207,31 @@ func Deactivate(stakePubkey, authPubkey common.PublicKey) types.Instruction {
 	}
 }
 
-func SetLockup() types.Instruction {
-	panic("not implement yet")
+// SetLockup changes the lockup, auth is the lockup custodian while the lockup is in force, otherwise the withdrawer
+func SetLockup(stakePubkey, authPubkey common.PublicKey, lockup LockupParam) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction Instruction
+		Lockup      LockupParam
+	}{
+		Instruction: InstructionSetLockup,
+		Lockup:      lockup,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: stakePubkey, IsSigner: false, IsWritable: true},
+			{PubKey: authPubkey, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
 }
 
 func Merge(dest, src, auth common.PublicKey) types.Instruction {
-	data, err := common.SerializeData(struct {
+	data, err := borsh.Marshal(struct {
 		Instruction Instruction
 	}{
 		Instruction: InstructionMerge,
@@ -229,18 +262,16 @@ func AuthorizeWithSeed(
 	authType StakeAuthorizationType,
 	custodianPubkey common.PublicKey) types.Instruction {
 
-	data, err := common.SerializeData(struct {
+	data, err := borsh.Marshal(struct {
 		Instruction            Instruction
 		NewAuthorized          common.PublicKey
 		StakeAuthorizationType StakeAuthorizationType
-		AuthSeedLen            uint64
-		AuthSeed               string
+		AuthSeed               string `borsh:"u64len"`
 		AuthOwner              common.PublicKey
 	}{
 		Instruction:            InstructionAuthorizeWithSeed,
 		NewAuthorized:          newAuthPubkey,
 		StakeAuthorizationType: authType,
-		AuthSeedLen:            uint64(len(authSeed)),
 		AuthSeed:               authSeed,
 		AuthOwner:              authOwnerPubkey,
 	})
@@ -264,3 +295,172 @@ func AuthorizeWithSeed(
 		Data:      data,
 	}
 }
+
+// InitializeChecked is Initialize without lockup, the withdrawer has to sign
+func InitializeChecked(initAccount, stakerPubkey, withdrawerPubkey common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionInitializeChecked,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: initAccount, IsSigner: false, IsWritable: true},
+			{PubKey: common.SysVarRentPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: stakerPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: withdrawerPubkey, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+// AuthorizeChecked is Authorize but the new authority has to sign
+func AuthorizeChecked(stakePubkey, authPubkey, newAuthPubkey common.PublicKey, authType StakeAuthorizationType, custodianPubkey common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction            Instruction
+		StakeAuthorizationType StakeAuthorizationType
+	}{
+		Instruction:            InstructionAuthorizeChecked,
+		StakeAuthorizationType: authType,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 5)
+	accounts = append(accounts,
+		types.AccountMeta{PubKey: stakePubkey, IsSigner: false, IsWritable: true},
+		types.AccountMeta{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+		types.AccountMeta{PubKey: authPubkey, IsSigner: true, IsWritable: false},
+		types.AccountMeta{PubKey: newAuthPubkey, IsSigner: true, IsWritable: false},
+	)
+	if custodianPubkey != (common.PublicKey{}) {
+		accounts = append(accounts, types.AccountMeta{PubKey: custodianPubkey, IsSigner: true, IsWritable: false})
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// AuthorizeCheckedWithSeed is AuthorizeWithSeed but the new authority has to sign
+func AuthorizeCheckedWithSeed(
+	stakePubkey common.PublicKey,
+	authBasePubkey common.PublicKey,
+	authSeed string,
+	authOwnerPubkey common.PublicKey,
+	newAuthPubkey common.PublicKey,
+	authType StakeAuthorizationType,
+	custodianPubkey common.PublicKey) types.Instruction {
+
+	data, err := borsh.Marshal(struct {
+		Instruction            Instruction
+		StakeAuthorizationType StakeAuthorizationType
+		AuthSeed               string `borsh:"u64len"`
+		AuthOwner              common.PublicKey
+	}{
+		Instruction:            InstructionAuthorizeCheckedWithSeed,
+		StakeAuthorizationType: authType,
+		AuthSeed:               authSeed,
+		AuthOwner:              authOwnerPubkey,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 5)
+	accounts = append(accounts,
+		types.AccountMeta{PubKey: stakePubkey, IsSigner: false, IsWritable: true},
+		types.AccountMeta{PubKey: authBasePubkey, IsSigner: true, IsWritable: false},
+		types.AccountMeta{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+		types.AccountMeta{PubKey: newAuthPubkey, IsSigner: true, IsWritable: false},
+	)
+	if custodianPubkey != (common.PublicKey{}) {
+		accounts = append(accounts, types.AccountMeta{PubKey: custodianPubkey, IsSigner: true, IsWritable: false})
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// SetLockupChecked is SetLockup but a new custodian has to sign, lockup.Custodian is ignored
+func SetLockupChecked(stakePubkey, authPubkey common.PublicKey, lockup LockupParam, newCustodianPubkey common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction   Instruction
+		UnixTimestamp *int64
+		Epoch         *uint64
+	}{
+		Instruction:   InstructionSetLockupChecked,
+		UnixTimestamp: lockup.UnixTimestamp,
+		Epoch:         lockup.Epoch,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 3)
+	accounts = append(accounts,
+		types.AccountMeta{PubKey: stakePubkey, IsSigner: false, IsWritable: true},
+		types.AccountMeta{PubKey: authPubkey, IsSigner: true, IsWritable: false},
+	)
+	if newCustodianPubkey != (common.PublicKey{}) {
+		accounts = append(accounts, types.AccountMeta{PubKey: newCustodianPubkey, IsSigner: true, IsWritable: false})
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// DeactivateDelinquent deactivates a stake delegated to a vote account which has been delinquent
+// for the last epochs, referenceVotePubkey has to be a vote account which voted in all of them
+func DeactivateDelinquent(stakePubkey, delinquentVotePubkey, referenceVotePubkey common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionDeactivateDelinquent,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: stakePubkey, IsSigner: false, IsWritable: true},
+			{PubKey: delinquentVotePubkey, IsSigner: false, IsWritable: false},
+			{PubKey: referenceVotePubkey, IsSigner: false, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+// GetMinimumDelegation returns the minimum delegation as the return data of the transaction
+func GetMinimumDelegation() types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionGetMinimumDelegation,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.StakeProgramID,
+		Accounts:  []types.AccountMeta{},
+		Data:      data,
+	}
+}
@@ -0,0 +1,306 @@
+package stakeprog
+
+import (
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// instructionMinAccounts is the number of accounts an instruction needs to be parsed
+var instructionMinAccounts = map[Instruction]int{
+	InstructionInitialize:               2,
+	InstructionAuthorize:                3,
+	InstructionDelegateStake:            6,
+	InstructionSplit:                    3,
+	InstructionWithdraw:                 5,
+	InstructionDeactivate:               3,
+	InstructionSetLockup:                2,
+	InstructionMerge:                    5,
+	InstructionAuthorizeWithSeed:        2,
+	InstructionInitializeChecked:        4,
+	InstructionAuthorizeChecked:         4,
+	InstructionAuthorizeCheckedWithSeed: 4,
+	InstructionSetLockupChecked:         2,
+	InstructionGetMinimumDelegation:     0,
+	InstructionDeactivateDelinquent:     3,
+}
+
+func (t StakeAuthorizationType) String() string {
+	switch t {
+	case StakeAuthorizationTypeStaker:
+		return "Staker"
+	case StakeAuthorizationTypeWithdrawer:
+		return "Withdrawer"
+	}
+	return fmt.Sprintf("StakeAuthorizationType(%d)", uint32(t))
+}
+
+func init() {
+	types.RegisterInstructionParser(common.StakeProgramID, ParseStake)
+}
+
+func ParseStake(ins types.Instruction) (types.ParsedInstruction, error) {
+	var parsedInstruction types.ParsedInstruction
+	var err error
+	var s struct {
+		Instruction Instruction
+	}
+	err = borsh.Unmarshal(ins.Data, &s)
+	if err != nil {
+		return parsedInstruction, err
+	}
+	minAccounts, exist := instructionMinAccounts[s.Instruction]
+	if !exist {
+		return parsedInstruction, fmt.Errorf("unknown stake instruction %d", s.Instruction)
+	}
+	if len(ins.Accounts) < minAccounts {
+		return parsedInstruction, fmt.Errorf("stake instruction %d needs %d accounts, got %d", s.Instruction, minAccounts, len(ins.Accounts))
+	}
+
+	var instructionType string
+	var parsedInfo map[string]interface{}
+	switch s.Instruction {
+	case InstructionInitialize:
+		var a struct {
+			Instruction Instruction
+			Auth        Authorized
+			Lockup      Lockup
+		}
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "initialize"
+		parsedInfo = map[string]interface{}{
+			"stakeAccount": ins.Accounts[0].PubKey.ToBase58(),
+			"rentSysvar":   ins.Accounts[1].PubKey.ToBase58(),
+			"authorized": map[string]interface{}{
+				"staker":     a.Auth.Staker.ToBase58(),
+				"withdrawer": a.Auth.Withdrawer.ToBase58(),
+			},
+			"lockup": map[string]interface{}{
+				"unixTimestamp": a.Lockup.UnixTimestamp,
+				"epoch":         a.Lockup.Epoch,
+				"custodian":     a.Lockup.Cusodian.ToBase58(),
+			},
+		}
+		break
+	case InstructionAuthorize:
+		var a struct {
+			Instruction            Instruction
+			NewAuthorized          common.PublicKey
+			StakeAuthorizationType StakeAuthorizationType
+		}
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "authorize"
+		parsedInfo = map[string]interface{}{
+			"stakeAccount":  ins.Accounts[0].PubKey.ToBase58(),
+			"clockSysvar":   ins.Accounts[1].PubKey.ToBase58(),
+			"authority":     ins.Accounts[2].PubKey.ToBase58(),
+			"newAuthority":  a.NewAuthorized.ToBase58(),
+			"authorityType": a.StakeAuthorizationType.String(),
+		}
+		parsedInfo = parseCustodian(parsedInfo, 3, ins.Accounts)
+		break
+	case InstructionDelegateStake:
+		instructionType = "delegate"
+		parsedInfo = map[string]interface{}{
+			"stakeAccount":       ins.Accounts[0].PubKey.ToBase58(),
+			"voteAccount":        ins.Accounts[1].PubKey.ToBase58(),
+			"clockSysvar":        ins.Accounts[2].PubKey.ToBase58(),
+			"stakeHistorySysvar": ins.Accounts[3].PubKey.ToBase58(),
+			"stakeConfigAccount": ins.Accounts[4].PubKey.ToBase58(),
+			"stakeAuthority":     ins.Accounts[5].PubKey.ToBase58(),
+		}
+		break
+	case InstructionSplit:
+		var a struct {
+			Instruction Instruction
+			Lamports    uint64
+		}
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "split"
+		parsedInfo = map[string]interface{}{
+			"stakeAccount":    ins.Accounts[0].PubKey.ToBase58(),
+			"newSplitAccount": ins.Accounts[1].PubKey.ToBase58(),
+			"stakeAuthority":  ins.Accounts[2].PubKey.ToBase58(),
+			"lamports":        a.Lamports,
+		}
+		break
+	case InstructionWithdraw:
+		var a struct {
+			Instruction Instruction
+			Lamports    uint64
+		}
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "withdraw"
+		parsedInfo = map[string]interface{}{
+			"stakeAccount":       ins.Accounts[0].PubKey.ToBase58(),
+			"destination":        ins.Accounts[1].PubKey.ToBase58(),
+			"clockSysvar":        ins.Accounts[2].PubKey.ToBase58(),
+			"stakeHistorySysvar": ins.Accounts[3].PubKey.ToBase58(),
+			"withdrawAuthority":  ins.Accounts[4].PubKey.ToBase58(),
+			"lamports":           a.Lamports,
+		}
+		parsedInfo = parseCustodian(parsedInfo, 5, ins.Accounts)
+		break
+	case InstructionDeactivate:
+		instructionType = "deactivate"
+		parsedInfo = map[string]interface{}{
+			"stakeAccount":   ins.Accounts[0].PubKey.ToBase58(),
+			"clockSysvar":    ins.Accounts[1].PubKey.ToBase58(),
+			"stakeAuthority": ins.Accounts[2].PubKey.ToBase58(),
+		}
+		break
+	case InstructionSetLockup:
+		var a struct {
+			Instruction Instruction
+			Lockup      LockupParam
+		}
+		err = borsh.Unmarshal(ins.Data, &a)
+		lockup := a.Lockup
+		instructionType = "setLockup"
+		parsedInfo = map[string]interface{}{
+			"stakeAccount": ins.Accounts[0].PubKey.ToBase58(),
+			"custodian":    ins.Accounts[1].PubKey.ToBase58(),
+			"lockup":       lockup.parsedInfo(),
+		}
+		break
+	case InstructionMerge:
+		instructionType = "merge"
+		parsedInfo = map[string]interface{}{
+			"destination":        ins.Accounts[0].PubKey.ToBase58(),
+			"source":             ins.Accounts[1].PubKey.ToBase58(),
+			"clockSysvar":        ins.Accounts[2].PubKey.ToBase58(),
+			"stakeHistorySysvar": ins.Accounts[3].PubKey.ToBase58(),
+			"stakeAuthority":     ins.Accounts[4].PubKey.ToBase58(),
+		}
+		break
+	case InstructionAuthorizeWithSeed:
+		var a struct {
+			Instruction            Instruction
+			NewAuthorized          common.PublicKey
+			StakeAuthorizationType StakeAuthorizationType
+			AuthSeed               string `borsh:"u64len"`
+			AuthOwner              common.PublicKey
+		}
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "authorizeWithSeed"
+		parsedInfo = map[string]interface{}{
+			"stakeAccount":   ins.Accounts[0].PubKey.ToBase58(),
+			"authorityBase":  ins.Accounts[1].PubKey.ToBase58(),
+			"newAuthorized":  a.NewAuthorized.ToBase58(),
+			"authorityType":  a.StakeAuthorizationType.String(),
+			"authoritySeed":  a.AuthSeed,
+			"authorityOwner": a.AuthOwner.ToBase58(),
+		}
+		if len(ins.Accounts) >= 3 {
+			parsedInfo["clockSysvar"] = ins.Accounts[2].PubKey.ToBase58()
+		}
+		parsedInfo = parseCustodian(parsedInfo, 3, ins.Accounts)
+		break
+	case InstructionInitializeChecked:
+		instructionType = "initializeChecked"
+		parsedInfo = map[string]interface{}{
+			"stakeAccount": ins.Accounts[0].PubKey.ToBase58(),
+			"rentSysvar":   ins.Accounts[1].PubKey.ToBase58(),
+			"staker":       ins.Accounts[2].PubKey.ToBase58(),
+			"withdrawer":   ins.Accounts[3].PubKey.ToBase58(),
+		}
+		break
+	case InstructionAuthorizeChecked:
+		var a struct {
+			Instruction            Instruction
+			StakeAuthorizationType StakeAuthorizationType
+		}
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "authorizeChecked"
+		parsedInfo = map[string]interface{}{
+			"stakeAccount":  ins.Accounts[0].PubKey.ToBase58(),
+			"clockSysvar":   ins.Accounts[1].PubKey.ToBase58(),
+			"authority":     ins.Accounts[2].PubKey.ToBase58(),
+			"newAuthority":  ins.Accounts[3].PubKey.ToBase58(),
+			"authorityType": a.StakeAuthorizationType.String(),
+		}
+		parsedInfo = parseCustodian(parsedInfo, 4, ins.Accounts)
+		break
+	case InstructionAuthorizeCheckedWithSeed:
+		var a struct {
+			Instruction            Instruction
+			StakeAuthorizationType StakeAuthorizationType
+			AuthSeed               string `borsh:"u64len"`
+			AuthOwner              common.PublicKey
+		}
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "authorizeCheckedWithSeed"
+		parsedInfo = map[string]interface{}{
+			"stakeAccount":   ins.Accounts[0].PubKey.ToBase58(),
+			"authorityBase":  ins.Accounts[1].PubKey.ToBase58(),
+			"clockSysvar":    ins.Accounts[2].PubKey.ToBase58(),
+			"newAuthorized":  ins.Accounts[3].PubKey.ToBase58(),
+			"authorityType":  a.StakeAuthorizationType.String(),
+			"authoritySeed":  a.AuthSeed,
+			"authorityOwner": a.AuthOwner.ToBase58(),
+		}
+		parsedInfo = parseCustodian(parsedInfo, 4, ins.Accounts)
+		break
+	case InstructionSetLockupChecked:
+		var a struct {
+			Instruction   Instruction
+			UnixTimestamp *int64
+			Epoch         *uint64
+		}
+		err = borsh.Unmarshal(ins.Data, &a)
+		lockup := LockupParam{UnixTimestamp: a.UnixTimestamp, Epoch: a.Epoch}
+		if len(ins.Accounts) >= 3 {
+			custodian := ins.Accounts[2].PubKey
+			lockup.Custodian = &custodian
+		}
+		instructionType = "setLockupChecked"
+		parsedInfo = map[string]interface{}{
+			"stakeAccount": ins.Accounts[0].PubKey.ToBase58(),
+			"custodian":    ins.Accounts[1].PubKey.ToBase58(),
+			"lockup":       lockup.parsedInfo(),
+		}
+		break
+	case InstructionGetMinimumDelegation:
+		instructionType = "getMinimumDelegation"
+		parsedInfo = map[string]interface{}{}
+		break
+	case InstructionDeactivateDelinquent:
+		instructionType = "deactivateDelinquent"
+		parsedInfo = map[string]interface{}{
+			"stakeAccount":         ins.Accounts[0].PubKey.ToBase58(),
+			"voteAccount":          ins.Accounts[1].PubKey.ToBase58(),
+			"referenceVoteAccount": ins.Accounts[2].PubKey.ToBase58(),
+		}
+		break
+	}
+	parsedInstruction.Parsed = &types.InstructionInfo{
+		Info:            parsedInfo,
+		InstructionType: instructionType,
+	}
+	return parsedInstruction, err
+}
+
+// parseCustodian adds the optional custodian account
+func parseCustodian(info map[string]interface{}, idx int, accounts []types.AccountMeta) map[string]interface{} {
+	if len(accounts) > idx {
+		info["custodian"] = accounts[idx].PubKey.ToBase58()
+	}
+	return info
+}
+
+// parsedInfo only contains the fields which are set, like the rpc node
+func (p LockupParam) parsedInfo() map[string]interface{} {
+	info := map[string]interface{}{}
+	if p.UnixTimestamp != nil {
+		info["unixTimestamp"] = *p.UnixTimestamp
+	}
+	if p.Epoch != nil {
+		info["epoch"] = *p.Epoch
+	}
+	if p.Custodian != nil {
+		info["custodian"] = p.Custodian.ToBase58()
+	}
+	return info
+}
@@ -0,0 +1,220 @@
+package stakeprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func TestParseStake(t *testing.T) {
+	stake := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	auth := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	other := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	custodian := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+	unixTimestamp := int64(-1)
+	epoch := uint64(3)
+
+	tests := []struct {
+		name     string
+		ins      types.Instruction
+		wantType string
+		wantInfo map[string]interface{}
+	}{
+		{
+			ins:      Initialize(stake, Authorized{Staker: auth, Withdrawer: other}, Lockup{UnixTimestamp: 1, Epoch: 2, Cusodian: custodian}),
+			wantType: "initialize",
+			wantInfo: map[string]interface{}{
+				"stakeAccount": stake.ToBase58(),
+				"rentSysvar":   common.SysVarRentPubkey.ToBase58(),
+				"authorized": map[string]interface{}{
+					"staker":     auth.ToBase58(),
+					"withdrawer": other.ToBase58(),
+				},
+				"lockup": map[string]interface{}{
+					"unixTimestamp": int64(1),
+					"epoch":         uint64(2),
+					"custodian":     custodian.ToBase58(),
+				},
+			},
+		},
+		{
+			ins:      Authorize(stake, auth, other, StakeAuthorizationTypeWithdrawer, custodian),
+			wantType: "authorize",
+			wantInfo: map[string]interface{}{
+				"stakeAccount":  stake.ToBase58(),
+				"clockSysvar":   common.SysVarClockPubkey.ToBase58(),
+				"authority":     auth.ToBase58(),
+				"newAuthority":  other.ToBase58(),
+				"authorityType": "Withdrawer",
+				"custodian":     custodian.ToBase58(),
+			},
+		},
+		{
+			ins:      DelegateStake(stake, auth, other),
+			wantType: "delegate",
+			wantInfo: map[string]interface{}{
+				"stakeAccount":       stake.ToBase58(),
+				"voteAccount":        other.ToBase58(),
+				"clockSysvar":        common.SysVarClockPubkey.ToBase58(),
+				"stakeHistorySysvar": common.SysVarStakeHistoryPubkey.ToBase58(),
+				"stakeConfigAccount": common.StakeConfigPubkey.ToBase58(),
+				"stakeAuthority":     auth.ToBase58(),
+			},
+		},
+		{
+			ins:      Split(stake, auth, other, 100),
+			wantType: "split",
+			wantInfo: map[string]interface{}{
+				"stakeAccount":    stake.ToBase58(),
+				"newSplitAccount": other.ToBase58(),
+				"stakeAuthority":  auth.ToBase58(),
+				"lamports":        uint64(100),
+			},
+		},
+		{
+			ins:      Withdraw(stake, auth, other, 100, common.PublicKey{}),
+			wantType: "withdraw",
+			wantInfo: map[string]interface{}{
+				"stakeAccount":       stake.ToBase58(),
+				"destination":        other.ToBase58(),
+				"clockSysvar":        common.SysVarClockPubkey.ToBase58(),
+				"stakeHistorySysvar": common.SysVarStakeHistoryPubkey.ToBase58(),
+				"withdrawAuthority":  auth.ToBase58(),
+				"lamports":           uint64(100),
+			},
+		},
+		{
+			ins:      Deactivate(stake, auth),
+			wantType: "deactivate",
+			wantInfo: map[string]interface{}{
+				"stakeAccount":   stake.ToBase58(),
+				"clockSysvar":    common.SysVarClockPubkey.ToBase58(),
+				"stakeAuthority": auth.ToBase58(),
+			},
+		},
+		{
+			ins:      SetLockup(stake, auth, LockupParam{UnixTimestamp: &unixTimestamp, Custodian: &custodian}),
+			wantType: "setLockup",
+			wantInfo: map[string]interface{}{
+				"stakeAccount": stake.ToBase58(),
+				"custodian":    auth.ToBase58(),
+				"lockup": map[string]interface{}{
+					"unixTimestamp": int64(-1),
+					"custodian":     custodian.ToBase58(),
+				},
+			},
+		},
+		{
+			ins:      Merge(stake, other, auth),
+			wantType: "merge",
+			wantInfo: map[string]interface{}{
+				"destination":        stake.ToBase58(),
+				"source":             other.ToBase58(),
+				"clockSysvar":        common.SysVarClockPubkey.ToBase58(),
+				"stakeHistorySysvar": common.SysVarStakeHistoryPubkey.ToBase58(),
+				"stakeAuthority":     auth.ToBase58(),
+			},
+		},
+		{
+			ins:      AuthorizeWithSeed(stake, auth, "seed", common.SystemProgramID, other, StakeAuthorizationTypeStaker, common.PublicKey{}),
+			wantType: "authorizeWithSeed",
+			wantInfo: map[string]interface{}{
+				"stakeAccount":   stake.ToBase58(),
+				"authorityBase":  auth.ToBase58(),
+				"newAuthorized":  other.ToBase58(),
+				"authorityType":  "Staker",
+				"authoritySeed":  "seed",
+				"authorityOwner": common.SystemProgramID.ToBase58(),
+				"clockSysvar":    common.SysVarClockPubkey.ToBase58(),
+			},
+		},
+		{
+			ins:      InitializeChecked(stake, auth, other),
+			wantType: "initializeChecked",
+			wantInfo: map[string]interface{}{
+				"stakeAccount": stake.ToBase58(),
+				"rentSysvar":   common.SysVarRentPubkey.ToBase58(),
+				"staker":       auth.ToBase58(),
+				"withdrawer":   other.ToBase58(),
+			},
+		},
+		{
+			ins:      AuthorizeChecked(stake, auth, other, StakeAuthorizationTypeStaker, common.PublicKey{}),
+			wantType: "authorizeChecked",
+			wantInfo: map[string]interface{}{
+				"stakeAccount":  stake.ToBase58(),
+				"clockSysvar":   common.SysVarClockPubkey.ToBase58(),
+				"authority":     auth.ToBase58(),
+				"newAuthority":  other.ToBase58(),
+				"authorityType": "Staker",
+			},
+		},
+		{
+			ins:      AuthorizeCheckedWithSeed(stake, auth, "seed", common.SystemProgramID, other, StakeAuthorizationTypeWithdrawer, custodian),
+			wantType: "authorizeCheckedWithSeed",
+			wantInfo: map[string]interface{}{
+				"stakeAccount":   stake.ToBase58(),
+				"authorityBase":  auth.ToBase58(),
+				"clockSysvar":    common.SysVarClockPubkey.ToBase58(),
+				"newAuthorized":  other.ToBase58(),
+				"authorityType":  "Withdrawer",
+				"authoritySeed":  "seed",
+				"authorityOwner": common.SystemProgramID.ToBase58(),
+				"custodian":      custodian.ToBase58(),
+			},
+		},
+		{
+			ins:      SetLockupChecked(stake, auth, LockupParam{Epoch: &epoch}, custodian),
+			wantType: "setLockupChecked",
+			wantInfo: map[string]interface{}{
+				"stakeAccount": stake.ToBase58(),
+				"custodian":    auth.ToBase58(),
+				"lockup": map[string]interface{}{
+					"epoch":     uint64(3),
+					"custodian": custodian.ToBase58(),
+				},
+			},
+		},
+		{
+			ins:      GetMinimumDelegation(),
+			wantType: "getMinimumDelegation",
+			wantInfo: map[string]interface{}{},
+		},
+		{
+			ins:      DeactivateDelinquent(stake, other, auth),
+			wantType: "deactivateDelinquent",
+			wantInfo: map[string]interface{}{
+				"stakeAccount":         stake.ToBase58(),
+				"voteAccount":          other.ToBase58(),
+				"referenceVoteAccount": auth.ToBase58(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantType, func(t *testing.T) {
+			got, err := ParseStake(tt.ins)
+			if err != nil {
+				t.Fatalf("ParseStake() error = %v", err)
+			}
+			if got.Parsed.InstructionType != tt.wantType {
+				t.Errorf("ParseStake() type = %v, want %v", got.Parsed.InstructionType, tt.wantType)
+			}
+			if !reflect.DeepEqual(got.Parsed.Info, tt.wantInfo) {
+				t.Errorf("ParseStake() info = %v, want %v", got.Parsed.Info, tt.wantInfo)
+			}
+		})
+	}
+}
+
+func TestParseStakeError(t *testing.T) {
+	if _, err := ParseStake(types.Instruction{ProgramID: common.StakeProgramID, Data: []byte{99, 0, 0, 0}}); err == nil {
+		t.Errorf("ParseStake() should fail on unknown instruction")
+	}
+	ins := Split(common.StakeProgramID, common.StakeProgramID, common.StakeProgramID, 1)
+	ins.Accounts = ins.Accounts[:1]
+	if _, err := ParseStake(ins); err == nil {
+		t.Errorf("ParseStake() should fail on missing accounts")
+	}
+}
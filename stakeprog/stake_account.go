@@ -0,0 +1,93 @@
+package stakeprog
+
+import (
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+type StakeAccountType uint32
+
+const (
+	StakeAccountTypeUninitialized StakeAccountType = iota
+	StakeAccountTypeInitialized
+	StakeAccountTypeStake
+	StakeAccountTypeRewardsPool
+)
+
+type Meta struct {
+	RentExemptReserve uint64
+	Authorized        Authorized
+	Lockup            Lockup
+}
+
+type Delegation struct {
+	VoterPubkey common.PublicKey
+	Stake       uint64
+	// ActivationEpoch is math.MaxUint64 for a bootstrap stake
+	ActivationEpoch uint64
+	// DeactivationEpoch is math.MaxUint64 until the stake is deactivated
+	DeactivationEpoch  uint64
+	WarmupCooldownRate float64
+}
+
+type Stake struct {
+	Delegation      Delegation
+	CreditsObserved uint64
+}
+
+// StakeAccount is the state of a stake account, Meta is set from Initialized on and Stake only for Stake
+type StakeAccount struct {
+	Type       StakeAccountType
+	Meta       Meta
+	Stake      Stake
+	StakeFlags uint8
+}
+
+// stakeLayout is the data of a StakeAccountTypeStake account, the stake flags byte follows it
+type stakeLayout struct {
+	Meta  Meta
+	Stake Stake
+}
+
+func StakeAccountDeserialize(data []byte) (StakeAccount, error) {
+	var accountType StakeAccountType
+	n, err := borsh.UnmarshalPrefix(data, &accountType)
+	if err != nil {
+		return StakeAccount{}, fmt.Errorf("stake account data size is not enough")
+	}
+	data = data[n:]
+
+	switch accountType {
+	case StakeAccountTypeUninitialized, StakeAccountTypeRewardsPool:
+		return StakeAccount{Type: accountType}, nil
+	case StakeAccountTypeInitialized:
+		var meta Meta
+		if err := borsh.Unmarshal(data, &meta); err != nil {
+			return StakeAccount{}, fmt.Errorf("stake account data size is not enough")
+		}
+		return StakeAccount{
+			Type: accountType,
+			Meta: meta,
+		}, nil
+	case StakeAccountTypeStake:
+		var layout stakeLayout
+		n, err := borsh.UnmarshalPrefix(data, &layout)
+		if err != nil {
+			return StakeAccount{}, fmt.Errorf("stake account data size is not enough")
+		}
+		// accounts created before stake flags existed have no flags byte
+		var stakeFlags uint8
+		if len(data) > n {
+			stakeFlags = data[n]
+		}
+		return StakeAccount{
+			Type:       accountType,
+			Meta:       layout.Meta,
+			Stake:      layout.Stake,
+			StakeFlags: stakeFlags,
+		}, nil
+	}
+	return StakeAccount{}, fmt.Errorf("unknown stake account type %d", accountType)
+}
@@ -0,0 +1,113 @@
+package stakeprog
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+func TestStakeAccountDeserialize(t *testing.T) {
+	staker := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	withdrawer := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	voter := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	u64 := func(v uint64) []byte {
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, v)
+		return b
+	}
+
+	meta := u64(2282880)
+	meta = append(meta, staker.Bytes()...)
+	meta = append(meta, withdrawer.Bytes()...)
+	meta = append(meta, u64(0)...)
+	meta = append(meta, u64(0)...)
+	meta = append(meta, make([]byte, 32)...)
+
+	initialized := append([]byte{1, 0, 0, 0}, meta...)
+	initialized = append(initialized, make([]byte, int(AccountSize)-len(initialized))...)
+
+	stake := append([]byte{2, 0, 0, 0}, meta...)
+	stake = append(stake, voter.Bytes()...)
+	stake = append(stake, u64(1000000000)...)
+	stake = append(stake, u64(100)...)
+	stake = append(stake, u64(math.MaxUint64)...)
+	stake = append(stake, u64(math.Float64bits(0.25))...)
+	stake = append(stake, u64(12345)...)
+	stake = append(stake, 1)
+	stake = append(stake, make([]byte, int(AccountSize)-len(stake))...)
+
+	wantMeta := Meta{
+		RentExemptReserve: 2282880,
+		Authorized:        Authorized{Staker: staker, Withdrawer: withdrawer},
+	}
+
+	type args struct {
+		data []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    StakeAccount
+		wantErr bool
+	}{
+		{
+			name: "uninitialized",
+			args: args{data: make([]byte, AccountSize)},
+			want: StakeAccount{Type: StakeAccountTypeUninitialized},
+		},
+		{
+			name: "initialized",
+			args: args{data: initialized},
+			want: StakeAccount{Type: StakeAccountTypeInitialized, Meta: wantMeta},
+		},
+		{
+			name: "stake",
+			args: args{data: stake},
+			want: StakeAccount{
+				Type: StakeAccountTypeStake,
+				Meta: wantMeta,
+				Stake: Stake{
+					Delegation: Delegation{
+						VoterPubkey:        voter,
+						Stake:              1000000000,
+						ActivationEpoch:    100,
+						DeactivationEpoch:  math.MaxUint64,
+						WarmupCooldownRate: 0.25,
+					},
+					CreditsObserved: 12345,
+				},
+				StakeFlags: 1,
+			},
+		},
+		{
+			name: "rewards pool",
+			args: args{data: []byte{3, 0, 0, 0}},
+			want: StakeAccount{Type: StakeAccountTypeRewardsPool},
+		},
+		{
+			name:    "not enough data",
+			args:    args{data: stake[:100]},
+			wantErr: true,
+		},
+		{
+			name:    "unknown type",
+			args:    args{data: []byte{4, 0, 0, 0}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := StakeAccountDeserialize(tt.args.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StakeAccountDeserialize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StakeAccountDeserialize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
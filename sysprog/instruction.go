@@ -1,6 +1,7 @@
 package sysprog
 
 import (
+	"github.com/portto/solana-go-sdk/borsh"
 	"github.com/portto/solana-go-sdk/common"
 	"github.com/portto/solana-go-sdk/types"
 )
@@ -40,8 +41,7 @@ type TransferInstruction struct {
 type CreateAccountWithSeedInstruction struct {
 	Instruction Instruction
 	Base        common.PublicKey
-	SeedLen     uint64
-	Seed        string
+	Seed        string `borsh:"u64len"`
 	Lamports    uint64
 	Space       uint64
 	ProgramID   common.PublicKey
@@ -68,28 +68,25 @@ type AllocateInstruction struct {
 type AllocateWithSeedInstruction struct {
 	Instruction Instruction
 	Base        common.PublicKey
-	SeedLen     uint64
-	Seed        string
+	Seed        string `borsh:"u64len"`
 	Space       uint64
 	ProgramID   common.PublicKey
 }
 type AssignWithSeedInstruction struct {
 	Instruction       Instruction
 	Base              common.PublicKey
-	SeedLen           uint64
-	Seed              string
+	Seed              string `borsh:"u64len"`
 	AssignToProgramID common.PublicKey
 }
 type TransferWithSeedInstruction struct {
 	Instruction Instruction
 	Lamports    uint64
-	SeedLen     uint64
-	Seed        string
+	Seed        string `borsh:"u64len"`
 	ProgramID   common.PublicKey
 }
 
 func CreateAccount(fromAccount, newAccount, owner common.PublicKey, initLamports, accountSpace uint64) types.Instruction {
-	data, err := common.SerializeData(CreateAccountInstruction{
+	data, err := borsh.Marshal(CreateAccountInstruction{
 		Instruction: InstructionCreateAccount,
 		Lamports:    initLamports,
 		Space:       accountSpace,
@@ -110,7 +107,7 @@ func CreateAccount(fromAccount, newAccount, owner common.PublicKey, initLamports
 }
 
 func Assign(accountPubkey, assignToProgramID common.PublicKey) types.Instruction {
-	data, err := common.SerializeData(AssignInstruction{
+	data, err := borsh.Marshal(AssignInstruction{
 		Instruction:       InstructionAssign,
 		AssignToProgramID: assignToProgramID,
 	})
@@ -128,7 +125,7 @@ func Assign(accountPubkey, assignToProgramID common.PublicKey) types.Instruction
 }
 
 func Transfer(from, to common.PublicKey, lamports uint64) types.Instruction {
-	data, err := common.SerializeData(TransferInstruction{
+	data, err := borsh.Marshal(TransferInstruction{
 		Instruction: InstructionTransfer,
 		Lamports:    lamports,
 	})
@@ -146,10 +143,9 @@ func Transfer(from, to common.PublicKey, lamports uint64) types.Instruction {
 	}
 }
 func CreateAccountWithSeed(fromPubkey, newAccountPubkey, basePubkey, programID common.PublicKey, seed string, lamports, space uint64) types.Instruction {
-	data, err := common.SerializeData(CreateAccountWithSeedInstruction{
+	data, err := borsh.Marshal(CreateAccountWithSeedInstruction{
 		Instruction: InstructionCreateAccountWithSeed,
 		Base:        basePubkey,
-		SeedLen:     uint64(len(seed)),
 		Seed:        seed,
 		Lamports:    lamports,
 		Space:       space,
@@ -176,7 +172,7 @@ func CreateAccountWithSeed(fromPubkey, newAccountPubkey, basePubkey, programID c
 }
 
 func AdvanceNonceAccount(noncePubkey, authPubkey common.PublicKey) types.Instruction {
-	data, err := common.SerializeData(AdvanceNonceAccountInstruction{
+	data, err := borsh.Marshal(AdvanceNonceAccountInstruction{
 		Instruction: InstructionAdvanceNonceAccount,
 	})
 	if err != nil {
@@ -195,7 +191,7 @@ func AdvanceNonceAccount(noncePubkey, authPubkey common.PublicKey) types.Instruc
 }
 
 func WithdrawNonceAccount(noncePubkey, authPubkey, toPubkey common.PublicKey, lamports uint64) types.Instruction {
-	data, err := common.SerializeData(WithdrawNonceAccountInstruction{
+	data, err := borsh.Marshal(WithdrawNonceAccountInstruction{
 		Instruction: InstructionWithdrawNonceAccount,
 		Lamports:    lamports,
 	})
@@ -217,7 +213,7 @@ func WithdrawNonceAccount(noncePubkey, authPubkey, toPubkey common.PublicKey, la
 }
 
 func InitializeNonceAccount(noncePubkey, authPubkey common.PublicKey) types.Instruction {
-	data, err := common.SerializeData(InitializeNonceAccountInstruction{
+	data, err := borsh.Marshal(InitializeNonceAccountInstruction{
 		Instruction: InstructionInitializeNonceAccount,
 		Auth:        authPubkey,
 	})
@@ -237,7 +233,7 @@ func InitializeNonceAccount(noncePubkey, authPubkey common.PublicKey) types.Inst
 }
 
 func AuthorizeNonceAccount(noncePubkey, oriAuthPubkey, newAuthPubkey common.PublicKey) types.Instruction {
-	data, err := common.SerializeData(AuthorizeNonceAccountInstruction{
+	data, err := borsh.Marshal(AuthorizeNonceAccountInstruction{
 		Instruction: InstructionAuthorizeNonceAccount,
 		Auth:        newAuthPubkey,
 	})
@@ -256,7 +252,7 @@ func AuthorizeNonceAccount(noncePubkey, oriAuthPubkey, newAuthPubkey common.Publ
 }
 
 func Allocate(accountPubkey common.PublicKey, space uint64) types.Instruction {
-	data, err := common.SerializeData(AllocateInstruction{
+	data, err := borsh.Marshal(AllocateInstruction{
 		Instruction: InstructionAllocate,
 		Space:       space,
 	})
@@ -274,10 +270,9 @@ func Allocate(accountPubkey common.PublicKey, space uint64) types.Instruction {
 }
 
 func AllocateWithSeed(accountPubkey, basePubkey, programID common.PublicKey, seed string, space uint64) types.Instruction {
-	data, err := common.SerializeData(AllocateWithSeedInstruction{
+	data, err := borsh.Marshal(AllocateWithSeedInstruction{
 		Instruction: InstructionAllocateWithSeed,
 		Base:        basePubkey,
-		SeedLen:     uint64(len(seed)),
 		Seed:        seed,
 		Space:       space,
 		ProgramID:   programID,
@@ -296,10 +291,9 @@ func AllocateWithSeed(accountPubkey, basePubkey, programID common.PublicKey, see
 	}
 }
 func AssignWithSeed(accountPubkey, assignToProgramID, basePubkey common.PublicKey, seed string) types.Instruction {
-	data, err := common.SerializeData(AssignWithSeedInstruction{
+	data, err := borsh.Marshal(AssignWithSeedInstruction{
 		Instruction:       InstructionAssignWithSeed,
 		Base:              basePubkey,
-		SeedLen:           uint64(len(seed)),
 		Seed:              seed,
 		AssignToProgramID: assignToProgramID,
 	})
@@ -318,10 +312,9 @@ func AssignWithSeed(accountPubkey, assignToProgramID, basePubkey common.PublicKe
 }
 
 func TransferWithSeed(from, to, base, programID common.PublicKey, seed string, lamports uint64) types.Instruction {
-	data, err := common.SerializeData(TransferWithSeedInstruction{
+	data, err := borsh.Marshal(TransferWithSeedInstruction{
 		Instruction: InstructionTransferWithSeed,
 		Lamports:    lamports,
-		SeedLen:     uint64(len(seed)),
 		Seed:        seed,
 		ProgramID:   programID,
 	})
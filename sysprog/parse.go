@@ -1,23 +1,28 @@
 package sysprog
 
 import (
-	"github.com/ghostiam/binstruct"
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
 	"github.com/portto/solana-go-sdk/types"
 )
 
+func init() {
+	types.RegisterInstructionParser(common.SystemProgramID, ParseSystem)
+}
+
 func ParseSystem(ins types.Instruction) (types.ParsedInstruction, error) {
 	var parsedInstruction types.ParsedInstruction
 	var err error
 	var s struct {
 		Instruction Instruction
 	}
-	err = binstruct.UnmarshalLE(ins.Data, &s)
+	err = borsh.Unmarshal(ins.Data, &s)
 	var instructionType string
 	var parsedInfo map[string]interface{}
 	switch s.Instruction {
 	case InstructionCreateAccount:
 		var a CreateAccountInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "createAccount"
 		parsedInfo = map[string]interface{}{
 			"source":     ins.Accounts[0].PubKey.ToBase58(),
@@ -29,7 +34,7 @@ func ParseSystem(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionAssign:
 		var a AssignInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "assign"
 		parsedInfo = map[string]interface{}{
 			"account": ins.Accounts[0].PubKey.ToBase58(),
@@ -38,7 +43,7 @@ func ParseSystem(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionTransfer:
 		var a TransferInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "transfer"
 		parsedInfo = map[string]interface{}{
 			"source":      ins.Accounts[0].PubKey.ToBase58(),
@@ -49,7 +54,7 @@ func ParseSystem(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionCreateAccountWithSeed:
 		var a CreateAccountWithSeedInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "createAccountWithSeed"
 		parsedInfo = map[string]interface{}{
 			"source":     ins.Accounts[0].PubKey.ToBase58(),
@@ -63,7 +68,7 @@ func ParseSystem(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionAdvanceNonceAccount:
 		var a AdvanceNonceAccountInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "advanceNonce"
 		parsedInfo = map[string]interface{}{
 			"nonceAccount":            ins.Accounts[0].PubKey.ToBase58(),
@@ -73,7 +78,7 @@ func ParseSystem(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionWithdrawNonceAccount:
 		var a WithdrawNonceAccountInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "withdrawFromNonce"
 		parsedInfo = map[string]interface{}{
 			"nonceAccount":            ins.Accounts[0].PubKey.ToBase58(),
@@ -86,7 +91,7 @@ func ParseSystem(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionInitializeNonceAccount:
 		var a InitializeNonceAccountInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "initializeNonce"
 		parsedInfo = map[string]interface{}{
 			"nonceAccount":            ins.Accounts[0].PubKey.ToBase58(),
@@ -97,7 +102,7 @@ func ParseSystem(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionAuthorizeNonceAccount:
 		var a AuthorizeNonceAccountInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "authorizeNonce"
 		parsedInfo = map[string]interface{}{
 			"nonceAccount":   ins.Accounts[0].PubKey.ToBase58(),
@@ -108,7 +113,7 @@ func ParseSystem(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionAllocate:
 		var a AllocateInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "allocate"
 		parsedInfo = map[string]interface{}{
 			"account": ins.Accounts[0].PubKey.ToBase58(),
@@ -117,7 +122,7 @@ func ParseSystem(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionAllocateWithSeed:
 		var a AllocateWithSeedInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "allocateWithSeed"
 		parsedInfo = map[string]interface{}{
 			"account": ins.Accounts[0].PubKey.ToBase58(),
@@ -129,7 +134,7 @@ func ParseSystem(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionAssignWithSeed:
 		var a AssignWithSeedInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "assignWithSeed"
 		parsedInfo = map[string]interface{}{
 			"account": ins.Accounts[0].PubKey.ToBase58(),
@@ -140,7 +145,7 @@ func ParseSystem(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionTransferWithSeed:
 		var a TransferWithSeedInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "transferWithSeed"
 		parsedInfo = map[string]interface{}{
 			"source":      ins.Accounts[0].PubKey.ToBase58(),
@@ -0,0 +1,77 @@
+package sysprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func TestParseSystem(t *testing.T) {
+	from := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	to := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvk7")
+	base := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUJfe1X9K")
+	owner := common.PublicKeyFromString("8765cK2iw8xU3HUnCNyqzRuLtSAkm4uddJwKv7Y1rB1e")
+
+	tests := []struct {
+		name string
+		ins  types.Instruction
+		want *types.InstructionInfo
+	}{
+		{
+			name: "transfer",
+			ins:  Transfer(from, to, 1),
+			want: &types.InstructionInfo{
+				InstructionType: "transfer",
+				Info: map[string]interface{}{
+					"source":      from.ToBase58(),
+					"destination": to.ToBase58(),
+					"lamports":    uint64(1),
+				},
+			},
+		},
+		{
+			name: "create account with seed",
+			ins:  CreateAccountWithSeed(from, to, base, owner, "seed", 2, 3),
+			want: &types.InstructionInfo{
+				InstructionType: "createAccountWithSeed",
+				Info: map[string]interface{}{
+					"source":     from.ToBase58(),
+					"newAccount": to.ToBase58(),
+					"base":       base,
+					"seed":       "seed",
+					"space":      uint64(3),
+					"lamports":   uint64(2),
+					"owner":      owner,
+				},
+			},
+		},
+		{
+			name: "transfer with seed",
+			ins:  TransferWithSeed(from, to, base, owner, "seed", 4),
+			want: &types.InstructionInfo{
+				InstructionType: "transferWithSeed",
+				Info: map[string]interface{}{
+					"source":      from.ToBase58(),
+					"sourceBase":  base.ToBase58(),
+					"destination": to.ToBase58(),
+					"lamports":    uint64(4),
+					"sourceSeed":  "seed",
+					"sourceOwner": owner.ToBase58(),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSystem(tt.ins)
+			if err != nil {
+				t.Fatalf("ParseSystem() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Parsed, tt.want) {
+				t.Errorf("ParseSystem() = %+v, want %+v", got.Parsed, tt.want)
+			}
+		})
+	}
+}
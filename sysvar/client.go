@@ -0,0 +1,106 @@
+package sysvar
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+// GetAccountData fetches the data of the sysvar account with the base64 encoding
+func GetAccountData(ctx context.Context, c *client.Client, sysvar common.PublicKey) ([]byte, error) {
+	res, err := c.GetAccountInfo(ctx, sysvar.ToBase58(), client.GetAccountInfoConfig{
+		Encoding: client.GetAccountInfoConfigEncodingBase64,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.Data == nil {
+		return nil, fmt.Errorf("sysvar %s is not found", sysvar.ToBase58())
+	}
+	// the data is returned as [data, encoding]
+	data, ok := res.Data.([]interface{})
+	if !ok || len(data) != 2 {
+		return nil, errors.New("unexpected account data format")
+	}
+	s, ok := data[0].(string)
+	if !ok {
+		return nil, errors.New("unexpected account data format")
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
+
+func GetClock(ctx context.Context, c *client.Client) (Clock, error) {
+	data, err := GetAccountData(ctx, c, common.SysVarClockPubkey)
+	if err != nil {
+		return Clock{}, err
+	}
+	return ClockDeserialize(data)
+}
+
+func GetRent(ctx context.Context, c *client.Client) (Rent, error) {
+	data, err := GetAccountData(ctx, c, common.SysVarRentPubkey)
+	if err != nil {
+		return Rent{}, err
+	}
+	return RentDeserialize(data)
+}
+
+func GetEpochSchedule(ctx context.Context, c *client.Client) (EpochSchedule, error) {
+	data, err := GetAccountData(ctx, c, common.SysVarEpochSchedulePubkey)
+	if err != nil {
+		return EpochSchedule{}, err
+	}
+	return EpochScheduleDeserialize(data)
+}
+
+func GetFees(ctx context.Context, c *client.Client) (Fees, error) {
+	data, err := GetAccountData(ctx, c, common.SysVarFeesPubkey)
+	if err != nil {
+		return Fees{}, err
+	}
+	return FeesDeserialize(data)
+}
+
+func GetRecentBlockhashes(ctx context.Context, c *client.Client) (RecentBlockhashes, error) {
+	data, err := GetAccountData(ctx, c, common.SysVarRecentBlockhashsPubkey)
+	if err != nil {
+		return nil, err
+	}
+	return RecentBlockhashesDeserialize(data)
+}
+
+func GetStakeHistory(ctx context.Context, c *client.Client) (StakeHistory, error) {
+	data, err := GetAccountData(ctx, c, common.SysVarStakeHistoryPubkey)
+	if err != nil {
+		return nil, err
+	}
+	return StakeHistoryDeserialize(data)
+}
+
+func GetSlotHashes(ctx context.Context, c *client.Client) (SlotHashes, error) {
+	data, err := GetAccountData(ctx, c, common.SysVarSlotHashesPubkey)
+	if err != nil {
+		return nil, err
+	}
+	return SlotHashesDeserialize(data)
+}
+
+func GetSlotHistory(ctx context.Context, c *client.Client) (SlotHistory, error) {
+	data, err := GetAccountData(ctx, c, common.SysVarSlotHistoryPubkey)
+	if err != nil {
+		return SlotHistory{}, err
+	}
+	return SlotHistoryDeserialize(data)
+}
+
+func GetEpochRewards(ctx context.Context, c *client.Client) (EpochRewards, error) {
+	data, err := GetAccountData(ctx, c, common.SysVarEpochRewardsPubkey)
+	if err != nil {
+		return EpochRewards{}, err
+	}
+	return EpochRewardsDeserialize(data)
+}
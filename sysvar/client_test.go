@@ -0,0 +1,54 @@
+package sysvar
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/client"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+func TestGetClock(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		var req struct {
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil || req.Method != "getAccountInfo" || req.Params[0] != common.SysVarClockPubkey.ToBase58() {
+			t.Errorf("unexpected request: %s", string(body))
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","id":0,"result":{"context":{"slot":152633788},"value":{"data":["vAEZCQAAAABXni5mAAAAAG4CAAAAAAAAbwIAAAAAAABb+y9mAAAAAA==","base64"],"executable":false,"lamports":1169280,"owner":"Sysvar1111111111111111111111111111111111111","rentEpoch":0}}}`))
+	}))
+	defer srv.Close()
+
+	got, err := GetClock(context.Background(), client.NewClient(srv.URL))
+	if err != nil {
+		t.Fatalf("GetClock() error = %v", err)
+	}
+	want := Clock{
+		Slot:                152633788,
+		EpochStartTimestamp: 1714331223,
+		Epoch:               622,
+		LeaderScheduleEpoch: 623,
+		UnixTimestamp:       1714420571,
+	}
+	if got != want {
+		t.Errorf("GetClock() = %v, want %v", got, want)
+	}
+}
+
+func TestGetAccountDataNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","id":0,"result":{"context":{"slot":1},"value":null}}`))
+	}))
+	defer srv.Close()
+
+	if _, err := GetEpochRewards(context.Background(), client.NewClient(srv.URL)); err == nil {
+		t.Errorf("GetEpochRewards() error = nil, want not found")
+	}
+}
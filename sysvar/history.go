@@ -0,0 +1,182 @@
+package sysvar
+
+import (
+	"errors"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/borsh"
+)
+
+type RecentBlockhashesEntry struct {
+	Blockhash     string
+	FeeCalculator FeeCalculator
+}
+
+// RecentBlockhashes is deprecated by the cluster, the newest blockhash comes first
+type RecentBlockhashes []RecentBlockhashesEntry
+
+func RecentBlockhashesDeserialize(data []byte) (RecentBlockhashes, error) {
+	var layout struct {
+		Entries []struct {
+			Blockhash     [32]byte
+			FeeCalculator FeeCalculator
+		} `borsh:"u64len"`
+	}
+	if err := borsh.Unmarshal(data, &layout); err != nil {
+		return nil, err
+	}
+	entries := make(RecentBlockhashes, 0, len(layout.Entries))
+	for _, entry := range layout.Entries {
+		entries = append(entries, RecentBlockhashesEntry{
+			Blockhash:     base58.Encode(entry.Blockhash[:]),
+			FeeCalculator: entry.FeeCalculator,
+		})
+	}
+	return entries, nil
+}
+
+type StakeHistoryEntry struct {
+	Effective    uint64
+	Activating   uint64
+	Deactivating uint64
+}
+
+type StakeHistoryItem struct {
+	Epoch uint64
+	StakeHistoryEntry
+}
+
+// StakeHistory is the stake of the cluster at the end of each epoch, the newest epoch comes first
+type StakeHistory []StakeHistoryItem
+
+func StakeHistoryDeserialize(data []byte) (StakeHistory, error) {
+	var layout struct {
+		History StakeHistory `borsh:"u64len"`
+	}
+	if err := borsh.Unmarshal(data, &layout); err != nil {
+		return nil, err
+	}
+	return layout.History, nil
+}
+
+// Get returns the entry of the epoch, it is false if the epoch is not in the history
+func (h StakeHistory) Get(epoch uint64) (StakeHistoryEntry, bool) {
+	for _, item := range h {
+		if item.Epoch == epoch {
+			return item.StakeHistoryEntry, true
+		}
+	}
+	return StakeHistoryEntry{}, false
+}
+
+type SlotHash struct {
+	Slot uint64
+	Hash string
+}
+
+// SlotHashes is the bank hashes of the recent slots, the newest slot comes first
+type SlotHashes []SlotHash
+
+func SlotHashesDeserialize(data []byte) (SlotHashes, error) {
+	var layout struct {
+		Hashes []struct {
+			Slot uint64
+			Hash [32]byte
+		} `borsh:"u64len"`
+	}
+	if err := borsh.Unmarshal(data, &layout); err != nil {
+		return nil, err
+	}
+	hashes := make(SlotHashes, 0, len(layout.Hashes))
+	for _, slotHash := range layout.Hashes {
+		hashes = append(hashes, SlotHash{
+			Slot: slotHash.Slot,
+			Hash: base58.Encode(slotHash.Hash[:]),
+		})
+	}
+	return hashes, nil
+}
+
+// Get returns the hash of the slot, it is false if the slot is not in the list
+func (h SlotHashes) Get(slot uint64) (string, bool) {
+	for _, slotHash := range h {
+		if slotHash.Slot == slot {
+			return slotHash.Hash, true
+		}
+	}
+	return "", false
+}
+
+// SlotHistoryMaxEntries is the number of slots the slot history keeps
+const SlotHistoryMaxEntries = 1024 * 1024
+
+type SlotHistoryCheck uint8
+
+const (
+	SlotHistoryCheckFuture SlotHistoryCheck = iota
+	SlotHistoryCheckTooOld
+	SlotHistoryCheckFound
+	SlotHistoryCheckNotFound
+)
+
+// SlotHistory is a bit set of the recent slots, a slot is set if a block was produced in it
+type SlotHistory struct {
+	Bits []uint64
+	// BitsLen is the number of bits in Bits, SlotHistoryMaxEntries
+	BitsLen  uint64
+	NextSlot uint64
+}
+
+func SlotHistoryDeserialize(data []byte) (SlotHistory, error) {
+	var layout struct {
+		// bit vectors keep their blocks in an option
+		Bits     *[]uint64 `borsh:"u64len"`
+		BitsLen  uint64
+		NextSlot uint64
+	}
+	if err := borsh.Unmarshal(data, &layout); err != nil {
+		return SlotHistory{}, err
+	}
+	history := SlotHistory{
+		BitsLen:  layout.BitsLen,
+		NextSlot: layout.NextSlot,
+	}
+	if layout.Bits != nil {
+		history.Bits = *layout.Bits
+	}
+	if history.BitsLen == 0 || history.BitsLen > uint64(len(history.Bits))*64 {
+		return SlotHistory{}, errors.New("slot history bits length is invalid")
+	}
+	return history, nil
+}
+
+// Newest returns the latest slot in the history, it is 0 if the history has no slot yet
+func (h SlotHistory) Newest() uint64 {
+	if h.NextSlot == 0 {
+		return 0
+	}
+	return h.NextSlot - 1
+}
+
+// Oldest returns the earliest slot the history still keeps
+func (h SlotHistory) Oldest() uint64 {
+	if h.NextSlot < h.BitsLen {
+		return 0
+	}
+	return h.NextSlot - h.BitsLen
+}
+
+// Check reports whether a block was produced in the slot
+func (h SlotHistory) Check(slot uint64) SlotHistoryCheck {
+	if h.NextSlot == 0 || slot > h.Newest() {
+		return SlotHistoryCheckFuture
+	}
+	if slot < h.Oldest() {
+		return SlotHistoryCheckTooOld
+	}
+	idx := slot % h.BitsLen
+	if h.Bits[idx/64]&(uint64(1)<<(idx%64)) != 0 {
+		return SlotHistoryCheckFound
+	}
+	return SlotHistoryCheckNotFound
+}
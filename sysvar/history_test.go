@@ -0,0 +1,123 @@
+package sysvar
+
+import (
+	"encoding/binary"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+func TestStakeHistoryDeserialize(t *testing.T) {
+	data := []byte{2, 0, 0, 0, 0, 0, 0, 0}
+	for _, v := range []uint64{11, 1000, 20, 30, 10, 900, 0, 5} {
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, v)
+		data = append(data, b...)
+	}
+
+	got, err := StakeHistoryDeserialize(data)
+	if err != nil {
+		t.Fatalf("StakeHistoryDeserialize() error = %v", err)
+	}
+	want := StakeHistory{
+		{Epoch: 11, StakeHistoryEntry: StakeHistoryEntry{Effective: 1000, Activating: 20, Deactivating: 30}},
+		{Epoch: 10, StakeHistoryEntry: StakeHistoryEntry{Effective: 900, Activating: 0, Deactivating: 5}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("StakeHistoryDeserialize() = %v, want %v", got, want)
+	}
+	if entry, ok := got.Get(10); !ok || entry.Effective != 900 {
+		t.Errorf("Get(10) = %v, %v", entry, ok)
+	}
+	if _, ok := got.Get(9); ok {
+		t.Errorf("Get(9) found, want not found")
+	}
+
+	if _, err := StakeHistoryDeserialize(data[:40]); err == nil {
+		t.Errorf("StakeHistoryDeserialize() with 2 entries and data of 1 error = nil")
+	}
+}
+
+func TestRecentBlockhashesAndSlotHashesDeserialize(t *testing.T) {
+	hash := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+
+	blockhashes := []byte{1, 0, 0, 0, 0, 0, 0, 0}
+	blockhashes = append(blockhashes, hash.Bytes()...)
+	blockhashes = append(blockhashes, 136, 19, 0, 0, 0, 0, 0, 0)
+	gotBlockhashes, err := RecentBlockhashesDeserialize(blockhashes)
+	if err != nil {
+		t.Fatalf("RecentBlockhashesDeserialize() error = %v", err)
+	}
+	wantBlockhashes := RecentBlockhashes{
+		{Blockhash: hash.ToBase58(), FeeCalculator: FeeCalculator{LamportsPerSignature: 5000}},
+	}
+	if !reflect.DeepEqual(gotBlockhashes, wantBlockhashes) {
+		t.Errorf("RecentBlockhashesDeserialize() = %v, want %v", gotBlockhashes, wantBlockhashes)
+	}
+
+	slotHashes := []byte{1, 0, 0, 0, 0, 0, 0, 0, 42, 0, 0, 0, 0, 0, 0, 0}
+	slotHashes = append(slotHashes, hash.Bytes()...)
+	gotSlotHashes, err := SlotHashesDeserialize(slotHashes)
+	if err != nil {
+		t.Fatalf("SlotHashesDeserialize() error = %v", err)
+	}
+	if got, ok := gotSlotHashes.Get(42); !ok || got != hash.ToBase58() {
+		t.Errorf("SlotHashes.Get(42) = %v, %v", got, ok)
+	}
+}
+
+func TestSlotHistory(t *testing.T) {
+	blocks := SlotHistoryMaxEntries / 64
+	data := []byte{1}
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, uint64(blocks))
+	data = append(data, b...)
+	bits := make([]byte, blocks*8)
+	// slot 0 and slot 1048577 share the bit 1
+	bits[0] = 0b11
+	data = append(data, bits...)
+	binary.LittleEndian.PutUint64(b, SlotHistoryMaxEntries)
+	data = append(data, b...)
+	binary.LittleEndian.PutUint64(b, SlotHistoryMaxEntries+10)
+	data = append(data, b...)
+
+	history, err := SlotHistoryDeserialize(data)
+	if err != nil {
+		t.Fatalf("SlotHistoryDeserialize() error = %v", err)
+	}
+	if len(data) != 131097 {
+		t.Fatalf("slot history data size = %v, want 131097", len(data))
+	}
+
+	tests := []struct {
+		slot uint64
+		want SlotHistoryCheck
+	}{
+		{slot: SlotHistoryMaxEntries + 10, want: SlotHistoryCheckFuture},
+		{slot: 9, want: SlotHistoryCheckTooOld},
+		{slot: SlotHistoryMaxEntries + 1, want: SlotHistoryCheckFound},
+		{slot: SlotHistoryMaxEntries + 2, want: SlotHistoryCheckNotFound},
+	}
+	for _, tt := range tests {
+		if got := history.Check(tt.slot); got != tt.want {
+			t.Errorf("Check(%v) = %v, want %v", tt.slot, got, tt.want)
+		}
+	}
+}
+
+func TestSlotHistoryEmpty(t *testing.T) {
+	history := SlotHistory{
+		Bits:    make([]uint64, SlotHistoryMaxEntries/64),
+		BitsLen: SlotHistoryMaxEntries,
+	}
+	if got := history.Newest(); got != 0 {
+		t.Errorf("Newest() = %v, want 0", got)
+	}
+	for _, slot := range []uint64{0, 1, math.MaxUint64} {
+		if got := history.Check(slot); got != SlotHistoryCheckFuture {
+			t.Errorf("Check(%v) = %v, want %v", slot, got, SlotHistoryCheckFuture)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Package sysvar decodes the accounts the runtime keeps the cluster state in
+// and fetches them with the client.
+package sysvar
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/borsh"
+)
+
+type Clock struct {
+	Slot uint64
+	// EpochStartTimestamp is the unix timestamp of the first slot of the epoch
+	EpochStartTimestamp int64
+	Epoch               uint64
+	// LeaderScheduleEpoch is the latest epoch the leader schedule is known for
+	LeaderScheduleEpoch uint64
+	UnixTimestamp       int64
+}
+
+func ClockDeserialize(data []byte) (Clock, error) {
+	var clock Clock
+	if err := borsh.Unmarshal(data, &clock); err != nil {
+		return Clock{}, err
+	}
+	return clock, nil
+}
+
+// AccountStorageOverhead is the bytes an account takes besides its data when its rent is computed
+const AccountStorageOverhead = 128
+
+type Rent struct {
+	LamportsPerByteYear uint64
+	// ExemptionThreshold is the years of rent an account must hold to be exempt
+	ExemptionThreshold float64
+	// BurnPercent is the percent of collected rent which is burned
+	BurnPercent uint8
+}
+
+// DefaultRent is the rent of mainnet-beta, testnet and devnet
+var DefaultRent = Rent{
+	LamportsPerByteYear: 3480,
+	ExemptionThreshold:  2,
+	BurnPercent:         50,
+}
+
+func RentDeserialize(data []byte) (Rent, error) {
+	var rent Rent
+	if err := borsh.Unmarshal(data, &rent); err != nil {
+		return Rent{}, err
+	}
+	return rent, nil
+}
+
+// MinimumBalance returns the lamports an account of dataLen bytes must hold to be rent exempt,
+// it is what the rpc method getMinimumBalanceForRentExemption returns
+func (r Rent) MinimumBalance(dataLen uint64) uint64 {
+	bytes := AccountStorageOverhead + dataLen
+	return uint64(float64(bytes*r.LamportsPerByteYear) * r.ExemptionThreshold)
+}
+
+// IsExempt reports whether an account of dataLen bytes holding the lamports is rent exempt
+func (r Rent) IsExempt(lamports, dataLen uint64) bool {
+	return lamports >= r.MinimumBalance(dataLen)
+}
+
+// MinimumSlotsPerEpoch is the length of the first epoch when the epochs warm up
+const MinimumSlotsPerEpoch = 32
+
+type EpochSchedule struct {
+	SlotsPerEpoch uint64
+	// LeaderScheduleSlotOffset is how many slots before an epoch its leader schedule is computed
+	LeaderScheduleSlotOffset uint64
+	// Warmup means the epochs start at MinimumSlotsPerEpoch and double until SlotsPerEpoch
+	Warmup           bool
+	FirstNormalEpoch uint64
+	FirstNormalSlot  uint64
+}
+
+func EpochScheduleDeserialize(data []byte) (EpochSchedule, error) {
+	var schedule EpochSchedule
+	if err := borsh.Unmarshal(data, &schedule); err != nil {
+		return EpochSchedule{}, err
+	}
+	return schedule, nil
+}
+
+// GetSlotsInEpoch returns the number of slots of the epoch
+func (s EpochSchedule) GetSlotsInEpoch(epoch uint64) uint64 {
+	if epoch < s.FirstNormalEpoch {
+		return uint64(1) << (epoch + uint64(bits.TrailingZeros64(MinimumSlotsPerEpoch)))
+	}
+	return s.SlotsPerEpoch
+}
+
+// GetEpoch returns the epoch the slot is in
+func (s EpochSchedule) GetEpoch(slot uint64) uint64 {
+	epoch, _ := s.GetEpochAndSlotIndex(slot)
+	return epoch
+}
+
+// GetEpochAndSlotIndex returns the epoch the slot is in and the index of the slot in the epoch
+func (s EpochSchedule) GetEpochAndSlotIndex(slot uint64) (uint64, uint64) {
+	if slot < s.FirstNormalSlot {
+		// the length of a warmup epoch is the next power of two of its last slot plus the minimum
+		epoch := uint64(bits.Len64(slot+MinimumSlotsPerEpoch)) - uint64(bits.TrailingZeros64(MinimumSlotsPerEpoch)) - 1
+		epochLen := s.GetSlotsInEpoch(epoch)
+		return epoch, slot - (epochLen - MinimumSlotsPerEpoch)
+	}
+	normalSlotIndex := slot - s.FirstNormalSlot
+	return s.FirstNormalEpoch + normalSlotIndex/s.SlotsPerEpoch, normalSlotIndex % s.SlotsPerEpoch
+}
+
+// GetFirstSlotInEpoch returns the first slot of the epoch
+func (s EpochSchedule) GetFirstSlotInEpoch(epoch uint64) uint64 {
+	if epoch <= s.FirstNormalEpoch {
+		return ((uint64(1) << epoch) - 1) * MinimumSlotsPerEpoch
+	}
+	return (epoch-s.FirstNormalEpoch)*s.SlotsPerEpoch + s.FirstNormalSlot
+}
+
+// GetLastSlotInEpoch returns the last slot of the epoch
+func (s EpochSchedule) GetLastSlotInEpoch(epoch uint64) uint64 {
+	return s.GetFirstSlotInEpoch(epoch) + s.GetSlotsInEpoch(epoch) - 1
+}
+
+// GetLeaderScheduleEpoch returns the epoch the leader schedule computed at the slot is for
+func (s EpochSchedule) GetLeaderScheduleEpoch(slot uint64) uint64 {
+	if slot < s.FirstNormalSlot {
+		// the leader schedule of the next epoch is known during the warmup
+		return s.GetEpoch(slot) + 1
+	}
+	newSlotsSinceFirstNormalSlot := slot - s.FirstNormalSlot
+	newFirstNormalLeaderScheduleSlot := newSlotsSinceFirstNormalSlot + s.LeaderScheduleSlotOffset
+	return s.FirstNormalEpoch + newFirstNormalLeaderScheduleSlot/s.SlotsPerEpoch
+}
+
+type FeeCalculator struct {
+	LamportsPerSignature uint64
+}
+
+// Fees is deprecated by the cluster, use the rpc method getFeeForMessage instead
+type Fees struct {
+	FeeCalculator FeeCalculator
+}
+
+func FeesDeserialize(data []byte) (Fees, error) {
+	var fees Fees
+	if err := borsh.Unmarshal(data, &fees); err != nil {
+		return Fees{}, err
+	}
+	return fees, nil
+}
+
+// EpochRewards tracks the distribution of the staking rewards at the start of an epoch
+type EpochRewards struct {
+	DistributionStartingBlockHeight uint64
+	NumPartitions                   uint64
+	ParentBlockhash                 string
+	// TotalPoints is a u128
+	TotalPoints        *big.Int
+	TotalRewards       uint64
+	DistributedRewards uint64
+	// Active is true while the rewards are being distributed
+	Active bool
+}
+
+// epochRewardsLayout is the bincode layout of EpochRewards
+type epochRewardsLayout struct {
+	DistributionStartingBlockHeight uint64
+	NumPartitions                   uint64
+	ParentBlockhash                 [32]byte
+	TotalPoints                     borsh.Uint128
+	TotalRewards                    uint64
+	DistributedRewards              uint64
+	Active                          bool
+}
+
+func EpochRewardsDeserialize(data []byte) (EpochRewards, error) {
+	var layout epochRewardsLayout
+	if err := borsh.Unmarshal(data, &layout); err != nil {
+		return EpochRewards{}, err
+	}
+	return EpochRewards{
+		DistributionStartingBlockHeight: layout.DistributionStartingBlockHeight,
+		NumPartitions:                   layout.NumPartitions,
+		ParentBlockhash:                 base58.Encode(layout.ParentBlockhash[:]),
+		TotalPoints:                     layout.TotalPoints.BigInt(),
+		TotalRewards:                    layout.TotalRewards,
+		DistributedRewards:              layout.DistributedRewards,
+		Active:                          layout.Active,
+	}, nil
+}
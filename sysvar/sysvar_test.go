@@ -0,0 +1,171 @@
+package sysvar
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+func TestClockDeserialize(t *testing.T) {
+	data := []byte{
+		188, 1, 25, 9, 0, 0, 0, 0,
+		87, 158, 46, 102, 0, 0, 0, 0,
+		110, 2, 0, 0, 0, 0, 0, 0,
+		111, 2, 0, 0, 0, 0, 0, 0,
+		91, 251, 47, 102, 0, 0, 0, 0,
+	}
+	type args struct {
+		data []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    Clock
+		wantErr bool
+	}{
+		{
+			args: args{data: data},
+			want: Clock{
+				Slot:                152633788,
+				EpochStartTimestamp: 1714331223,
+				Epoch:               622,
+				LeaderScheduleEpoch: 623,
+				UnixTimestamp:       1714420571,
+			},
+		},
+		{
+			name:    "data size is not enough",
+			args:    args{data: data[:39]},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ClockDeserialize(tt.args.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ClockDeserialize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClockDeserialize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRent(t *testing.T) {
+	data := []byte{152, 13, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 64, 50}
+	got, err := RentDeserialize(data)
+	if err != nil {
+		t.Fatalf("RentDeserialize() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, DefaultRent) {
+		t.Errorf("RentDeserialize() = %v, want %v", got, DefaultRent)
+	}
+
+	tests := []struct {
+		dataLen uint64
+		want    uint64
+	}{
+		{dataLen: 0, want: 890880},
+		{dataLen: 165, want: 2039280},
+	}
+	for _, tt := range tests {
+		if got := DefaultRent.MinimumBalance(tt.dataLen); got != tt.want {
+			t.Errorf("MinimumBalance(%v) = %v, want %v", tt.dataLen, got, tt.want)
+		}
+	}
+	if DefaultRent.IsExempt(2039279, 165) {
+		t.Errorf("IsExempt() = true, want false")
+	}
+}
+
+func TestEpochSchedule(t *testing.T) {
+	data := []byte{0, 32, 0, 0, 0, 0, 0, 0, 0, 32, 0, 0, 0, 0, 0, 0, 1, 8, 0, 0, 0, 0, 0, 0, 0, 224, 31, 0, 0, 0, 0, 0, 0}
+	schedule, err := EpochScheduleDeserialize(data)
+	if err != nil {
+		t.Fatalf("EpochScheduleDeserialize() error = %v", err)
+	}
+	want := EpochSchedule{
+		SlotsPerEpoch:            8192,
+		LeaderScheduleSlotOffset: 8192,
+		Warmup:                   true,
+		FirstNormalEpoch:         8,
+		FirstNormalSlot:          8160,
+	}
+	if !reflect.DeepEqual(schedule, want) {
+		t.Fatalf("EpochScheduleDeserialize() = %v, want %v", schedule, want)
+	}
+
+	tests := []struct {
+		slot          uint64
+		wantEpoch     uint64
+		wantSlotIndex uint64
+	}{
+		{slot: 0, wantEpoch: 0, wantSlotIndex: 0},
+		{slot: 31, wantEpoch: 0, wantSlotIndex: 31},
+		{slot: 32, wantEpoch: 1, wantSlotIndex: 0},
+		{slot: 95, wantEpoch: 1, wantSlotIndex: 63},
+		{slot: 96, wantEpoch: 2, wantSlotIndex: 0},
+		{slot: 8159, wantEpoch: 7, wantSlotIndex: 4095},
+		{slot: 8160, wantEpoch: 8, wantSlotIndex: 0},
+		{slot: 8160 + 8192 + 5, wantEpoch: 9, wantSlotIndex: 5},
+	}
+	for _, tt := range tests {
+		epoch, slotIndex := schedule.GetEpochAndSlotIndex(tt.slot)
+		if epoch != tt.wantEpoch || slotIndex != tt.wantSlotIndex {
+			t.Errorf("GetEpochAndSlotIndex(%v) = %v, %v, want %v, %v", tt.slot, epoch, slotIndex, tt.wantEpoch, tt.wantSlotIndex)
+		}
+		if first := schedule.GetFirstSlotInEpoch(epoch); first+slotIndex != tt.slot {
+			t.Errorf("GetFirstSlotInEpoch(%v) = %v, slot %v", epoch, first, tt.slot)
+		}
+	}
+	if got := schedule.GetLastSlotInEpoch(1); got != 95 {
+		t.Errorf("GetLastSlotInEpoch(1) = %v, want 95", got)
+	}
+	if got := schedule.GetSlotsInEpoch(3); got != 256 {
+		t.Errorf("GetSlotsInEpoch(3) = %v, want 256", got)
+	}
+	if got := schedule.GetLeaderScheduleEpoch(8160); got != 9 {
+		t.Errorf("GetLeaderScheduleEpoch(8160) = %v, want 9", got)
+	}
+}
+
+func TestEpochRewardsDeserialize(t *testing.T) {
+	blockhash := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	data := make([]byte, 81)
+	binary.LittleEndian.PutUint64(data[0:], 100)
+	binary.LittleEndian.PutUint64(data[8:], 4)
+	copy(data[16:], blockhash.Bytes())
+	binary.LittleEndian.PutUint64(data[48:], math.MaxUint64)
+	binary.LittleEndian.PutUint64(data[56:], 1)
+	binary.LittleEndian.PutUint64(data[64:], 5000)
+	binary.LittleEndian.PutUint64(data[72:], 1000)
+	data[80] = 1
+
+	got, err := EpochRewardsDeserialize(data)
+	if err != nil {
+		t.Fatalf("EpochRewardsDeserialize() error = %v", err)
+	}
+	totalPoints, _ := new(big.Int).SetString("36893488147419103231", 10)
+	want := EpochRewards{
+		DistributionStartingBlockHeight: 100,
+		NumPartitions:                   4,
+		ParentBlockhash:                 blockhash.ToBase58(),
+		TotalPoints:                     totalPoints,
+		TotalRewards:                    5000,
+		DistributedRewards:              1000,
+		Active:                          true,
+	}
+	if got.TotalPoints.Cmp(want.TotalPoints) != 0 {
+		t.Errorf("EpochRewardsDeserialize() total points = %v, want %v", got.TotalPoints, want.TotalPoints)
+	}
+	got.TotalPoints, want.TotalPoints = nil, nil
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EpochRewardsDeserialize() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,78 @@
+package tokenprog
+
+import "fmt"
+
+// TokenError is the custom program error code returned by the token program
+type TokenError uint32
+
+const (
+	TokenErrorNotRentExempt TokenError = iota
+	TokenErrorInsufficientFunds
+	TokenErrorInvalidMint
+	TokenErrorMintMismatch
+	TokenErrorOwnerMismatch
+	TokenErrorFixedSupply
+	TokenErrorAlreadyInUse
+	TokenErrorInvalidNumberOfProvidedSigners
+	TokenErrorInvalidNumberOfRequiredSigners
+	TokenErrorUninitializedState
+	TokenErrorNativeNotSupported
+	TokenErrorNonNativeHasBalance
+	TokenErrorInvalidInstruction
+	TokenErrorInvalidState
+	TokenErrorOverflow
+	TokenErrorAuthorityTypeNotSupported
+	TokenErrorMintCannotFreeze
+	TokenErrorAccountFrozen
+	TokenErrorMintDecimalsMismatch
+	TokenErrorNonNativeNotSupported
+)
+
+var tokenErrors = []struct {
+	name    string
+	message string
+}{
+	{"NotRentExempt", "Lamport balance below rent-exempt threshold"},
+	{"InsufficientFunds", "Insufficient funds"},
+	{"InvalidMint", "Invalid Mint"},
+	{"MintMismatch", "Account not associated with this Mint"},
+	{"OwnerMismatch", "Owner does not match"},
+	{"FixedSupply", "Fixed supply"},
+	{"AlreadyInUse", "Already in use"},
+	{"InvalidNumberOfProvidedSigners", "Invalid number of provided signers"},
+	{"InvalidNumberOfRequiredSigners", "Invalid number of required signers"},
+	{"UninitializedState", "State is unititialized"},
+	{"NativeNotSupported", "Instruction does not support native tokens"},
+	{"NonNativeHasBalance", "Non-native account can only be closed if its balance is zero"},
+	{"InvalidInstruction", "Invalid instruction"},
+	{"InvalidState", "State is invalid for requested operation"},
+	{"Overflow", "Operation overflowed"},
+	{"AuthorityTypeNotSupported", "Account does not support specified authority type"},
+	{"MintCannotFreeze", "This token mint cannot freeze accounts"},
+	{"AccountFrozen", "Account is frozen"},
+	{"MintDecimalsMismatch", "The provided decimals value different from the Mint decimals"},
+	{"NonNativeNotSupported", "Instruction does not support non-native tokens"},
+}
+
+// TokenErrorFromCode converts a custom program error code to TokenError, ok is false if the code is unknown
+func TokenErrorFromCode(code uint32) (TokenError, bool) {
+	if int(code) >= len(tokenErrors) {
+		return 0, false
+	}
+	return TokenError(code), true
+}
+
+// String returns the variant name, e.g. "InsufficientFunds"
+func (e TokenError) String() string {
+	if int(e) >= len(tokenErrors) {
+		return fmt.Sprintf("TokenError(%d)", uint32(e))
+	}
+	return tokenErrors[e].name
+}
+
+func (e TokenError) Error() string {
+	if int(e) >= len(tokenErrors) {
+		return fmt.Sprintf("unknown token error: %d", uint32(e))
+	}
+	return tokenErrors[e].message
+}
@@ -0,0 +1,339 @@
+package tokenprog
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+// AccountType is written right after the base account by Token-2022 when an account has extensions
+type AccountType uint8
+
+const (
+	AccountTypeUninitialized AccountType = iota
+	AccountTypeMint
+	AccountTypeAccount
+)
+
+type ExtensionType uint16
+
+const (
+	ExtensionTypeUninitialized ExtensionType = iota
+	ExtensionTypeTransferFeeConfig
+	ExtensionTypeTransferFeeAmount
+	ExtensionTypeMintCloseAuthority
+	ExtensionTypeConfidentialTransferMint
+	ExtensionTypeConfidentialTransferAccount
+	ExtensionTypeDefaultAccountState
+	ExtensionTypeImmutableOwner
+	ExtensionTypeMemoTransfer
+	ExtensionTypeNonTransferable
+	ExtensionTypeInterestBearingConfig
+	ExtensionTypeCpiGuard
+	ExtensionTypePermanentDelegate
+	ExtensionTypeNonTransferableAccount
+	ExtensionTypeTransferHook
+	ExtensionTypeTransferHookAccount
+	ExtensionTypeConfidentialTransferFeeConfig
+	ExtensionTypeConfidentialTransferFeeAmount
+	ExtensionTypeMetadataPointer
+	ExtensionTypeTokenMetadata
+	ExtensionTypeGroupPointer
+	ExtensionTypeTokenGroup
+	ExtensionTypeGroupMemberPointer
+	ExtensionTypeTokenGroupMember
+)
+
+var extensionTypeNames = map[ExtensionType]string{
+	ExtensionTypeUninitialized:                 "uninitialized",
+	ExtensionTypeTransferFeeConfig:             "transferFeeConfig",
+	ExtensionTypeTransferFeeAmount:             "transferFeeAmount",
+	ExtensionTypeMintCloseAuthority:            "mintCloseAuthority",
+	ExtensionTypeConfidentialTransferMint:      "confidentialTransferMint",
+	ExtensionTypeConfidentialTransferAccount:   "confidentialTransferAccount",
+	ExtensionTypeDefaultAccountState:           "defaultAccountState",
+	ExtensionTypeImmutableOwner:                "immutableOwner",
+	ExtensionTypeMemoTransfer:                  "memoTransfer",
+	ExtensionTypeNonTransferable:               "nonTransferable",
+	ExtensionTypeInterestBearingConfig:         "interestBearingConfig",
+	ExtensionTypeCpiGuard:                      "cpiGuard",
+	ExtensionTypePermanentDelegate:             "permanentDelegate",
+	ExtensionTypeNonTransferableAccount:        "nonTransferableAccount",
+	ExtensionTypeTransferHook:                  "transferHook",
+	ExtensionTypeTransferHookAccount:           "transferHookAccount",
+	ExtensionTypeConfidentialTransferFeeConfig: "confidentialTransferFeeConfig",
+	ExtensionTypeConfidentialTransferFeeAmount: "confidentialTransferFeeAmount",
+	ExtensionTypeMetadataPointer:               "metadataPointer",
+	ExtensionTypeTokenMetadata:                 "tokenMetadata",
+	ExtensionTypeGroupPointer:                  "groupPointer",
+	ExtensionTypeTokenGroup:                    "tokenGroup",
+	ExtensionTypeGroupMemberPointer:            "groupMemberPointer",
+	ExtensionTypeTokenGroupMember:              "tokenGroupMember",
+}
+
+func (t ExtensionType) String() string {
+	if name, exist := extensionTypeNames[t]; exist {
+		return name
+	}
+	return fmt.Sprintf("ExtensionType(%d)", uint16(t))
+}
+
+// extensionHeader is the type and the length before the data of an extension
+type extensionHeader struct {
+	Type   ExtensionType
+	Length uint16
+}
+
+// Extension is a TLV entry of a Token-2022 account, use Decode to get its value
+type Extension struct {
+	Type ExtensionType
+	Data []byte
+}
+
+type TransferFee struct {
+	Epoch                  uint64
+	MaximumFee             uint64
+	TransferFeeBasisPoints uint16
+}
+
+type TransferFeeConfig struct {
+	TransferFeeConfigAuthority *common.PublicKey `borsh:"nonzero"`
+	WithdrawWithheldAuthority  *common.PublicKey `borsh:"nonzero"`
+	WithheldAmount             uint64
+	OlderTransferFee           TransferFee
+	NewerTransferFee           TransferFee
+}
+
+// Fee returns the transfer fee of the amount in the epoch, the newer fee takes effect from its epoch
+func (c TransferFeeConfig) Fee(epoch, amount uint64) uint64 {
+	transferFee := c.OlderTransferFee
+	if epoch >= c.NewerTransferFee.Epoch {
+		transferFee = c.NewerTransferFee
+	}
+	if transferFee.TransferFeeBasisPoints == 0 || amount == 0 {
+		return 0
+	}
+	// ceil(amount * bps / 10000) without overflowing
+	hi, lo := bits.Mul64(amount, uint64(transferFee.TransferFeeBasisPoints))
+	if hi >= 10000 {
+		return transferFee.MaximumFee
+	}
+	fee, rem := bits.Div64(hi, lo, 10000)
+	if rem != 0 {
+		fee++
+	}
+	if fee > transferFee.MaximumFee {
+		return transferFee.MaximumFee
+	}
+	return fee
+}
+
+type TransferFeeAmount struct {
+	WithheldAmount uint64
+}
+
+type MintCloseAuthority struct {
+	CloseAuthority *common.PublicKey `borsh:"nonzero"`
+}
+
+type DefaultAccountState struct {
+	State TokenAccountState
+}
+
+type ImmutableOwner struct{}
+
+type MemoTransfer struct {
+	RequireIncomingTransferMemos bool
+}
+
+type NonTransferable struct{}
+
+type NonTransferableAccount struct{}
+
+type InterestBearingConfig struct {
+	RateAuthority           *common.PublicKey `borsh:"nonzero"`
+	InitializationTimestamp int64
+	PreUpdateAverageRate    int16
+	LastUpdateTimestamp     int64
+	CurrentRate             int16
+}
+
+type CpiGuard struct {
+	LockCpi bool
+}
+
+type PermanentDelegate struct {
+	Delegate *common.PublicKey `borsh:"nonzero"`
+}
+
+type TransferHook struct {
+	Authority *common.PublicKey `borsh:"nonzero"`
+	ProgramID *common.PublicKey `borsh:"nonzero"`
+}
+
+type TransferHookAccount struct {
+	Transferring bool
+}
+
+type MetadataPointer struct {
+	Authority       *common.PublicKey `borsh:"nonzero"`
+	MetadataAddress *common.PublicKey `borsh:"nonzero"`
+}
+
+type TokenMetadata struct {
+	UpdateAuthority    *common.PublicKey `borsh:"nonzero"`
+	Mint               common.PublicKey
+	Name               string
+	Symbol             string
+	Uri                string
+	AdditionalMetadata [][2]string
+}
+
+type GroupPointer struct {
+	Authority    *common.PublicKey `borsh:"nonzero"`
+	GroupAddress *common.PublicKey `borsh:"nonzero"`
+}
+
+type GroupMemberPointer struct {
+	Authority     *common.PublicKey `borsh:"nonzero"`
+	MemberAddress *common.PublicKey `borsh:"nonzero"`
+}
+
+var ErrExtensionNotSupported = errors.New("extension is not supported")
+
+// Decode returns the value of the extension, like TransferFeeConfig for ExtensionTypeTransferFeeConfig.
+// Confidential transfer and token group extensions are not supported.
+func (e Extension) Decode() (interface{}, error) {
+	var v interface{}
+	var err error
+	switch e.Type {
+	case ExtensionTypeTransferFeeConfig:
+		var transferFeeConfig TransferFeeConfig
+		err = borsh.Unmarshal(e.Data, &transferFeeConfig)
+		v = transferFeeConfig
+	case ExtensionTypeTransferFeeAmount:
+		var transferFeeAmount TransferFeeAmount
+		err = borsh.Unmarshal(e.Data, &transferFeeAmount)
+		v = transferFeeAmount
+	case ExtensionTypeMintCloseAuthority:
+		var mintCloseAuthority MintCloseAuthority
+		err = borsh.Unmarshal(e.Data, &mintCloseAuthority)
+		v = mintCloseAuthority
+	case ExtensionTypeDefaultAccountState:
+		var defaultAccountState DefaultAccountState
+		err = borsh.Unmarshal(e.Data, &defaultAccountState)
+		v = defaultAccountState
+	case ExtensionTypeImmutableOwner:
+		var immutableOwner ImmutableOwner
+		err = borsh.Unmarshal(e.Data, &immutableOwner)
+		v = immutableOwner
+	case ExtensionTypeMemoTransfer:
+		var memoTransfer MemoTransfer
+		err = borsh.Unmarshal(e.Data, &memoTransfer)
+		v = memoTransfer
+	case ExtensionTypeNonTransferable:
+		var nonTransferable NonTransferable
+		err = borsh.Unmarshal(e.Data, &nonTransferable)
+		v = nonTransferable
+	case ExtensionTypeNonTransferableAccount:
+		var nonTransferableAccount NonTransferableAccount
+		err = borsh.Unmarshal(e.Data, &nonTransferableAccount)
+		v = nonTransferableAccount
+	case ExtensionTypeInterestBearingConfig:
+		var interestBearingConfig InterestBearingConfig
+		err = borsh.Unmarshal(e.Data, &interestBearingConfig)
+		v = interestBearingConfig
+	case ExtensionTypeCpiGuard:
+		var cpiGuard CpiGuard
+		err = borsh.Unmarshal(e.Data, &cpiGuard)
+		v = cpiGuard
+	case ExtensionTypePermanentDelegate:
+		var permanentDelegate PermanentDelegate
+		err = borsh.Unmarshal(e.Data, &permanentDelegate)
+		v = permanentDelegate
+	case ExtensionTypeTransferHook:
+		var transferHook TransferHook
+		err = borsh.Unmarshal(e.Data, &transferHook)
+		v = transferHook
+	case ExtensionTypeTransferHookAccount:
+		var transferHookAccount TransferHookAccount
+		err = borsh.Unmarshal(e.Data, &transferHookAccount)
+		v = transferHookAccount
+	case ExtensionTypeMetadataPointer:
+		var metadataPointer MetadataPointer
+		err = borsh.Unmarshal(e.Data, &metadataPointer)
+		v = metadataPointer
+	case ExtensionTypeTokenMetadata:
+		var tokenMetadata TokenMetadata
+		err = borsh.Unmarshal(e.Data, &tokenMetadata)
+		v = tokenMetadata
+	case ExtensionTypeGroupPointer:
+		var groupPointer GroupPointer
+		err = borsh.Unmarshal(e.Data, &groupPointer)
+		v = groupPointer
+	case ExtensionTypeGroupMemberPointer:
+		var groupMemberPointer GroupMemberPointer
+		err = borsh.Unmarshal(e.Data, &groupMemberPointer)
+		v = groupMemberPointer
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrExtensionNotSupported, e.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %v: %w", e.Type, err)
+	}
+	return v, nil
+}
+
+// FindExtension returns the first extension of the type
+func FindExtension(extensions []Extension, extensionType ExtensionType) (Extension, bool) {
+	for _, extension := range extensions {
+		if extension.Type == extensionType {
+			return extension, true
+		}
+	}
+	return Extension{}, false
+}
+
+// ExtensionsFromData returns the account type and the extensions of a mint or token account data.
+// The base mint and token account sizes have no extensions, a Token-2022 account with extensions is
+// padded to TokenAccountSize and followed by its account type and the TLV entries.
+func ExtensionsFromData(data []byte) (AccountType, []Extension, error) {
+	switch {
+	case len(data) == MintAccountSize:
+		return AccountTypeMint, nil, nil
+	case len(data) == TokenAccountSize:
+		return AccountTypeAccount, nil, nil
+	case len(data) == int(MultisigAccountSize):
+		return AccountTypeUninitialized, nil, errors.New("multisig account has no extensions")
+	case len(data) < TokenAccountSize+1:
+		return AccountTypeUninitialized, nil, fmt.Errorf("data length %d not match", len(data))
+	}
+
+	accountType := AccountType(data[TokenAccountSize])
+	if accountType != AccountTypeMint && accountType != AccountTypeAccount {
+		return AccountTypeUninitialized, nil, fmt.Errorf("unknown account type %d", accountType)
+	}
+
+	extensions := []Extension{}
+	tlv := data[TokenAccountSize+1:]
+	for len(tlv) >= 4 {
+		var header extensionHeader
+		if err := borsh.Unmarshal(tlv, &header); err != nil {
+			return AccountTypeUninitialized, nil, err
+		}
+		extensionType, length := header.Type, int(header.Length)
+		// the rest of the data is zeroed space for extensions added later
+		if extensionType == ExtensionTypeUninitialized {
+			break
+		}
+		if len(tlv) < 4+length {
+			return AccountTypeUninitialized, nil, fmt.Errorf("%v extension data size is not enough", extensionType)
+		}
+		extensions = append(extensions, Extension{Type: extensionType, Data: tlv[4 : 4+length]})
+		tlv = tlv[4+length:]
+	}
+	return accountType, extensions, nil
+}
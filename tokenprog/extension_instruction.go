@@ -0,0 +1,601 @@
+package tokenprog
+
+import (
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// instructions of the Token-2022 extensions, they are only supported by common.Token2022ProgramID
+
+type TransferFeeInstruction uint8
+
+const (
+	TransferFeeInstructionInitializeTransferFeeConfig TransferFeeInstruction = iota
+	TransferFeeInstructionTransferCheckedWithFee
+	TransferFeeInstructionWithdrawWithheldTokensFromMint
+	TransferFeeInstructionWithdrawWithheldTokensFromAccounts
+	TransferFeeInstructionHarvestWithheldTokensToMint
+	TransferFeeInstructionSetTransferFee
+)
+
+type DefaultAccountStateInstruction uint8
+
+const (
+	DefaultAccountStateInstructionInitialize DefaultAccountStateInstruction = iota
+	DefaultAccountStateInstructionUpdate
+)
+
+type RequiredMemoTransfersInstruction uint8
+
+const (
+	RequiredMemoTransfersInstructionEnable RequiredMemoTransfersInstruction = iota
+	RequiredMemoTransfersInstructionDisable
+)
+
+type InterestBearingMintInstruction uint8
+
+const (
+	InterestBearingMintInstructionInitialize InterestBearingMintInstruction = iota
+	InterestBearingMintInstructionUpdateRate
+)
+
+type CpiGuardInstruction uint8
+
+const (
+	CpiGuardInstructionEnable CpiGuardInstruction = iota
+	CpiGuardInstructionDisable
+)
+
+type TransferHookInstruction uint8
+
+const (
+	TransferHookInstructionInitialize TransferHookInstruction = iota
+	TransferHookInstructionUpdate
+)
+
+type MetadataPointerInstruction uint8
+
+const (
+	MetadataPointerInstructionInitialize MetadataPointerInstruction = iota
+	MetadataPointerInstructionUpdate
+)
+
+// InitializeMintCloseAuthority lets the close authority close the mint, it must be used before InitializeMint
+func InitializeMintCloseAuthority(mintPubkey, closeAuthPubkey common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction    Instruction
+		CloseAuthority *common.PublicKey
+	}{
+		Instruction:    InstructionInitializeMintCloseAuthority,
+		CloseAuthority: pubkeyOption(closeAuthPubkey),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: mintPubkey, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+// InitializeTransferFeeConfig must be used before InitializeMint, pass common.PublicKey{} for an authority which is not needed
+func InitializeTransferFeeConfig(mintPubkey, transferFeeConfigAuthPubkey, withdrawWithheldAuthPubkey common.PublicKey, transferFeeBasisPoints uint16, maximumFee uint64) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction                Instruction
+		TransferFeeInstruction     TransferFeeInstruction
+		TransferFeeConfigAuthority *common.PublicKey
+		WithdrawWithheldAuthority  *common.PublicKey
+		TransferFeeBasisPoints     uint16
+		MaximumFee                 uint64
+	}{
+		Instruction:                InstructionTransferFeeExtension,
+		TransferFeeInstruction:     TransferFeeInstructionInitializeTransferFeeConfig,
+		TransferFeeConfigAuthority: pubkeyOption(transferFeeConfigAuthPubkey),
+		WithdrawWithheldAuthority:  pubkeyOption(withdrawWithheldAuthPubkey),
+		TransferFeeBasisPoints:     transferFeeBasisPoints,
+		MaximumFee:                 maximumFee,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: mintPubkey, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+// TransferCheckedWithFee is TransferChecked of a mint with a transfer fee, the fee must match the one the program calculates
+func TransferCheckedWithFee(srcPubkey, mintPubkey, destPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, amount uint64, decimals uint8, fee uint64) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction            Instruction
+		TransferFeeInstruction TransferFeeInstruction
+		Amount                 uint64
+		Decimals               uint8
+		Fee                    uint64
+	}{
+		Instruction:            InstructionTransferFeeExtension,
+		TransferFeeInstruction: TransferFeeInstructionTransferCheckedWithFee,
+		Amount:                 amount,
+		Decimals:               decimals,
+		Fee:                    fee,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 4+len(signerPubkeys))
+	accounts = append(accounts,
+		types.AccountMeta{PubKey: srcPubkey, IsSigner: false, IsWritable: true},
+		types.AccountMeta{PubKey: mintPubkey, IsSigner: false, IsWritable: false},
+		types.AccountMeta{PubKey: destPubkey, IsSigner: false, IsWritable: true},
+	)
+	accounts = append(accounts, authorityAccounts(authPubkey, signerPubkeys)...)
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// WithdrawWithheldTokensFromMint moves the fees withheld in the mint to the dest account
+func WithdrawWithheldTokensFromMint(mintPubkey, destPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction            Instruction
+		TransferFeeInstruction TransferFeeInstruction
+	}{
+		Instruction:            InstructionTransferFeeExtension,
+		TransferFeeInstruction: TransferFeeInstructionWithdrawWithheldTokensFromMint,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 3+len(signerPubkeys))
+	accounts = append(accounts,
+		types.AccountMeta{PubKey: mintPubkey, IsSigner: false, IsWritable: true},
+		types.AccountMeta{PubKey: destPubkey, IsSigner: false, IsWritable: true},
+	)
+	accounts = append(accounts, authorityAccounts(authPubkey, signerPubkeys)...)
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// WithdrawWithheldTokensFromAccounts moves the fees withheld in the source accounts to the dest account
+func WithdrawWithheldTokensFromAccounts(mintPubkey, destPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, sourcePubkeys []common.PublicKey) types.Instruction {
+	if len(sourcePubkeys) > 255 {
+		panic("maximum of source accounts is 255")
+	}
+	data, err := borsh.Marshal(struct {
+		Instruction            Instruction
+		TransferFeeInstruction TransferFeeInstruction
+		NumTokenAccounts       uint8
+	}{
+		Instruction:            InstructionTransferFeeExtension,
+		TransferFeeInstruction: TransferFeeInstructionWithdrawWithheldTokensFromAccounts,
+		NumTokenAccounts:       uint8(len(sourcePubkeys)),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 3+len(signerPubkeys)+len(sourcePubkeys))
+	accounts = append(accounts,
+		types.AccountMeta{PubKey: mintPubkey, IsSigner: false, IsWritable: false},
+		types.AccountMeta{PubKey: destPubkey, IsSigner: false, IsWritable: true},
+	)
+	accounts = append(accounts, authorityAccounts(authPubkey, signerPubkeys)...)
+	for _, sourcePubkey := range sourcePubkeys {
+		accounts = append(accounts, types.AccountMeta{PubKey: sourcePubkey, IsSigner: false, IsWritable: true})
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// HarvestWithheldTokensToMint moves the fees withheld in the source accounts to the mint, anyone can use it
+func HarvestWithheldTokensToMint(mintPubkey common.PublicKey, sourcePubkeys []common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction            Instruction
+		TransferFeeInstruction TransferFeeInstruction
+	}{
+		Instruction:            InstructionTransferFeeExtension,
+		TransferFeeInstruction: TransferFeeInstructionHarvestWithheldTokensToMint,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 1+len(sourcePubkeys))
+	accounts = append(accounts, types.AccountMeta{PubKey: mintPubkey, IsSigner: false, IsWritable: true})
+	for _, sourcePubkey := range sourcePubkeys {
+		accounts = append(accounts, types.AccountMeta{PubKey: sourcePubkey, IsSigner: false, IsWritable: true})
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// SetTransferFee sets the fee which takes effect two epochs later
+func SetTransferFee(mintPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, transferFeeBasisPoints uint16, maximumFee uint64) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction            Instruction
+		TransferFeeInstruction TransferFeeInstruction
+		TransferFeeBasisPoints uint16
+		MaximumFee             uint64
+	}{
+		Instruction:            InstructionTransferFeeExtension,
+		TransferFeeInstruction: TransferFeeInstructionSetTransferFee,
+		TransferFeeBasisPoints: transferFeeBasisPoints,
+		MaximumFee:             maximumFee,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 2+len(signerPubkeys))
+	accounts = append(accounts, types.AccountMeta{PubKey: mintPubkey, IsSigner: false, IsWritable: true})
+	accounts = append(accounts, authorityAccounts(authPubkey, signerPubkeys)...)
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// InitializeDefaultAccountState sets the state of new token accounts, it must be used before InitializeMint
+func InitializeDefaultAccountState(mintPubkey common.PublicKey, state TokenAccountState) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction                    Instruction
+		DefaultAccountStateInstruction DefaultAccountStateInstruction
+		State                          TokenAccountState
+	}{
+		Instruction:                    InstructionDefaultAccountStateExtension,
+		DefaultAccountStateInstruction: DefaultAccountStateInstructionInitialize,
+		State:                          state,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: mintPubkey, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+// UpdateDefaultAccountState is signed by the freeze authority of the mint
+func UpdateDefaultAccountState(mintPubkey, freezeAuthPubkey common.PublicKey, signerPubkeys []common.PublicKey, state TokenAccountState) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction                    Instruction
+		DefaultAccountStateInstruction DefaultAccountStateInstruction
+		State                          TokenAccountState
+	}{
+		Instruction:                    InstructionDefaultAccountStateExtension,
+		DefaultAccountStateInstruction: DefaultAccountStateInstructionUpdate,
+		State:                          state,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 2+len(signerPubkeys))
+	accounts = append(accounts, types.AccountMeta{PubKey: mintPubkey, IsSigner: false, IsWritable: true})
+	accounts = append(accounts, authorityAccounts(freezeAuthPubkey, signerPubkeys)...)
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// Reallocate grows a token account for the extensions, the payer funds the rent
+func Reallocate(accountPubkey, payerPubkey, ownerPubkey common.PublicKey, signerPubkeys []common.PublicKey, extensionTypes []ExtensionType) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction    Instruction
+		ExtensionTypes []ExtensionType `borsh:"rest"`
+	}{
+		Instruction:    InstructionReallocate,
+		ExtensionTypes: extensionTypes,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 4+len(signerPubkeys))
+	accounts = append(accounts,
+		types.AccountMeta{PubKey: accountPubkey, IsSigner: false, IsWritable: true},
+		types.AccountMeta{PubKey: payerPubkey, IsSigner: true, IsWritable: true},
+		types.AccountMeta{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+	)
+	accounts = append(accounts, authorityAccounts(ownerPubkey, signerPubkeys)...)
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// EnableRequiredMemoTransfers makes incoming transfers of the token account require a memo
+func EnableRequiredMemoTransfers(accountPubkey, ownerPubkey common.PublicKey, signerPubkeys []common.PublicKey) types.Instruction {
+	return requiredMemoTransfers(RequiredMemoTransfersInstructionEnable, accountPubkey, ownerPubkey, signerPubkeys)
+}
+
+func DisableRequiredMemoTransfers(accountPubkey, ownerPubkey common.PublicKey, signerPubkeys []common.PublicKey) types.Instruction {
+	return requiredMemoTransfers(RequiredMemoTransfersInstructionDisable, accountPubkey, ownerPubkey, signerPubkeys)
+}
+
+func requiredMemoTransfers(ins RequiredMemoTransfersInstruction, accountPubkey, ownerPubkey common.PublicKey, signerPubkeys []common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction                      Instruction
+		RequiredMemoTransfersInstruction RequiredMemoTransfersInstruction
+	}{
+		Instruction:                      InstructionMemoTransferExtension,
+		RequiredMemoTransfersInstruction: ins,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 2+len(signerPubkeys))
+	accounts = append(accounts, types.AccountMeta{PubKey: accountPubkey, IsSigner: false, IsWritable: true})
+	accounts = append(accounts, authorityAccounts(ownerPubkey, signerPubkeys)...)
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// InitializeNonTransferableMint must be used before InitializeMint
+func InitializeNonTransferableMint(mintPubkey common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionInitializeNonTransferableMint,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: mintPubkey, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+// InitializeInterestBearingMint must be used before InitializeMint, the rate is in basis points
+func InitializeInterestBearingMint(mintPubkey, rateAuthPubkey common.PublicKey, rate int16) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction                    Instruction
+		InterestBearingMintInstruction InterestBearingMintInstruction
+		RateAuthority                  common.PublicKey
+		Rate                           int16
+	}{
+		Instruction:                    InstructionInterestBearingMintExtension,
+		InterestBearingMintInstruction: InterestBearingMintInstructionInitialize,
+		RateAuthority:                  rateAuthPubkey,
+		Rate:                           rate,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: mintPubkey, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+func UpdateInterestRate(mintPubkey, rateAuthPubkey common.PublicKey, signerPubkeys []common.PublicKey, rate int16) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction                    Instruction
+		InterestBearingMintInstruction InterestBearingMintInstruction
+		Rate                           int16
+	}{
+		Instruction:                    InstructionInterestBearingMintExtension,
+		InterestBearingMintInstruction: InterestBearingMintInstructionUpdateRate,
+		Rate:                           rate,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 2+len(signerPubkeys))
+	accounts = append(accounts, types.AccountMeta{PubKey: mintPubkey, IsSigner: false, IsWritable: true})
+	accounts = append(accounts, authorityAccounts(rateAuthPubkey, signerPubkeys)...)
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// EnableCpiGuard stops programs from using the token account with the owner's signature in cpi
+func EnableCpiGuard(accountPubkey, ownerPubkey common.PublicKey, signerPubkeys []common.PublicKey) types.Instruction {
+	return cpiGuard(CpiGuardInstructionEnable, accountPubkey, ownerPubkey, signerPubkeys)
+}
+
+func DisableCpiGuard(accountPubkey, ownerPubkey common.PublicKey, signerPubkeys []common.PublicKey) types.Instruction {
+	return cpiGuard(CpiGuardInstructionDisable, accountPubkey, ownerPubkey, signerPubkeys)
+}
+
+func cpiGuard(ins CpiGuardInstruction, accountPubkey, ownerPubkey common.PublicKey, signerPubkeys []common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction         Instruction
+		CpiGuardInstruction CpiGuardInstruction
+	}{
+		Instruction:         InstructionCpiGuardExtension,
+		CpiGuardInstruction: ins,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 2+len(signerPubkeys))
+	accounts = append(accounts, types.AccountMeta{PubKey: accountPubkey, IsSigner: false, IsWritable: true})
+	accounts = append(accounts, authorityAccounts(ownerPubkey, signerPubkeys)...)
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// InitializePermanentDelegate must be used before InitializeMint
+func InitializePermanentDelegate(mintPubkey, delegatePubkey common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction Instruction
+		Delegate    common.PublicKey
+	}{
+		Instruction: InstructionInitializePermanentDelegate,
+		Delegate:    delegatePubkey,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: mintPubkey, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+// InitializeTransferHook must be used before InitializeMint, pass common.PublicKey{} for a missing authority or program
+func InitializeTransferHook(mintPubkey, authPubkey, hookProgramID common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction             Instruction
+		TransferHookInstruction TransferHookInstruction
+		Authority               common.PublicKey
+		ProgramID               common.PublicKey
+	}{
+		Instruction:             InstructionTransferHookExtension,
+		TransferHookInstruction: TransferHookInstructionInitialize,
+		Authority:               authPubkey,
+		ProgramID:               hookProgramID,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: mintPubkey, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+func UpdateTransferHook(mintPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, hookProgramID common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction             Instruction
+		TransferHookInstruction TransferHookInstruction
+		ProgramID               common.PublicKey
+	}{
+		Instruction:             InstructionTransferHookExtension,
+		TransferHookInstruction: TransferHookInstructionUpdate,
+		ProgramID:               hookProgramID,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 2+len(signerPubkeys))
+	accounts = append(accounts, types.AccountMeta{PubKey: mintPubkey, IsSigner: false, IsWritable: true})
+	accounts = append(accounts, authorityAccounts(authPubkey, signerPubkeys)...)
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// InitializeMetadataPointer must be used before InitializeMint, the metadata address is usually the mint itself
+func InitializeMetadataPointer(mintPubkey, authPubkey, metadataPubkey common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction                Instruction
+		MetadataPointerInstruction MetadataPointerInstruction
+		Authority                  common.PublicKey
+		MetadataAddress            common.PublicKey
+	}{
+		Instruction:                InstructionMetadataPointerExtension,
+		MetadataPointerInstruction: MetadataPointerInstructionInitialize,
+		Authority:                  authPubkey,
+		MetadataAddress:            metadataPubkey,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: mintPubkey, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+func UpdateMetadataPointer(mintPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, metadataPubkey common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction                Instruction
+		MetadataPointerInstruction MetadataPointerInstruction
+		MetadataAddress            common.PublicKey
+	}{
+		Instruction:                InstructionMetadataPointerExtension,
+		MetadataPointerInstruction: MetadataPointerInstructionUpdate,
+		MetadataAddress:            metadataPubkey,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 2+len(signerPubkeys))
+	accounts = append(accounts, types.AccountMeta{PubKey: mintPubkey, IsSigner: false, IsWritable: true})
+	accounts = append(accounts, authorityAccounts(authPubkey, signerPubkeys)...)
+
+	return types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
@@ -0,0 +1,119 @@
+package tokenprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func TestTransferCheckedWithFee(t *testing.T) {
+	src := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	mint := common.PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH")
+	dest := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	auth := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+
+	got := TransferCheckedWithFee(src, mint, dest, auth, []common.PublicKey{}, 1000, 6, 5)
+	want := types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: src, IsSigner: false, IsWritable: true},
+			{PubKey: mint, IsSigner: false, IsWritable: false},
+			{PubKey: dest, IsSigner: false, IsWritable: true},
+			{PubKey: auth, IsSigner: true, IsWritable: false},
+		},
+		Data: []byte{26, 1, 232, 3, 0, 0, 0, 0, 0, 0, 6, 5, 0, 0, 0, 0, 0, 0, 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TransferCheckedWithFee() = %v, want %v", got, want)
+	}
+
+	parsed, err := types.ParseInstruction(got)
+	if err != nil {
+		t.Fatalf("ParseInstruction() error = %v", err)
+	}
+	wantInfo := map[string]interface{}{
+		"source":      src.ToBase58(),
+		"mint":        mint.ToBase58(),
+		"destination": dest.ToBase58(),
+		"tokenAmount": UiTokenAmount{UiAmount: 0.001, Decimals: 6, Amount: "1000"},
+		"feeAmount":   UiTokenAmount{UiAmount: 0.000005, Decimals: 6, Amount: "5"},
+		"authority":   auth.ToBase58(),
+	}
+	if parsed.Program != "spl-token-2022" || parsed.Parsed.InstructionType != "transferCheckedWithFee" || !reflect.DeepEqual(parsed.Parsed.Info, wantInfo) {
+		t.Errorf("ParseInstruction() = %v %v, want %v", parsed.Program, parsed.Parsed, wantInfo)
+	}
+}
+
+func TestInitializeTransferFeeConfig(t *testing.T) {
+	mint := common.PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH")
+	auth := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+
+	got := InitializeTransferFeeConfig(mint, auth, common.PublicKey{}, 100, 1000)
+	want := append([]byte{26, 0, 1}, auth.Bytes()...)
+	want = append(want, 0, 100, 0, 232, 3, 0, 0, 0, 0, 0, 0)
+	if !reflect.DeepEqual(got.Data, want) {
+		t.Errorf("InitializeTransferFeeConfig() data = %v, want %v", got.Data, want)
+	}
+	if len(got.Accounts) != 1 || got.Accounts[0].PubKey != mint || !got.Accounts[0].IsWritable {
+		t.Errorf("InitializeTransferFeeConfig() accounts = %v", got.Accounts)
+	}
+}
+
+func TestWithdrawWithheldTokensFromAccounts(t *testing.T) {
+	mint := common.PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH")
+	dest := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	multisig := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	signer := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	source := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+
+	got := WithdrawWithheldTokensFromAccounts(mint, dest, multisig, []common.PublicKey{signer}, []common.PublicKey{source})
+	want := types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: mint, IsSigner: false, IsWritable: false},
+			{PubKey: dest, IsSigner: false, IsWritable: true},
+			{PubKey: multisig, IsSigner: false, IsWritable: false},
+			{PubKey: signer, IsSigner: true, IsWritable: false},
+			{PubKey: source, IsSigner: false, IsWritable: true},
+		},
+		Data: []byte{26, 3, 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WithdrawWithheldTokensFromAccounts() = %v, want %v", got, want)
+	}
+}
+
+func TestReallocate(t *testing.T) {
+	account := common.PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH")
+	payer := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	owner := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+
+	got := Reallocate(account, payer, owner, nil, []ExtensionType{ExtensionTypeMemoTransfer, ExtensionTypeCpiGuard})
+	want := types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: account, IsSigner: false, IsWritable: true},
+			{PubKey: payer, IsSigner: true, IsWritable: true},
+			{PubKey: common.SystemProgramID, IsSigner: false, IsWritable: false},
+			{PubKey: owner, IsSigner: true, IsWritable: false},
+		},
+		Data: []byte{29, 8, 0, 11, 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Reallocate() = %v, want %v", got, want)
+	}
+}
+
+func TestInitializeInterestBearingMint(t *testing.T) {
+	mint := common.PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH")
+	auth := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+
+	got := InitializeInterestBearingMint(mint, auth, -5)
+	want := append([]byte{33, 0}, auth.Bytes()...)
+	want = append(want, 0xfb, 0xff)
+	if !reflect.DeepEqual(got.Data, want) {
+		t.Errorf("InitializeInterestBearingMint() data = %v, want %v", got.Data, want)
+	}
+}
@@ -0,0 +1,259 @@
+package tokenprog
+
+import (
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+func TestExtensionsFromData(t *testing.T) {
+	mint := common.PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH")
+	owner := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	authority := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	tlv := func(extensionType ExtensionType, value []byte) []byte {
+		b := make([]byte, 4)
+		binary.LittleEndian.PutUint16(b[:2], uint16(extensionType))
+		binary.LittleEndian.PutUint16(b[2:], uint16(len(value)))
+		return append(b, value...)
+	}
+
+	account := make([]byte, TokenAccountSize)
+	copy(account[:32], mint.Bytes())
+	copy(account[32:64], owner.Bytes())
+	binary.LittleEndian.PutUint64(account[64:72], 100)
+	account[108] = byte(TokenAccountStateInitialized)
+	account = append(account, byte(AccountTypeAccount))
+	account = append(account, tlv(ExtensionTypeImmutableOwner, nil)...)
+	account = append(account, tlv(ExtensionTypeMemoTransfer, []byte{1})...)
+	account = append(account, tlv(ExtensionTypeTransferFeeAmount, []byte{5, 0, 0, 0, 0, 0, 0, 0})...)
+
+	mintData := make([]byte, TokenAccountSize)
+	mintData = append(mintData, byte(AccountTypeMint))
+	pointer := append(authority.Bytes(), mint.Bytes()...)
+	mintData = append(mintData, tlv(ExtensionTypeMetadataPointer, pointer)...)
+	// space reserved for extensions added later
+	mintData = append(mintData, make([]byte, 8)...)
+
+	type args struct {
+		data []byte
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantAccountType AccountType
+		want            []Extension
+		wantErr         bool
+	}{
+		{
+			name:            "token account without extensions",
+			args:            args{data: make([]byte, TokenAccountSize)},
+			wantAccountType: AccountTypeAccount,
+		},
+		{
+			name:            "mint without extensions",
+			args:            args{data: make([]byte, MintAccountSize)},
+			wantAccountType: AccountTypeMint,
+		},
+		{
+			name:            "token account",
+			args:            args{data: account},
+			wantAccountType: AccountTypeAccount,
+			want: []Extension{
+				{Type: ExtensionTypeImmutableOwner, Data: []byte{}},
+				{Type: ExtensionTypeMemoTransfer, Data: []byte{1}},
+				{Type: ExtensionTypeTransferFeeAmount, Data: []byte{5, 0, 0, 0, 0, 0, 0, 0}},
+			},
+		},
+		{
+			name:            "mint",
+			args:            args{data: mintData},
+			wantAccountType: AccountTypeMint,
+			want: []Extension{
+				{Type: ExtensionTypeMetadataPointer, Data: pointer},
+			},
+		},
+		{
+			name:    "truncated extension",
+			args:    args{data: account[:len(account)-1]},
+			wantErr: true,
+		},
+		{
+			name:    "multisig",
+			args:    args{data: make([]byte, MultisigAccountSize)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotAccountType, got, err := ExtensionsFromData(tt.args.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExtensionsFromData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotAccountType != tt.wantAccountType {
+				t.Errorf("ExtensionsFromData() account type = %v, want %v", gotAccountType, tt.wantAccountType)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtensionsFromData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	tokenAccount, err := TokenAccountFromData(account)
+	if err != nil {
+		t.Fatalf("TokenAccountFromData() error = %v", err)
+	}
+	if tokenAccount.Mint != mint || tokenAccount.Amount != 100 || len(tokenAccount.Extensions) != 3 {
+		t.Errorf("TokenAccountFromData() = %v", tokenAccount)
+	}
+	if _, err := TokenAccountFromData(mintData); err == nil {
+		t.Errorf("TokenAccountFromData() should fail on a mint")
+	}
+}
+
+func TestExtensionDecode(t *testing.T) {
+	authority := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	mint := common.PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH")
+	u64 := func(v uint64) []byte {
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, v)
+		return b
+	}
+	str := func(s string) []byte {
+		b := make([]byte, 4)
+		binary.LittleEndian.PutUint32(b, uint32(len(s)))
+		return append(b, s...)
+	}
+
+	transferFeeConfig := append(authority.Bytes(), make([]byte, 32)...)
+	transferFeeConfig = append(transferFeeConfig, u64(7)...)
+	transferFeeConfig = append(transferFeeConfig, u64(1)...)
+	transferFeeConfig = append(transferFeeConfig, u64(10)...)
+	transferFeeConfig = append(transferFeeConfig, 50, 0)
+	transferFeeConfig = append(transferFeeConfig, u64(5)...)
+	transferFeeConfig = append(transferFeeConfig, u64(1000)...)
+	transferFeeConfig = append(transferFeeConfig, 100, 0)
+
+	interestBearingConfig := append(authority.Bytes(), u64(1600000000)...)
+	interestBearingConfig = append(interestBearingConfig, 0xf6, 0xff)
+	interestBearingConfig = append(interestBearingConfig, u64(1600000001)...)
+	interestBearingConfig = append(interestBearingConfig, 0x0a, 0x00)
+
+	tokenMetadata := append(make([]byte, 32), mint.Bytes()...)
+	tokenMetadata = append(tokenMetadata, str("Token")...)
+	tokenMetadata = append(tokenMetadata, str("TKN")...)
+	tokenMetadata = append(tokenMetadata, str("https://example.com")...)
+	tokenMetadata = append(tokenMetadata, 1, 0, 0, 0)
+	tokenMetadata = append(tokenMetadata, str("key")...)
+	tokenMetadata = append(tokenMetadata, str("value")...)
+
+	tests := []struct {
+		name      string
+		extension Extension
+		want      interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "transfer fee config",
+			extension: Extension{Type: ExtensionTypeTransferFeeConfig, Data: transferFeeConfig},
+			want: TransferFeeConfig{
+				TransferFeeConfigAuthority: &authority,
+				WithheldAmount:             7,
+				OlderTransferFee:           TransferFee{Epoch: 1, MaximumFee: 10, TransferFeeBasisPoints: 50},
+				NewerTransferFee:           TransferFee{Epoch: 5, MaximumFee: 1000, TransferFeeBasisPoints: 100},
+			},
+		},
+		{
+			name:      "interest bearing config",
+			extension: Extension{Type: ExtensionTypeInterestBearingConfig, Data: interestBearingConfig},
+			want: InterestBearingConfig{
+				RateAuthority:           &authority,
+				InitializationTimestamp: 1600000000,
+				PreUpdateAverageRate:    -10,
+				LastUpdateTimestamp:     1600000001,
+				CurrentRate:             10,
+			},
+		},
+		{
+			name:      "memo transfer",
+			extension: Extension{Type: ExtensionTypeMemoTransfer, Data: []byte{1}},
+			want:      MemoTransfer{RequireIncomingTransferMemos: true},
+		},
+		{
+			name:      "immutable owner",
+			extension: Extension{Type: ExtensionTypeImmutableOwner, Data: []byte{}},
+			want:      ImmutableOwner{},
+		},
+		{
+			name:      "metadata pointer",
+			extension: Extension{Type: ExtensionTypeMetadataPointer, Data: append(make([]byte, 32), mint.Bytes()...)},
+			want:      MetadataPointer{MetadataAddress: &mint},
+		},
+		{
+			name:      "token metadata",
+			extension: Extension{Type: ExtensionTypeTokenMetadata, Data: tokenMetadata},
+			want: TokenMetadata{
+				Mint:               mint,
+				Name:               "Token",
+				Symbol:             "TKN",
+				Uri:                "https://example.com",
+				AdditionalMetadata: [][2]string{{"key", "value"}},
+			},
+		},
+		{
+			name:      "data size is not enough",
+			extension: Extension{Type: ExtensionTypeTransferFeeConfig, Data: transferFeeConfig[:100]},
+			wantErr:   true,
+		},
+		{
+			name:      "not supported",
+			extension: Extension{Type: ExtensionTypeConfidentialTransferMint},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.extension.Decode()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Extension.Decode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Extension.Decode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := (Extension{Type: ExtensionTypeTokenGroup}).Decode(); !errors.Is(err, ErrExtensionNotSupported) {
+		t.Errorf("Extension.Decode() error = %v, want %v", err, ErrExtensionNotSupported)
+	}
+}
+
+func TestTransferFeeConfigFee(t *testing.T) {
+	config := TransferFeeConfig{
+		OlderTransferFee: TransferFee{Epoch: 0, MaximumFee: 10, TransferFeeBasisPoints: 50},
+		NewerTransferFee: TransferFee{Epoch: 5, MaximumFee: 1 << 62, TransferFeeBasisPoints: 10000},
+	}
+	tests := []struct {
+		name   string
+		epoch  uint64
+		amount uint64
+		want   uint64
+	}{
+		{name: "rounds up", epoch: 1, amount: 201, want: 2},
+		{name: "maximum fee", epoch: 1, amount: 100000, want: 10},
+		{name: "newer fee", epoch: 5, amount: 3, want: 3},
+		{name: "no overflow", epoch: 5, amount: 1 << 63, want: 1 << 62},
+		{name: "zero amount", epoch: 5, amount: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := config.Fee(tt.epoch, tt.amount); got != tt.want {
+				t.Errorf("TransferFeeConfig.Fee() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,10 +1,13 @@
 package tokenprog
 
 import (
+	"github.com/portto/solana-go-sdk/borsh"
 	"github.com/portto/solana-go-sdk/common"
 	"github.com/portto/solana-go-sdk/types"
 )
 
+// Instruction is the instruction of the token program and Token-2022,
+// the builders take the program id so they work with both programs.
 type Instruction uint8
 
 const (
@@ -25,6 +28,32 @@ const (
 	InstructionMintToChecked
 	InstructionBurnChecked
 	InstructionInitializeAccount2
+	InstructionSyncNative
+	InstructionInitializeAccount3
+	InstructionInitializeMultisig2
+	InstructionInitializeMint2
+	InstructionGetAccountDataSize
+	InstructionInitializeImmutableOwner
+	InstructionAmountToUiAmount
+	InstructionUiAmountToAmount
+	// instructions below are only supported by Token-2022
+	InstructionInitializeMintCloseAuthority
+	InstructionTransferFeeExtension
+	InstructionConfidentialTransferExtension
+	InstructionDefaultAccountStateExtension
+	InstructionReallocate
+	InstructionMemoTransferExtension
+	InstructionCreateNativeMint
+	InstructionInitializeNonTransferableMint
+	InstructionInterestBearingMintExtension
+	InstructionCpiGuardExtension
+	InstructionInitializePermanentDelegate
+	InstructionTransferHookExtension
+	InstructionConfidentialTransferFeeExtension
+	InstructionWithdrawExcessLamports
+	InstructionMetadataPointerExtension
+	InstructionGroupPointerExtension
+	InstructionGroupMemberPointerExtension
 )
 
 type InitializeMintInstruction struct {
@@ -93,10 +122,87 @@ type InitializeAccount2Instruction struct {
 	Instruction Instruction
 	Owner       common.PublicKey
 }
+type SetAuthorityInstruction struct {
+	Instruction   Instruction
+	AuthorityType AuthorityType
+	NewAuthority  *common.PublicKey
+}
+type SyncNativeInstruction struct {
+	Instruction Instruction
+}
+type InitializeAccount3Instruction struct {
+	Instruction Instruction
+	Owner       common.PublicKey
+}
+type InitializeMultisig2Instruction struct {
+	Instruction     Instruction
+	MinimumRequired uint8
+}
+type InitializeMint2Instruction struct {
+	Instruction     Instruction
+	Decimals        uint8
+	MintAuthority   common.PublicKey
+	Option          bool
+	FreezeAuthority common.PublicKey
+}
+type GetAccountDataSizeInstruction struct {
+	Instruction    Instruction
+	ExtensionTypes []ExtensionType `borsh:"rest"`
+}
+type InitializeImmutableOwnerInstruction struct {
+	Instruction Instruction
+}
+type AmountToUiAmountInstruction struct {
+	Instruction Instruction
+	Amount      uint64
+}
+type UiAmountToAmountInstruction struct {
+	Instruction Instruction
+	UiAmount    string `borsh:"rest"`
+}
+
+type AuthorityType uint8
+
+const (
+	AuthorityTypeMintTokens AuthorityType = iota
+	AuthorityTypeFreezeAccount
+	AuthorityTypeAccountOwner
+	AuthorityTypeCloseAccount
+	// authority types below are only supported by Token-2022
+	AuthorityTypeTransferFeeConfig
+	AuthorityTypeWithheldWithdraw
+	AuthorityTypeCloseMint
+	AuthorityTypeInterestRate
+	AuthorityTypePermanentDelegate
+	AuthorityTypeConfidentialTransferMint
+	AuthorityTypeTransferHookProgramId
+	AuthorityTypeConfidentialTransferFeeConfig
+	AuthorityTypeMetadataPointer
+	AuthorityTypeGroupPointer
+	AuthorityTypeGroupMemberPointer
+)
+
+// pubkeyOption is the COption<Pubkey> of instruction data, an empty pubkey is none
+func pubkeyOption(pubkey common.PublicKey) *common.PublicKey {
+	if pubkey == (common.PublicKey{}) {
+		return nil
+	}
+	return &pubkey
+}
+
+// authorityAccounts are the single authority or the multisig authority and its signers
+func authorityAccounts(authPubkey common.PublicKey, signerPubkeys []common.PublicKey) []types.AccountMeta {
+	accounts := make([]types.AccountMeta, 0, 1+len(signerPubkeys))
+	accounts = append(accounts, types.AccountMeta{PubKey: authPubkey, IsSigner: len(signerPubkeys) == 0, IsWritable: false})
+	for _, signerPubkey := range signerPubkeys {
+		accounts = append(accounts, types.AccountMeta{PubKey: signerPubkey, IsSigner: true, IsWritable: false})
+	}
+	return accounts
+}
 
 // InitializeMint init a mint, if you don't need to freeze, pass the empty pubKey common.PublicKey{}
-func InitializeMint(decimals uint8, mint, mintAuthority common.PublicKey, freezeAuthority common.PublicKey) types.Instruction {
-	data, err := common.SerializeData(InitializeMintInstruction{
+func InitializeMint(programID common.PublicKey, decimals uint8, mint, mintAuthority common.PublicKey, freezeAuthority common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(InitializeMintInstruction{
 		Instruction:     InstructionInitializeMint,
 		Decimals:        decimals,
 		MintAuthority:   mintAuthority,
@@ -108,7 +214,7 @@ func InitializeMint(decimals uint8, mint, mintAuthority common.PublicKey, freeze
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID,
 		Accounts: []types.AccountMeta{
 			{PubKey: mint, IsSigner: false, IsWritable: true},
 			{PubKey: common.SysVarRentPubkey, IsSigner: false, IsWritable: false},
@@ -118,8 +224,8 @@ func InitializeMint(decimals uint8, mint, mintAuthority common.PublicKey, freeze
 }
 
 // InitializeAccount init a token account which can receive token
-func InitializeAccount(accountPublicKey, mintPublicKey, ownerPublickey common.PublicKey) types.Instruction {
-	data, err := common.SerializeData(InitializeAccountInstruction{
+func InitializeAccount(programID, accountPublicKey, mintPublicKey, ownerPublickey common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(InitializeAccountInstruction{
 		Instruction: InstructionInitializeAccount,
 	})
 	if err != nil {
@@ -133,13 +239,13 @@ func InitializeAccount(accountPublicKey, mintPublicKey, ownerPublickey common.Pu
 		{PubKey: common.SysVarRentPubkey, IsSigner: false, IsWritable: false},
 	}
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID,
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
-func InitializeMultisig(authPubkey common.PublicKey, signerPubkeys []common.PublicKey, miniRequired uint8) types.Instruction {
+func InitializeMultisig(programID, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, miniRequired uint8) types.Instruction {
 	if len(signerPubkeys) < 1 {
 		panic("minimum of signer is 1")
 	}
@@ -150,7 +256,7 @@ func InitializeMultisig(authPubkey common.PublicKey, signerPubkeys []common.Publ
 		panic("required number too big")
 	}
 
-	data, err := common.SerializeData(InitializeMultisigInstruction{
+	data, err := borsh.Marshal(InitializeMultisigInstruction{
 		Instruction:     InstructionInitializeMultisig,
 		MinimumRequired: miniRequired,
 	})
@@ -168,14 +274,14 @@ func InitializeMultisig(authPubkey common.PublicKey, signerPubkeys []common.Publ
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID,
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
-func Transfer(srcPubkey, destPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, amount uint64) types.Instruction {
-	data, err := common.SerializeData(TransferInstruction{
+func Transfer(programID, srcPubkey, destPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, amount uint64) types.Instruction {
+	data, err := borsh.Marshal(TransferInstruction{
 		Instruction: InstructionTransfer,
 		Amount:      amount,
 	})
@@ -191,14 +297,14 @@ func Transfer(srcPubkey, destPubkey, authPubkey common.PublicKey, signerPubkeys
 		accounts = append(accounts, types.AccountMeta{PubKey: signerPubkey, IsSigner: true, IsWritable: false})
 	}
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID,
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
-func Approve(sourcePubkey, delegatePubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, amount uint64) types.Instruction {
-	data, err := common.SerializeData(ApproveInstruction{
+func Approve(programID, sourcePubkey, delegatePubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, amount uint64) types.Instruction {
+	data, err := borsh.Marshal(ApproveInstruction{
 		Instruction: InstructionApprove,
 		Amount:      amount,
 	})
@@ -215,14 +321,14 @@ func Approve(sourcePubkey, delegatePubkey, authPubkey common.PublicKey, signerPu
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID,
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
-func Revoke(srcPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey) types.Instruction {
-	data, err := common.SerializeData(RevokeInstruction{
+func Revoke(programID, srcPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(RevokeInstruction{
 		Instruction: InstructionRevoke,
 	})
 	if err != nil {
@@ -239,18 +345,36 @@ func Revoke(srcPubkey, authPubkey common.PublicKey, signerPubkeys []common.Publi
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID,
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
-func SetAuthority() types.Instruction {
-	panic("not implement yet")
+// SetAuthority changes the authority of a mint or an account, pass common.PublicKey{} to remove the authority
+func SetAuthority(programID, accountPubkey, newAuthPubkey common.PublicKey, authType AuthorityType, authPubkey common.PublicKey, signerPubkeys []common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(SetAuthorityInstruction{
+		Instruction:   InstructionSetAuthority,
+		AuthorityType: authType,
+		NewAuthority:  pubkeyOption(newAuthPubkey),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 2+len(signerPubkeys))
+	accounts = append(accounts, types.AccountMeta{PubKey: accountPubkey, IsSigner: false, IsWritable: true})
+	accounts = append(accounts, authorityAccounts(authPubkey, signerPubkeys)...)
+
+	return types.Instruction{
+		ProgramID: programID,
+		Accounts:  accounts,
+		Data:      data,
+	}
 }
 
-func MintTo(mintPubkey, destPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, amount uint64) types.Instruction {
-	data, err := common.SerializeData(MintToInstruction{
+func MintTo(programID, mintPubkey, destPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, amount uint64) types.Instruction {
+	data, err := borsh.Marshal(MintToInstruction{
 		Instruction: InstructionMintTo,
 		Amount:      amount,
 	})
@@ -269,14 +393,14 @@ func MintTo(mintPubkey, destPubkey, authPubkey common.PublicKey, signerPubkeys [
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID,
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
-func Burn(accountPubkey, mintPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, amount uint64) types.Instruction {
-	data, err := common.SerializeData(BurnInstruction{
+func Burn(programID, accountPubkey, mintPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, amount uint64) types.Instruction {
+	data, err := borsh.Marshal(BurnInstruction{
 		Instruction: InstructionBurn,
 		Amount:      amount,
 	})
@@ -295,15 +419,15 @@ func Burn(accountPubkey, mintPubkey, authPubkey common.PublicKey, signerPubkeys
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID,
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
 // Close an account and transfer its all SOL to dest, only account's token balance is zero can be closed.
-func CloseAccount(accountPubkey, destPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey) types.Instruction {
-	data, err := common.SerializeData(CloseAccountInstruction{
+func CloseAccount(programID, accountPubkey, destPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(CloseAccountInstruction{
 		Instruction: InstructionCloseAccount,
 	})
 	if err != nil {
@@ -319,14 +443,14 @@ func CloseAccount(accountPubkey, destPubkey, authPubkey common.PublicKey, signer
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID,
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
-func FreezeAccount(accountPubkey, mintPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey) types.Instruction {
-	data, err := common.SerializeData(FreezeAccountInstruction{
+func FreezeAccount(programID, accountPubkey, mintPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(FreezeAccountInstruction{
 		Instruction: InstructionFreezeAccount,
 	})
 	if err != nil {
@@ -342,14 +466,14 @@ func FreezeAccount(accountPubkey, mintPubkey, authPubkey common.PublicKey, signe
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID,
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
-func ThawAccount(accountPubkey, mintPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey) types.Instruction {
-	data, err := common.SerializeData(ThawAccountInstruction{
+func ThawAccount(programID, accountPubkey, mintPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(ThawAccountInstruction{
 		Instruction: InstructionThawAccount,
 	})
 	if err != nil {
@@ -365,14 +489,14 @@ func ThawAccount(accountPubkey, mintPubkey, authPubkey common.PublicKey, signerP
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID,
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
-func TransferChecked(srcPubkey, destPubkey, mintPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, amount uint64, decimals uint8) types.Instruction {
-	data, err := common.SerializeData(TransferCheckedInstruction{
+func TransferChecked(programID, srcPubkey, destPubkey, mintPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, amount uint64, decimals uint8) types.Instruction {
+	data, err := borsh.Marshal(TransferCheckedInstruction{
 		Instruction: InstructionTransferChecked,
 		Amount:      amount,
 		Decimals:    decimals,
@@ -391,14 +515,14 @@ func TransferChecked(srcPubkey, destPubkey, mintPubkey, authPubkey common.Public
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID,
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
-func ApproveChecked(sourcePubkey, mintPubkey, delegatePubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, amount uint64, decimals uint8) types.Instruction {
-	data, err := common.SerializeData(ApproveCheckedInstruction{
+func ApproveChecked(programID, sourcePubkey, mintPubkey, delegatePubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, amount uint64, decimals uint8) types.Instruction {
+	data, err := borsh.Marshal(ApproveCheckedInstruction{
 		Instruction: InstructionApproveChecked,
 		Amount:      amount,
 		Decimals:    decimals,
@@ -417,14 +541,14 @@ func ApproveChecked(sourcePubkey, mintPubkey, delegatePubkey, authPubkey common.
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID,
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
-func MintToChecked(mintPubkey, destPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, amount uint64, decimals uint8) types.Instruction {
-	data, err := common.SerializeData(MintToCheckedInstruction{
+func MintToChecked(programID, mintPubkey, destPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, amount uint64, decimals uint8) types.Instruction {
+	data, err := borsh.Marshal(MintToCheckedInstruction{
 		Instruction: InstructionMintToChecked,
 		Amount:      amount,
 		Decimals:    decimals,
@@ -444,14 +568,14 @@ func MintToChecked(mintPubkey, destPubkey, authPubkey common.PublicKey, signerPu
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID,
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
-func BurnChecked(accountPubkey, mintPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, amount uint64, decimals uint8) types.Instruction {
-	data, err := common.SerializeData(BurnCheckedInstruction{
+func BurnChecked(programID, accountPubkey, mintPubkey, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, amount uint64, decimals uint8) types.Instruction {
+	data, err := borsh.Marshal(BurnCheckedInstruction{
 		Instruction: InstructionBurnChecked,
 		Amount:      amount,
 		Decimals:    decimals,
@@ -471,14 +595,14 @@ func BurnChecked(accountPubkey, mintPubkey, authPubkey common.PublicKey, signerP
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID,
 		Accounts:  accounts,
 		Data:      data,
 	}
 }
 
-func InitializeAccount2(accountPubkey, mintPubkey, ownerPubkey common.PublicKey) types.Instruction {
-	data, err := common.SerializeData(InitializeAccount2Instruction{
+func InitializeAccount2(programID, accountPubkey, mintPubkey, ownerPubkey common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(InitializeAccount2Instruction{
 		Instruction: InstructionInitializeAccount2,
 		Owner:       ownerPubkey,
 	})
@@ -487,7 +611,7 @@ func InitializeAccount2(accountPubkey, mintPubkey, ownerPubkey common.PublicKey)
 	}
 
 	return types.Instruction{
-		ProgramID: common.TokenProgramID,
+		ProgramID: programID,
 		Accounts: []types.AccountMeta{
 			{PubKey: accountPubkey, IsSigner: false, IsWritable: true},
 			{PubKey: mintPubkey, IsSigner: false, IsWritable: false},
@@ -496,3 +620,172 @@ func InitializeAccount2(accountPubkey, mintPubkey, ownerPubkey common.PublicKey)
 		Data: data,
 	}
 }
+
+// SyncNative updates the amount of a native token account to its lamports minus the rent exempt reserve
+func SyncNative(programID, accountPubkey common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(SyncNativeInstruction{
+		Instruction: InstructionSyncNative,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: programID,
+		Accounts: []types.AccountMeta{
+			{PubKey: accountPubkey, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+// InitializeAccount3 is InitializeAccount2 without the rent sysvar account
+func InitializeAccount3(programID, accountPubkey, mintPubkey, ownerPubkey common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(InitializeAccount3Instruction{
+		Instruction: InstructionInitializeAccount3,
+		Owner:       ownerPubkey,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: programID,
+		Accounts: []types.AccountMeta{
+			{PubKey: accountPubkey, IsSigner: false, IsWritable: true},
+			{PubKey: mintPubkey, IsSigner: false, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+// InitializeMultisig2 is InitializeMultisig without the rent sysvar account, the signers don't need to sign
+func InitializeMultisig2(programID, authPubkey common.PublicKey, signerPubkeys []common.PublicKey, miniRequired uint8) types.Instruction {
+	if len(signerPubkeys) < 1 {
+		panic("minimum of signer is 1")
+	}
+	if len(signerPubkeys) > MultisigMaxSigners {
+		panic("maximum of signer is 11")
+	}
+	if miniRequired > uint8(len(signerPubkeys)) {
+		panic("required number too big")
+	}
+
+	data, err := borsh.Marshal(InitializeMultisig2Instruction{
+		Instruction:     InstructionInitializeMultisig2,
+		MinimumRequired: miniRequired,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	accounts := make([]types.AccountMeta, 0, 1+len(signerPubkeys))
+	accounts = append(accounts, types.AccountMeta{PubKey: authPubkey, IsSigner: false, IsWritable: true})
+	for _, signerPubkey := range signerPubkeys {
+		accounts = append(accounts, types.AccountMeta{PubKey: signerPubkey, IsSigner: false, IsWritable: false})
+	}
+
+	return types.Instruction{
+		ProgramID: programID,
+		Accounts:  accounts,
+		Data:      data,
+	}
+}
+
+// InitializeMint2 is InitializeMint without the rent sysvar account
+func InitializeMint2(programID common.PublicKey, decimals uint8, mint, mintAuthority, freezeAuthority common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(InitializeMint2Instruction{
+		Instruction:     InstructionInitializeMint2,
+		Decimals:        decimals,
+		MintAuthority:   mintAuthority,
+		Option:          freezeAuthority != common.PublicKey{},
+		FreezeAuthority: freezeAuthority,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: programID,
+		Accounts: []types.AccountMeta{
+			{PubKey: mint, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+// GetAccountDataSize returns the size of a token account of the mint through the return data,
+// extension types are only supported by Token-2022
+func GetAccountDataSize(programID, mintPubkey common.PublicKey, extensionTypes []ExtensionType) types.Instruction {
+	data, err := borsh.Marshal(GetAccountDataSizeInstruction{
+		Instruction:    InstructionGetAccountDataSize,
+		ExtensionTypes: extensionTypes,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: programID,
+		Accounts: []types.AccountMeta{
+			{PubKey: mintPubkey, IsSigner: false, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+// InitializeImmutableOwner must be used before InitializeAccount
+func InitializeImmutableOwner(programID, accountPubkey common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(InitializeImmutableOwnerInstruction{
+		Instruction: InstructionInitializeImmutableOwner,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: programID,
+		Accounts: []types.AccountMeta{
+			{PubKey: accountPubkey, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+}
+
+// AmountToUiAmount returns the ui amount string of the amount through the return data
+func AmountToUiAmount(programID, mintPubkey common.PublicKey, amount uint64) types.Instruction {
+	data, err := borsh.Marshal(AmountToUiAmountInstruction{
+		Instruction: InstructionAmountToUiAmount,
+		Amount:      amount,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: programID,
+		Accounts: []types.AccountMeta{
+			{PubKey: mintPubkey, IsSigner: false, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+// UiAmountToAmount returns the amount of the ui amount string through the return data
+func UiAmountToAmount(programID, mintPubkey common.PublicKey, uiAmount string) types.Instruction {
+	data, err := borsh.Marshal(UiAmountToAmountInstruction{
+		Instruction: InstructionUiAmountToAmount,
+		UiAmount:    uiAmount,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: programID,
+		Accounts: []types.AccountMeta{
+			{PubKey: mintPubkey, IsSigner: false, IsWritable: false},
+		},
+		Data: data,
+	}
+}
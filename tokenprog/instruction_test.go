@@ -42,7 +42,7 @@ func TestMintTo(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := MintTo(tt.args.mintPubkey, tt.args.destPubkey, tt.args.authPubkey, tt.args.signerPubkeys, tt.args.amount); !reflect.DeepEqual(got, tt.want) {
+			if got := MintTo(common.TokenProgramID, tt.args.mintPubkey, tt.args.destPubkey, tt.args.authPubkey, tt.args.signerPubkeys, tt.args.amount); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("MintTo() = %v, want %v", got, tt.want)
 			}
 		})
@@ -85,7 +85,7 @@ func TestMintToChecked(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := MintToChecked(tt.args.mintPubkey, tt.args.destPubkey, tt.args.authPubkey, tt.args.signerPubkeys, tt.args.amount, tt.args.decimals); !reflect.DeepEqual(got, tt.want) {
+			if got := MintToChecked(common.TokenProgramID, tt.args.mintPubkey, tt.args.destPubkey, tt.args.authPubkey, tt.args.signerPubkeys, tt.args.amount, tt.args.decimals); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("MintToChecked() = %v, want %v", got, tt.want)
 			}
 		})
@@ -126,7 +126,7 @@ func TestTransfer(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := Transfer(tt.args.srcPubkey, tt.args.destPubkey, tt.args.authPubkey, tt.args.signerPubkeys, tt.args.amount); !reflect.DeepEqual(got, tt.want) {
+			if got := Transfer(common.TokenProgramID, tt.args.srcPubkey, tt.args.destPubkey, tt.args.authPubkey, tt.args.signerPubkeys, tt.args.amount); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Transfer() = %v, want %v", got, tt.want)
 			}
 		})
@@ -172,7 +172,7 @@ func TestTransferChecked(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := TransferChecked(tt.args.srcPubkey, tt.args.destPubkey, tt.args.mintPubkey, tt.args.authPubkey, tt.args.signerPubkeys, tt.args.amount, tt.args.decimals); !reflect.DeepEqual(got, tt.want) {
+			if got := TransferChecked(common.TokenProgramID, tt.args.srcPubkey, tt.args.destPubkey, tt.args.mintPubkey, tt.args.authPubkey, tt.args.signerPubkeys, tt.args.amount, tt.args.decimals); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("TransferChecked() = %v, want %v", got, tt.want)
 			}
 		})
@@ -213,7 +213,7 @@ func TestBurn(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := Burn(tt.args.accountPubkey, tt.args.mintPubkey, tt.args.authPubkey, tt.args.signerPubkeys, tt.args.amount); !reflect.DeepEqual(got, tt.want) {
+			if got := Burn(common.TokenProgramID, tt.args.accountPubkey, tt.args.mintPubkey, tt.args.authPubkey, tt.args.signerPubkeys, tt.args.amount); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Burn() = %v, want %v", got, tt.want)
 			}
 		})
@@ -256,7 +256,7 @@ func TestBurnChecked(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := BurnChecked(tt.args.accountPubkey, tt.args.mintPubkey, tt.args.authPubkey, tt.args.signerPubkeys, tt.args.amount, tt.args.decimals); !reflect.DeepEqual(got, tt.want) {
+			if got := BurnChecked(common.TokenProgramID, tt.args.accountPubkey, tt.args.mintPubkey, tt.args.authPubkey, tt.args.signerPubkeys, tt.args.amount, tt.args.decimals); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("BurnChecked() = %v, want %v", got, tt.want)
 			}
 		})
@@ -295,7 +295,7 @@ func TestCloseAccount(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := CloseAccount(tt.args.accountPubkey, tt.args.destPubkey, tt.args.authPubkey, tt.args.signerPubkeys); !reflect.DeepEqual(got, tt.want) {
+			if got := CloseAccount(common.TokenProgramID, tt.args.accountPubkey, tt.args.destPubkey, tt.args.authPubkey, tt.args.signerPubkeys); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("CloseAccount() = %v, want %v", got, tt.want)
 			}
 		})
@@ -332,7 +332,7 @@ func TestInitializeAccount2(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := InitializeAccount2(tt.args.accountPubkey, tt.args.mintPubkey, tt.args.ownerPubkey); !reflect.DeepEqual(got, tt.want) {
+			if got := InitializeAccount2(common.TokenProgramID, tt.args.accountPubkey, tt.args.mintPubkey, tt.args.ownerPubkey); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("InitializeAccount2() = %v, want %v", got, tt.want)
 			}
 		})
@@ -370,7 +370,7 @@ func TestFreezeAccount(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := FreezeAccount(tt.args.accountPubkey, tt.args.mintPubkey, tt.args.authPubkey, tt.args.signerPubkeys); !reflect.DeepEqual(got, tt.want) {
+			if got := FreezeAccount(common.TokenProgramID, tt.args.accountPubkey, tt.args.mintPubkey, tt.args.authPubkey, tt.args.signerPubkeys); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("FreezeAccount() = %v, want %v", got, tt.want)
 			}
 		})
@@ -408,7 +408,7 @@ func TestThawAccount(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := ThawAccount(tt.args.accountPubkey, tt.args.mintPubkey, tt.args.authPubkey, tt.args.signerPubkeys); !reflect.DeepEqual(got, tt.want) {
+			if got := ThawAccount(common.TokenProgramID, tt.args.accountPubkey, tt.args.mintPubkey, tt.args.authPubkey, tt.args.signerPubkeys); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("ThawAccount() = %v, want %v", got, tt.want)
 			}
 		})
@@ -449,7 +449,7 @@ func TestApprove(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := Approve(tt.args.sourcePubkey, tt.args.delegatePubkey, tt.args.authPubkey, tt.args.signerPubkeys, tt.args.amount); !reflect.DeepEqual(got, tt.want) {
+			if got := Approve(common.TokenProgramID, tt.args.sourcePubkey, tt.args.delegatePubkey, tt.args.authPubkey, tt.args.signerPubkeys, tt.args.amount); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Approve() = %v, want %v", got, tt.want)
 			}
 		})
@@ -484,7 +484,7 @@ func TestRevoke(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := Revoke(tt.args.srcPubkey, tt.args.authPubkey, tt.args.signerPubkeys); !reflect.DeepEqual(got, tt.want) {
+			if got := Revoke(common.TokenProgramID, tt.args.srcPubkey, tt.args.authPubkey, tt.args.signerPubkeys); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Revoke() = %v, want %v", got, tt.want)
 			}
 		})
@@ -530,7 +530,7 @@ func TestApproveChecked(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := ApproveChecked(tt.args.sourcePubkey, tt.args.mintPubkey, tt.args.delegatePubkey, tt.args.authPubkey, tt.args.signerPubkeys, tt.args.amount, tt.args.decimals); !reflect.DeepEqual(got, tt.want) {
+			if got := ApproveChecked(common.TokenProgramID, tt.args.sourcePubkey, tt.args.mintPubkey, tt.args.delegatePubkey, tt.args.authPubkey, tt.args.signerPubkeys, tt.args.amount, tt.args.decimals); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("ApproveChecked() = %v, want %v", got, tt.want)
 			}
 		})
@@ -574,9 +574,100 @@ func TestInitializeMultisig(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := InitializeMultisig(tt.args.authPubkey, tt.args.signerPubkeys, tt.args.miniRequired); !reflect.DeepEqual(got, tt.want) {
+			if got := InitializeMultisig(common.TokenProgramID, tt.args.authPubkey, tt.args.signerPubkeys, tt.args.miniRequired); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("InitializeMultisig() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestSetAuthority(t *testing.T) {
+	type args struct {
+		accountPubkey common.PublicKey
+		newAuthPubkey common.PublicKey
+		authType      AuthorityType
+		authPubkey    common.PublicKey
+		signerPubkeys []common.PublicKey
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				accountPubkey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+				newAuthPubkey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+				authType:      AuthorityTypeMintTokens,
+				authPubkey:    common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				signerPubkeys: []common.PublicKey{},
+			},
+			want: types.Instruction{
+				ProgramID: common.TokenProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: true, IsWritable: false},
+				},
+				Data: append([]byte{6, 0, 1}, common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ").Bytes()...),
+			},
+		},
+		{
+			name: "remove authority with multisig",
+			args: args{
+				accountPubkey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+				authType:      AuthorityTypeCloseAccount,
+				authPubkey:    common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				signerPubkeys: []common.PublicKey{common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")},
+			},
+			want: types.Instruction{
+				ProgramID: common.TokenProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: false, IsWritable: false},
+					{PubKey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"), IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{6, 3, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SetAuthority(common.TokenProgramID, tt.args.accountPubkey, tt.args.newAuthPubkey, tt.args.authType, tt.args.authPubkey, tt.args.signerPubkeys); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SetAuthority() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInitializeMint2(t *testing.T) {
+	mint := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	mintAuthority := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+
+	data := append([]byte{20, 9}, mintAuthority.Bytes()...)
+	data = append(data, 0)
+	data = append(data, make([]byte, 32)...)
+	want := types.Instruction{
+		ProgramID: common.Token2022ProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: mint, IsSigner: false, IsWritable: true},
+		},
+		Data: data,
+	}
+	if got := InitializeMint2(common.Token2022ProgramID, 9, mint, mintAuthority, common.PublicKey{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("InitializeMint2() = %v, want %v", got, want)
+	}
+}
+
+func TestUiAmountToAmount(t *testing.T) {
+	mint := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	want := types.Instruction{
+		ProgramID: common.TokenProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: mint, IsSigner: false, IsWritable: false},
+		},
+		Data: []byte{24, '1', '.', '5'},
+	}
+	if got := UiAmountToAmount(common.TokenProgramID, mint, "1.5"); !reflect.DeepEqual(got, want) {
+		t.Errorf("UiAmountToAmount() = %v, want %v", got, want)
+	}
+}
@@ -1,15 +1,51 @@
 package tokenprog
 
-import "github.com/portto/solana-go-sdk/common"
+import (
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+)
 
 const MintAccountSize = 82
 
+// MintAccount is token program mint account
 type MintAccount struct {
-	MintAuthorityOption   uint32
-	MintAuthority         common.PublicKey
-	Supply                uint64
-	Decimals              uint8
-	IsInitialized         bool
-	FreezeAuthorityOption uint32
-	FreezeAuthority       common.PublicKey
+	MintAuthority   *common.PublicKey `borsh:"coption"`
+	Supply          uint64
+	Decimals        uint8
+	IsInitialized   bool
+	FreezeAuthority *common.PublicKey `borsh:"coption"`
+	// Extensions of a Token-2022 mint, nil if it has none
+	Extensions []Extension `borsh:"skip"`
+}
+
+// MintAccountFromData decodes a mint of the token program or Token-2022, it fails on an uninitialized mint
+func MintAccountFromData(data []byte) (*MintAccount, error) {
+	if len(data) < MintAccountSize || len(data) == int(MultisigAccountSize) {
+		return nil, fmt.Errorf("data length not match")
+	}
+	accountType, extensions, err := ExtensionsFromData(data)
+	if err != nil {
+		return nil, err
+	}
+	if accountType != AccountTypeMint {
+		return nil, fmt.Errorf("account type not match")
+	}
+
+	var mint MintAccount
+	if err := borsh.Unmarshal(data, &mint); err != nil {
+		return nil, err
+	}
+	if !mint.IsInitialized {
+		return nil, ErrAccountNotInitialized
+	}
+	mint.Extensions = extensions
+	return &mint, nil
+}
+
+// Serialize encodes the mint, extensions are appended if they are not nil
+func (a MintAccount) Serialize() []byte {
+	data := borsh.MustMarshal(a)
+	return serializeExtensions(data, AccountTypeMint, a.Extensions)
 }
@@ -0,0 +1,96 @@
+package tokenprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+func TestMintAccountFromData(t *testing.T) {
+	mintAuthority := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	freezeAuthority := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+
+	data := append([]byte{1, 0, 0, 0}, mintAuthority.Bytes()...)
+	data = append(data, 0, 232, 118, 72, 23, 0, 0, 0, 9, 1)
+	data = append(data, 0, 0, 0, 0)
+	data = append(data, make([]byte, 32)...)
+
+	uninitialized := append([]byte{}, data...)
+	uninitialized[45] = 0
+
+	invalidOption := append([]byte{}, data...)
+	invalidOption[0] = 2
+
+	withExtensions := MintAccount{
+		MintAuthority:   &mintAuthority,
+		Supply:          1,
+		Decimals:        6,
+		IsInitialized:   true,
+		FreezeAuthority: &freezeAuthority,
+		Extensions: []Extension{
+			{Type: ExtensionTypeNonTransferable, Data: []byte{}},
+			{Type: ExtensionTypeMintCloseAuthority, Data: freezeAuthority.Bytes()},
+		},
+	}
+
+	type args struct {
+		data []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *MintAccount
+		wantErr bool
+	}{
+		{
+			args: args{data: data},
+			want: &MintAccount{
+				MintAuthority: &mintAuthority,
+				Supply:        100000000000,
+				Decimals:      9,
+				IsInitialized: true,
+			},
+		},
+		{
+			name: "with extensions",
+			args: args{data: withExtensions.Serialize()},
+			want: &withExtensions,
+		},
+		{
+			name:    "uninitialized",
+			args:    args{data: uninitialized},
+			wantErr: true,
+		},
+		{
+			name:    "invalid option",
+			args:    args{data: invalidOption},
+			wantErr: true,
+		},
+		{
+			name:    "data length not match",
+			args:    args{data: data[:81]},
+			wantErr: true,
+		},
+		{
+			name:    "token account",
+			args:    args{data: make([]byte, TokenAccountSize)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MintAccountFromData(tt.args.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MintAccountFromData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MintAccountFromData() = %v, want %v", got, tt.want)
+			}
+			if got != nil && !reflect.DeepEqual(got.Serialize(), tt.args.data) {
+				t.Errorf("MintAccount.Serialize() = %v, want %v", got.Serialize(), tt.args.data)
+			}
+		})
+	}
+}
@@ -1,3 +1,68 @@
 package tokenprog
 
+import (
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+)
+
 const MultisigAccountSize uint64 = 355
+
+// MultisigMaxSigners is the maximum number of signers of a multisig
+const MultisigMaxSigners = 11
+
+// MultisigAccount is token program multisig account, M of the N signers have to sign
+type MultisigAccount struct {
+	M             uint8
+	N             uint8
+	IsInitialized bool
+	Signers       []common.PublicKey
+}
+
+// multisigLayout is the data of a multisig, the signer slots after N are zero
+type multisigLayout struct {
+	M             uint8
+	N             uint8
+	IsInitialized bool
+	Signers       [MultisigMaxSigners]common.PublicKey
+}
+
+// MultisigAccountFromData decodes a multisig, it fails on an uninitialized multisig
+func MultisigAccountFromData(data []byte) (*MultisigAccount, error) {
+	if len(data) != int(MultisigAccountSize) {
+		return nil, fmt.Errorf("data length not match")
+	}
+
+	var layout multisigLayout
+	if err := borsh.Unmarshal(data, &layout); err != nil {
+		return nil, err
+	}
+	if !layout.IsInitialized {
+		return nil, ErrAccountNotInitialized
+	}
+	if layout.N > MultisigMaxSigners || layout.M > layout.N {
+		return nil, fmt.Errorf("invalid multisig %d of %d", layout.M, layout.N)
+	}
+
+	signers := make([]common.PublicKey, 0, layout.N)
+	signers = append(signers, layout.Signers[:layout.N]...)
+
+	return &MultisigAccount{
+		M:             layout.M,
+		N:             layout.N,
+		IsInitialized: layout.IsInitialized,
+		Signers:       signers,
+	}, nil
+}
+
+// Serialize encodes the multisig, the unused signer slots are zero
+func (a MultisigAccount) Serialize() []byte {
+	layout := multisigLayout{
+		M:             a.M,
+		N:             a.N,
+		IsInitialized: a.IsInitialized,
+	}
+	copy(layout.Signers[:], a.Signers)
+	return borsh.MustMarshal(layout)
+}
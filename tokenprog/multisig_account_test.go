@@ -0,0 +1,76 @@
+package tokenprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+func TestMultisigAccountFromData(t *testing.T) {
+	signers := []common.PublicKey{
+		common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+		common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+	}
+
+	data := []byte{1, 2, 1}
+	data = append(data, signers[0].Bytes()...)
+	data = append(data, signers[1].Bytes()...)
+	data = append(data, make([]byte, int(MultisigAccountSize)-len(data))...)
+
+	uninitialized := append([]byte{}, data...)
+	uninitialized[2] = 0
+
+	invalid := append([]byte{}, data...)
+	invalid[0] = 3
+
+	type args struct {
+		data []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *MultisigAccount
+		wantErr bool
+	}{
+		{
+			args: args{data: data},
+			want: &MultisigAccount{
+				M:             1,
+				N:             2,
+				IsInitialized: true,
+				Signers:       signers,
+			},
+		},
+		{
+			name:    "uninitialized",
+			args:    args{data: uninitialized},
+			wantErr: true,
+		},
+		{
+			name:    "m is greater than n",
+			args:    args{data: invalid},
+			wantErr: true,
+		},
+		{
+			name:    "data length not match",
+			args:    args{data: data[:100]},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MultisigAccountFromData(tt.args.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MultisigAccountFromData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MultisigAccountFromData() = %v, want %v", got, tt.want)
+			}
+			if got != nil && !reflect.DeepEqual(got.Serialize(), tt.args.data) {
+				t.Errorf("MultisigAccount.Serialize() = %v, want %v", got.Serialize(), tt.args.data)
+			}
+		})
+	}
+}
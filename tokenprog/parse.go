@@ -4,23 +4,80 @@ import (
 	"fmt"
 	"math"
 
-	"github.com/ghostiam/binstruct"
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
 	"github.com/portto/solana-go-sdk/types"
 )
 
+func init() {
+	types.RegisterInstructionParser(common.TokenProgramID, ParseToken)
+	types.RegisterInstructionParser(common.Token2022ProgramID, ParseToken)
+}
+
+// instructionMinAccounts is the number of accounts an instruction needs to be parsed,
+// the signers of a multisig authority are not counted
+var instructionMinAccounts = map[Instruction]int{
+	InstructionInitializeMint:           2,
+	InstructionInitializeAccount:        4,
+	InstructionInitializeMultisig:       2,
+	InstructionTransfer:                 3,
+	InstructionApprove:                  3,
+	InstructionRevoke:                   2,
+	InstructionSetAuthority:             2,
+	InstructionMintTo:                   3,
+	InstructionBurn:                     3,
+	InstructionCloseAccount:             3,
+	InstructionFreezeAccount:            3,
+	InstructionThawAccount:              3,
+	InstructionTransferChecked:          4,
+	InstructionApproveChecked:           4,
+	InstructionMintToChecked:            3,
+	InstructionBurnChecked:              3,
+	InstructionInitializeAccount2:       3,
+	InstructionSyncNative:               1,
+	InstructionInitializeAccount3:       2,
+	InstructionInitializeMultisig2:      1,
+	InstructionInitializeMint2:          1,
+	InstructionGetAccountDataSize:       1,
+	InstructionInitializeImmutableOwner: 1,
+	InstructionAmountToUiAmount:         1,
+	InstructionUiAmountToAmount:         1,
+	// the accounts of the extension instructions are checked by their parsers
+	InstructionInitializeMintCloseAuthority:  1,
+	InstructionTransferFeeExtension:          1,
+	InstructionDefaultAccountStateExtension:  1,
+	InstructionReallocate:                    4,
+	InstructionMemoTransferExtension:         2,
+	InstructionInitializeNonTransferableMint: 1,
+	InstructionInterestBearingMintExtension:  1,
+	InstructionCpiGuardExtension:             2,
+	InstructionInitializePermanentDelegate:   1,
+	InstructionTransferHookExtension:         1,
+	InstructionMetadataPointerExtension:      1,
+	InstructionGroupPointerExtension:         1,
+	InstructionGroupMemberPointerExtension:   1,
+}
+
+// ParseToken decodes instructions of the token program and Token-2022
 func ParseToken(ins types.Instruction) (types.ParsedInstruction, error) {
 	var parsedInstruction types.ParsedInstruction
 	var err error
 	var s struct {
 		Instruction Instruction
 	}
-	err = binstruct.UnmarshalLE(ins.Data, &s)
+	err = borsh.Unmarshal(ins.Data, &s)
+	if err != nil {
+		return parsedInstruction, err
+	}
+	if minAccounts := instructionMinAccounts[s.Instruction]; len(ins.Accounts) < minAccounts {
+		return parsedInstruction, fmt.Errorf("token instruction %d needs %d accounts, got %d", s.Instruction, minAccounts, len(ins.Accounts))
+	}
 	var instructionType string
 	var parsedInfo map[string]interface{}
 	switch s.Instruction {
 	case InstructionInitializeMint:
 		var a InitializeMintInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "initializeMint"
 		parsedInfo = map[string]interface{}{
 			"mint":            ins.Accounts[0].PubKey.ToBase58(),
@@ -32,7 +89,7 @@ func ParseToken(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionInitializeAccount:
 		var a InitializeAccountInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "initializeAccount"
 		parsedInfo = map[string]interface{}{
 			"account":    ins.Accounts[0].PubKey.ToBase58(),
@@ -42,8 +99,8 @@ func ParseToken(ins types.Instruction) (types.ParsedInstruction, error) {
 		}
 		break
 	case InstructionInitializeMultisig:
-		var a TransferInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		var a InitializeMultisigInstruction
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "initializeMultisig"
 		var signers []string
 		for _, v := range ins.Accounts[2:] {
@@ -53,12 +110,12 @@ func ParseToken(ins types.Instruction) (types.ParsedInstruction, error) {
 			"multisig":   ins.Accounts[0].PubKey.ToBase58(),
 			"rentSysvar": ins.Accounts[1].PubKey.ToBase58(),
 			"signers":    signers,
-			"m":          a.Amount,
+			"m":          a.MinimumRequired,
 		}
 		break
 	case InstructionTransfer:
 		var a TransferInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "transfer"
 		parsedInfo = map[string]interface{}{
 			"source":      ins.Accounts[0].PubKey.ToBase58(),
@@ -69,7 +126,7 @@ func ParseToken(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionApprove:
 		var a ApproveInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "approve"
 		parsedInfo = map[string]interface{}{
 			"source":   ins.Accounts[0].PubKey.ToBase58(),
@@ -80,7 +137,7 @@ func ParseToken(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionRevoke:
 		var a RevokeInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "revoke"
 		parsedInfo = map[string]interface{}{
 			"source": ins.Accounts[0].PubKey.ToBase58(),
@@ -88,11 +145,25 @@ func ParseToken(ins types.Instruction) (types.ParsedInstruction, error) {
 		parsedInfo = parse_signers(parsedInfo, 1, ins.Accounts, "owner", "multisigOwner")
 
 		break
-	//case InstructionSetAuthority:
-	//	break
+	case InstructionSetAuthority:
+		var a SetAuthorityInstruction
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "setAuthority"
+		var newAuthority interface{}
+		if a.NewAuthority != nil {
+			newAuthority = a.NewAuthority.ToBase58()
+		}
+		parsedInfo = map[string]interface{}{
+			a.AuthorityType.owned(): ins.Accounts[0].PubKey.ToBase58(),
+			"authorityType":         a.AuthorityType.String(),
+			"newAuthority":          newAuthority,
+		}
+		parsedInfo = parse_signers(parsedInfo, 1, ins.Accounts, "authority", "multisigAuthority")
+
+		break
 	case InstructionMintTo:
 		var a MintToInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "mintTo"
 		parsedInfo = map[string]interface{}{
 			"mint":    ins.Accounts[0].PubKey.ToBase58(),
@@ -104,7 +175,7 @@ func ParseToken(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionCloseAccount:
 		var a CloseAccountInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "closeAccount"
 		parsedInfo = map[string]interface{}{
 			"account":     ins.Accounts[0].PubKey.ToBase58(),
@@ -115,7 +186,7 @@ func ParseToken(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionFreezeAccount:
 		var a FreezeAccountInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "freezeAccount"
 		parsedInfo = map[string]interface{}{
 			"account": ins.Accounts[0].PubKey.ToBase58(),
@@ -126,7 +197,7 @@ func ParseToken(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionThawAccount:
 		var a ThawAccountInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "thawAccount"
 		parsedInfo = map[string]interface{}{
 			"account": ins.Accounts[0].PubKey.ToBase58(),
@@ -137,7 +208,7 @@ func ParseToken(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionTransferChecked:
 		var a TransferCheckedInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "transferChecked"
 		parsedInfo = map[string]interface{}{
 			"source":      ins.Accounts[0].PubKey.ToBase58(),
@@ -150,7 +221,7 @@ func ParseToken(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionApproveChecked:
 		var a ApproveCheckedInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "approveChecked"
 		parsedInfo = map[string]interface{}{
 			"account":     ins.Accounts[0].PubKey.ToBase58(),
@@ -163,7 +234,7 @@ func ParseToken(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionMintToChecked:
 		var a MintToCheckedInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "mintToChecked"
 		parsedInfo = map[string]interface{}{
 			"account":     ins.Accounts[0].PubKey.ToBase58(),
@@ -175,7 +246,7 @@ func ParseToken(ins types.Instruction) (types.ParsedInstruction, error) {
 		break
 	case InstructionBurnChecked:
 		var a BurnCheckedInstruction
-		err = binstruct.UnmarshalLE(ins.Data, &a)
+		err = borsh.Unmarshal(ins.Data, &a)
 		instructionType = "burnChecked"
 		parsedInfo = map[string]interface{}{
 			"account":     ins.Accounts[0].PubKey.ToBase58(),
@@ -185,6 +256,180 @@ func ParseToken(ins types.Instruction) (types.ParsedInstruction, error) {
 		parsedInfo = parse_signers(parsedInfo, 2, ins.Accounts, "authority", "multisigAuthority")
 
 		break
+	case InstructionBurn:
+		var a BurnInstruction
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "burn"
+		parsedInfo = map[string]interface{}{
+			"account": ins.Accounts[0].PubKey.ToBase58(),
+			"mint":    ins.Accounts[1].PubKey.ToBase58(),
+			"amount":  a.Amount,
+		}
+		parsedInfo = parse_signers(parsedInfo, 2, ins.Accounts, "authority", "multisigAuthority")
+
+		break
+	case InstructionInitializeAccount2:
+		var a InitializeAccount2Instruction
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "initializeAccount2"
+		parsedInfo = map[string]interface{}{
+			"account":    ins.Accounts[0].PubKey.ToBase58(),
+			"mint":       ins.Accounts[1].PubKey.ToBase58(),
+			"owner":      a.Owner.ToBase58(),
+			"rentSysvar": ins.Accounts[2].PubKey.ToBase58(),
+		}
+		break
+	case InstructionSyncNative:
+		instructionType = "syncNative"
+		parsedInfo = map[string]interface{}{
+			"account": ins.Accounts[0].PubKey.ToBase58(),
+		}
+		break
+	case InstructionInitializeAccount3:
+		var a InitializeAccount3Instruction
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "initializeAccount3"
+		parsedInfo = map[string]interface{}{
+			"account": ins.Accounts[0].PubKey.ToBase58(),
+			"mint":    ins.Accounts[1].PubKey.ToBase58(),
+			"owner":   a.Owner.ToBase58(),
+		}
+		break
+	case InstructionInitializeMultisig2:
+		var a InitializeMultisig2Instruction
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "initializeMultisig2"
+		signers := []string{}
+		for _, v := range ins.Accounts[1:] {
+			signers = append(signers, v.PubKey.ToBase58())
+		}
+		parsedInfo = map[string]interface{}{
+			"multisig": ins.Accounts[0].PubKey.ToBase58(),
+			"signers":  signers,
+			"m":        a.MinimumRequired,
+		}
+		break
+	case InstructionInitializeMint2:
+		var a InitializeMint2Instruction
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "initializeMint2"
+		parsedInfo = map[string]interface{}{
+			"mint":          ins.Accounts[0].PubKey.ToBase58(),
+			"decimals":      a.Decimals,
+			"mintAuthority": a.MintAuthority.ToBase58(),
+		}
+		if a.Option {
+			parsedInfo["freezeAuthority"] = a.FreezeAuthority.ToBase58()
+		}
+		break
+	case InstructionGetAccountDataSize:
+		instructionType = "getAccountDataSize"
+		parsedInfo = map[string]interface{}{
+			"mint": ins.Accounts[0].PubKey.ToBase58(),
+		}
+		var a GetAccountDataSizeInstruction
+		err = borsh.Unmarshal(ins.Data, &a)
+		if err != nil {
+			err = fmt.Errorf("invalid extension types")
+			break
+		}
+		if len(a.ExtensionTypes) > 0 {
+			extensionTypes := []string{}
+			for _, extensionType := range a.ExtensionTypes {
+				extensionTypes = append(extensionTypes, extensionType.String())
+			}
+			parsedInfo["extensionTypes"] = extensionTypes
+		}
+		break
+	case InstructionInitializeImmutableOwner:
+		instructionType = "initializeImmutableOwner"
+		parsedInfo = map[string]interface{}{
+			"account": ins.Accounts[0].PubKey.ToBase58(),
+		}
+		break
+	case InstructionAmountToUiAmount:
+		var a AmountToUiAmountInstruction
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "amountToUiAmount"
+		parsedInfo = map[string]interface{}{
+			"mint":   ins.Accounts[0].PubKey.ToBase58(),
+			"amount": fmt.Sprint(a.Amount),
+		}
+		break
+	case InstructionUiAmountToAmount:
+		var a UiAmountToAmountInstruction
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "uiAmountToAmount"
+		parsedInfo = map[string]interface{}{
+			"mint":     ins.Accounts[0].PubKey.ToBase58(),
+			"uiAmount": a.UiAmount,
+		}
+		break
+	case InstructionTransferFeeExtension:
+		instructionType, parsedInfo, err = parseTransferFee(ins)
+	case InstructionInitializeMintCloseAuthority:
+		var a struct {
+			Instruction    Instruction
+			CloseAuthority *common.PublicKey
+		}
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "initializeMintCloseAuthority"
+		parsedInfo = map[string]interface{}{
+			"mint":         ins.Accounts[0].PubKey.ToBase58(),
+			"newAuthority": optionalPubkeyInfo(a.CloseAuthority),
+		}
+	case InstructionDefaultAccountStateExtension:
+		instructionType, parsedInfo, err = parseDefaultAccountState(ins)
+	case InstructionReallocate:
+		var a struct {
+			Instruction    Instruction
+			ExtensionTypes []ExtensionType `borsh:"rest"`
+		}
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "reallocate"
+		extensionTypes := []string{}
+		for _, extensionType := range a.ExtensionTypes {
+			extensionTypes = append(extensionTypes, extensionType.String())
+		}
+		parsedInfo = map[string]interface{}{
+			"account":        ins.Accounts[0].PubKey.ToBase58(),
+			"payer":          ins.Accounts[1].PubKey.ToBase58(),
+			"systemProgram":  ins.Accounts[2].PubKey.ToBase58(),
+			"extensionTypes": extensionTypes,
+		}
+		parsedInfo = parse_signers(parsedInfo, 3, ins.Accounts, "owner", "multisigOwner")
+	case InstructionMemoTransferExtension:
+		instructionType, parsedInfo, err = parseMemoTransfer(ins)
+	case InstructionInitializeNonTransferableMint:
+		instructionType = "initializeNonTransferableMint"
+		parsedInfo = map[string]interface{}{
+			"mint": ins.Accounts[0].PubKey.ToBase58(),
+		}
+	case InstructionInterestBearingMintExtension:
+		instructionType, parsedInfo, err = parseInterestBearingMint(ins)
+	case InstructionCpiGuardExtension:
+		instructionType, parsedInfo, err = parseCpiGuard(ins)
+	case InstructionInitializePermanentDelegate:
+		var a struct {
+			Instruction Instruction
+			Delegate    common.PublicKey
+		}
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "initializePermanentDelegate"
+		parsedInfo = map[string]interface{}{
+			"mint":     ins.Accounts[0].PubKey.ToBase58(),
+			"delegate": a.Delegate.ToBase58(),
+		}
+	case InstructionTransferHookExtension:
+		instructionType, parsedInfo, err = parsePointer(ins, "TransferHook", "programId")
+	case InstructionMetadataPointerExtension:
+		instructionType, parsedInfo, err = parsePointer(ins, "MetadataPointer", "metadataAddress")
+	case InstructionGroupPointerExtension:
+		instructionType, parsedInfo, err = parsePointer(ins, "GroupPointer", "groupAddress")
+	case InstructionGroupMemberPointerExtension:
+		instructionType, parsedInfo, err = parsePointer(ins, "GroupMemberPointer", "memberAddress")
+	default:
+		return parsedInstruction, fmt.Errorf("unsupported token instruction %d", s.Instruction)
 	}
 	parsedInstruction.Parsed = &types.InstructionInfo{
 		Info:            parsedInfo,
@@ -193,6 +438,348 @@ func ParseToken(ins types.Instruction) (types.ParsedInstruction, error) {
 	return parsedInstruction, err
 }
 
+var authorityTypeNames = map[AuthorityType]string{
+	AuthorityTypeMintTokens:                    "mintTokens",
+	AuthorityTypeFreezeAccount:                 "freezeAccount",
+	AuthorityTypeAccountOwner:                  "accountOwner",
+	AuthorityTypeCloseAccount:                  "closeAccount",
+	AuthorityTypeTransferFeeConfig:             "transferFeeConfig",
+	AuthorityTypeWithheldWithdraw:              "withheldWithdraw",
+	AuthorityTypeCloseMint:                     "closeMint",
+	AuthorityTypeInterestRate:                  "interestRate",
+	AuthorityTypePermanentDelegate:             "permanentDelegate",
+	AuthorityTypeConfidentialTransferMint:      "confidentialTransferMint",
+	AuthorityTypeTransferHookProgramId:         "transferHookProgramId",
+	AuthorityTypeConfidentialTransferFeeConfig: "confidentialTransferFeeConfig",
+	AuthorityTypeMetadataPointer:               "metadataPointer",
+	AuthorityTypeGroupPointer:                  "groupPointer",
+	AuthorityTypeGroupMemberPointer:            "groupMemberPointer",
+}
+
+var tokenAccountStateNames = map[TokenAccountState]string{
+	TokenAccountStateUninitialized: "uninitialized",
+	TokenAccountStateInitialized:   "initialized",
+	TokenAccountFrozen:             "frozen",
+}
+
+func (s TokenAccountState) String() string {
+	if name, exist := tokenAccountStateNames[s]; exist {
+		return name
+	}
+	return fmt.Sprintf("TokenAccountState(%d)", uint8(s))
+}
+
+func (t AuthorityType) String() string {
+	if name, exist := authorityTypeNames[t]; exist {
+		return name
+	}
+	return fmt.Sprintf("AuthorityType(%d)", uint8(t))
+}
+
+// owned is the name of the account whose authority is set, only the account owner and
+// the close authority belong to a token account
+func (t AuthorityType) owned() string {
+	if t == AuthorityTypeAccountOwner || t == AuthorityTypeCloseAccount {
+		return "account"
+	}
+	return "mint"
+}
+
+// needAccounts checks the accounts of an extension instruction, whose number depends on the instruction of the extension
+func needAccounts(ins types.Instruction, n int, instructionType string) error {
+	if len(ins.Accounts) < n {
+		return fmt.Errorf("%v needs %d accounts, got %d", instructionType, n, len(ins.Accounts))
+	}
+	return nil
+}
+
+// optionalPubkeyInfo is the base58 of the pubkey, nil if it is none
+func optionalPubkeyInfo(pubkey *common.PublicKey) interface{} {
+	if pubkey == nil {
+		return nil
+	}
+	return pubkey.ToBase58()
+}
+
+func parseTransferFee(ins types.Instruction) (string, map[string]interface{}, error) {
+	var s struct {
+		Instruction            Instruction
+		TransferFeeInstruction TransferFeeInstruction
+	}
+	if err := borsh.Unmarshal(ins.Data, &s); err != nil {
+		return "", nil, err
+	}
+	switch s.TransferFeeInstruction {
+	case TransferFeeInstructionInitializeTransferFeeConfig:
+		var a struct {
+			Instruction                Instruction
+			TransferFeeInstruction     TransferFeeInstruction
+			TransferFeeConfigAuthority *common.PublicKey
+			WithdrawWithheldAuthority  *common.PublicKey
+			TransferFeeBasisPoints     uint16
+			MaximumFee                 uint64
+		}
+		if err := borsh.Unmarshal(ins.Data, &a); err != nil {
+			return "", nil, err
+		}
+		return "initializeTransferFeeConfig", map[string]interface{}{
+			"mint":                       ins.Accounts[0].PubKey.ToBase58(),
+			"transferFeeConfigAuthority": optionalPubkeyInfo(a.TransferFeeConfigAuthority),
+			"withdrawWithheldAuthority":  optionalPubkeyInfo(a.WithdrawWithheldAuthority),
+			"transferFeeBasisPoints":     a.TransferFeeBasisPoints,
+			"maximumFee":                 a.MaximumFee,
+		}, nil
+	case TransferFeeInstructionTransferCheckedWithFee:
+		if err := needAccounts(ins, 4, "transferCheckedWithFee"); err != nil {
+			return "", nil, err
+		}
+		var a struct {
+			Instruction            Instruction
+			TransferFeeInstruction TransferFeeInstruction
+			Amount                 uint64
+			Decimals               uint8
+			Fee                    uint64
+		}
+		if err := borsh.Unmarshal(ins.Data, &a); err != nil {
+			return "", nil, err
+		}
+		info := map[string]interface{}{
+			"source":      ins.Accounts[0].PubKey.ToBase58(),
+			"mint":        ins.Accounts[1].PubKey.ToBase58(),
+			"destination": ins.Accounts[2].PubKey.ToBase58(),
+			"tokenAmount": tokenAmountToUiAmount(a.Amount, a.Decimals),
+			"feeAmount":   tokenAmountToUiAmount(a.Fee, a.Decimals),
+		}
+		return "transferCheckedWithFee", parse_signers(info, 3, ins.Accounts, "authority", "multisigAuthority"), nil
+	case TransferFeeInstructionWithdrawWithheldTokensFromMint:
+		if err := needAccounts(ins, 3, "withdrawWithheldTokensFromMint"); err != nil {
+			return "", nil, err
+		}
+		info := map[string]interface{}{
+			"mint":         ins.Accounts[0].PubKey.ToBase58(),
+			"feeRecipient": ins.Accounts[1].PubKey.ToBase58(),
+		}
+		return "withdrawWithheldTokensFromMint", parse_signers(info, 2, ins.Accounts, "withdrawWithheldAuthority", "multisigWithdrawWithheldAuthority"), nil
+	case TransferFeeInstructionWithdrawWithheldTokensFromAccounts:
+		var a struct {
+			Instruction            Instruction
+			TransferFeeInstruction TransferFeeInstruction
+			NumTokenAccounts       uint8
+		}
+		if err := borsh.Unmarshal(ins.Data, &a); err != nil {
+			return "", nil, err
+		}
+		if err := needAccounts(ins, 3+int(a.NumTokenAccounts), "withdrawWithheldTokensFromAccounts"); err != nil {
+			return "", nil, err
+		}
+		// the source accounts come after the signers
+		sourceStart := len(ins.Accounts) - int(a.NumTokenAccounts)
+		sourceAccounts := []string{}
+		for _, v := range ins.Accounts[sourceStart:] {
+			sourceAccounts = append(sourceAccounts, v.PubKey.ToBase58())
+		}
+		info := map[string]interface{}{
+			"mint":           ins.Accounts[0].PubKey.ToBase58(),
+			"feeRecipient":   ins.Accounts[1].PubKey.ToBase58(),
+			"sourceAccounts": sourceAccounts,
+		}
+		return "withdrawWithheldTokensFromAccounts", parse_signers(info, 2, ins.Accounts[:sourceStart], "withdrawWithheldAuthority", "multisigWithdrawWithheldAuthority"), nil
+	case TransferFeeInstructionHarvestWithheldTokensToMint:
+		sourceAccounts := []string{}
+		for _, v := range ins.Accounts[1:] {
+			sourceAccounts = append(sourceAccounts, v.PubKey.ToBase58())
+		}
+		return "harvestWithheldTokensToMint", map[string]interface{}{
+			"mint":           ins.Accounts[0].PubKey.ToBase58(),
+			"sourceAccounts": sourceAccounts,
+		}, nil
+	case TransferFeeInstructionSetTransferFee:
+		if err := needAccounts(ins, 2, "setTransferFee"); err != nil {
+			return "", nil, err
+		}
+		var a struct {
+			Instruction            Instruction
+			TransferFeeInstruction TransferFeeInstruction
+			TransferFeeBasisPoints uint16
+			MaximumFee             uint64
+		}
+		if err := borsh.Unmarshal(ins.Data, &a); err != nil {
+			return "", nil, err
+		}
+		info := map[string]interface{}{
+			"mint":                   ins.Accounts[0].PubKey.ToBase58(),
+			"transferFeeBasisPoints": a.TransferFeeBasisPoints,
+			"maximumFee":             a.MaximumFee,
+		}
+		return "setTransferFee", parse_signers(info, 1, ins.Accounts, "transferFeeConfigAuthority", "multisigTransferFeeConfigAuthority"), nil
+	}
+	return "", nil, fmt.Errorf("unsupported transfer fee instruction %d", s.TransferFeeInstruction)
+}
+
+func parseDefaultAccountState(ins types.Instruction) (string, map[string]interface{}, error) {
+	var a struct {
+		Instruction                    Instruction
+		DefaultAccountStateInstruction DefaultAccountStateInstruction
+		State                          TokenAccountState
+	}
+	if err := borsh.Unmarshal(ins.Data, &a); err != nil {
+		return "", nil, err
+	}
+	info := map[string]interface{}{
+		"mint":         ins.Accounts[0].PubKey.ToBase58(),
+		"accountState": a.State.String(),
+	}
+	switch a.DefaultAccountStateInstruction {
+	case DefaultAccountStateInstructionInitialize:
+		return "initializeDefaultAccountState", info, nil
+	case DefaultAccountStateInstructionUpdate:
+		if err := needAccounts(ins, 2, "updateDefaultAccountState"); err != nil {
+			return "", nil, err
+		}
+		return "updateDefaultAccountState", parse_signers(info, 1, ins.Accounts, "freezeAuthority", "multisigFreezeAuthority"), nil
+	}
+	return "", nil, fmt.Errorf("unsupported default account state instruction %d", a.DefaultAccountStateInstruction)
+}
+
+func parseMemoTransfer(ins types.Instruction) (string, map[string]interface{}, error) {
+	var a struct {
+		Instruction                      Instruction
+		RequiredMemoTransfersInstruction RequiredMemoTransfersInstruction
+	}
+	if err := borsh.Unmarshal(ins.Data, &a); err != nil {
+		return "", nil, err
+	}
+	var instructionType string
+	switch a.RequiredMemoTransfersInstruction {
+	case RequiredMemoTransfersInstructionEnable:
+		instructionType = "enableRequiredMemoTransfers"
+	case RequiredMemoTransfersInstructionDisable:
+		instructionType = "disableRequiredMemoTransfers"
+	default:
+		return "", nil, fmt.Errorf("unsupported memo transfer instruction %d", a.RequiredMemoTransfersInstruction)
+	}
+	info := map[string]interface{}{
+		"account": ins.Accounts[0].PubKey.ToBase58(),
+	}
+	return instructionType, parse_signers(info, 1, ins.Accounts, "owner", "multisigOwner"), nil
+}
+
+func parseInterestBearingMint(ins types.Instruction) (string, map[string]interface{}, error) {
+	var s struct {
+		Instruction                    Instruction
+		InterestBearingMintInstruction InterestBearingMintInstruction
+	}
+	if err := borsh.Unmarshal(ins.Data, &s); err != nil {
+		return "", nil, err
+	}
+	switch s.InterestBearingMintInstruction {
+	case InterestBearingMintInstructionInitialize:
+		var a struct {
+			Instruction                    Instruction
+			InterestBearingMintInstruction InterestBearingMintInstruction
+			RateAuthority                  *common.PublicKey `borsh:"nonzero"`
+			Rate                           int16
+		}
+		if err := borsh.Unmarshal(ins.Data, &a); err != nil {
+			return "", nil, err
+		}
+		return "initializeInterestBearingConfig", map[string]interface{}{
+			"mint":          ins.Accounts[0].PubKey.ToBase58(),
+			"rateAuthority": optionalPubkeyInfo(a.RateAuthority),
+			"rate":          a.Rate,
+		}, nil
+	case InterestBearingMintInstructionUpdateRate:
+		if err := needAccounts(ins, 2, "updateInterestBearingConfigRate"); err != nil {
+			return "", nil, err
+		}
+		var a struct {
+			Instruction                    Instruction
+			InterestBearingMintInstruction InterestBearingMintInstruction
+			Rate                           int16
+		}
+		if err := borsh.Unmarshal(ins.Data, &a); err != nil {
+			return "", nil, err
+		}
+		info := map[string]interface{}{
+			"mint":    ins.Accounts[0].PubKey.ToBase58(),
+			"newRate": a.Rate,
+		}
+		return "updateInterestBearingConfigRate", parse_signers(info, 1, ins.Accounts, "rateAuthority", "multisigRateAuthority"), nil
+	}
+	return "", nil, fmt.Errorf("unsupported interest bearing mint instruction %d", s.InterestBearingMintInstruction)
+}
+
+func parseCpiGuard(ins types.Instruction) (string, map[string]interface{}, error) {
+	var a struct {
+		Instruction         Instruction
+		CpiGuardInstruction CpiGuardInstruction
+	}
+	if err := borsh.Unmarshal(ins.Data, &a); err != nil {
+		return "", nil, err
+	}
+	var instructionType string
+	switch a.CpiGuardInstruction {
+	case CpiGuardInstructionEnable:
+		instructionType = "enableCpiGuard"
+	case CpiGuardInstructionDisable:
+		instructionType = "disableCpiGuard"
+	default:
+		return "", nil, fmt.Errorf("unsupported cpi guard instruction %d", a.CpiGuardInstruction)
+	}
+	info := map[string]interface{}{
+		"account": ins.Accounts[0].PubKey.ToBase58(),
+	}
+	return instructionType, parse_signers(info, 1, ins.Accounts, "owner", "multisigOwner"), nil
+}
+
+// parsePointer parses the extensions which store an authority and an address,
+// the transfer hook, metadata pointer, group pointer and group member pointer
+func parsePointer(ins types.Instruction, extension, addressName string) (string, map[string]interface{}, error) {
+	var s struct {
+		Instruction Instruction
+		// the extensions share the same initialize and update instructions
+		PointerInstruction MetadataPointerInstruction
+	}
+	if err := borsh.Unmarshal(ins.Data, &s); err != nil {
+		return "", nil, err
+	}
+	switch s.PointerInstruction {
+	case MetadataPointerInstructionInitialize:
+		var a struct {
+			Instruction        Instruction
+			PointerInstruction MetadataPointerInstruction
+			Authority          *common.PublicKey `borsh:"nonzero"`
+			Address            *common.PublicKey `borsh:"nonzero"`
+		}
+		if err := borsh.Unmarshal(ins.Data, &a); err != nil {
+			return "", nil, err
+		}
+		return "initialize" + extension, map[string]interface{}{
+			"mint":      ins.Accounts[0].PubKey.ToBase58(),
+			"authority": optionalPubkeyInfo(a.Authority),
+			addressName: optionalPubkeyInfo(a.Address),
+		}, nil
+	case MetadataPointerInstructionUpdate:
+		if err := needAccounts(ins, 2, "update"+extension); err != nil {
+			return "", nil, err
+		}
+		var a struct {
+			Instruction        Instruction
+			PointerInstruction MetadataPointerInstruction
+			Address            *common.PublicKey `borsh:"nonzero"`
+		}
+		if err := borsh.Unmarshal(ins.Data, &a); err != nil {
+			return "", nil, err
+		}
+		info := map[string]interface{}{
+			"mint":      ins.Accounts[0].PubKey.ToBase58(),
+			addressName: optionalPubkeyInfo(a.Address),
+		}
+		return "update" + extension, parse_signers(info, 1, ins.Accounts, "authority", "multisigAuthority"), nil
+	}
+	return "", nil, fmt.Errorf("unsupported %v instruction %d", extension, s.PointerInstruction)
+}
+
 func parse_signers(
 	m map[string]interface{},
 	lastNonsignerIndex uint,
@@ -0,0 +1,417 @@
+package tokenprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func TestParseToken(t *testing.T) {
+	account := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	mint := common.PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH")
+	auth := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	signer := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+
+	tests := []struct {
+		ins      types.Instruction
+		wantType string
+		wantInfo map[string]interface{}
+	}{
+		{
+			ins:      SetAuthority(common.TokenProgramID, mint, account, AuthorityTypeFreezeAccount, auth, []common.PublicKey{signer}),
+			wantType: "setAuthority",
+			wantInfo: map[string]interface{}{
+				"mint":              mint.ToBase58(),
+				"authorityType":     "freezeAccount",
+				"newAuthority":      account.ToBase58(),
+				"multisigAuthority": auth.ToBase58(),
+				"signers":           []string{signer.ToBase58()},
+			},
+		},
+		{
+			ins:      SetAuthority(common.TokenProgramID, account, common.PublicKey{}, AuthorityTypeCloseAccount, auth, nil),
+			wantType: "setAuthority",
+			wantInfo: map[string]interface{}{
+				"account":       account.ToBase58(),
+				"authorityType": "closeAccount",
+				"newAuthority":  nil,
+				"authority":     auth.ToBase58(),
+			},
+		},
+		{
+			ins:      Burn(common.TokenProgramID, account, mint, auth, nil, 10),
+			wantType: "burn",
+			wantInfo: map[string]interface{}{
+				"account":   account.ToBase58(),
+				"mint":      mint.ToBase58(),
+				"amount":    uint64(10),
+				"authority": auth.ToBase58(),
+			},
+		},
+		{
+			ins:      SyncNative(common.TokenProgramID, account),
+			wantType: "syncNative",
+			wantInfo: map[string]interface{}{
+				"account": account.ToBase58(),
+			},
+		},
+		{
+			ins:      InitializeAccount3(common.TokenProgramID, account, mint, auth),
+			wantType: "initializeAccount3",
+			wantInfo: map[string]interface{}{
+				"account": account.ToBase58(),
+				"mint":    mint.ToBase58(),
+				"owner":   auth.ToBase58(),
+			},
+		},
+		{
+			ins:      InitializeMultisig(common.TokenProgramID, account, []common.PublicKey{auth, signer}, 2),
+			wantType: "initializeMultisig",
+			wantInfo: map[string]interface{}{
+				"multisig":   account.ToBase58(),
+				"rentSysvar": common.SysVarRentPubkey.ToBase58(),
+				"signers":    []string{auth.ToBase58(), signer.ToBase58()},
+				"m":          uint8(2),
+			},
+		},
+		{
+			ins:      InitializeMultisig2(common.TokenProgramID, account, []common.PublicKey{auth, signer}, 1),
+			wantType: "initializeMultisig2",
+			wantInfo: map[string]interface{}{
+				"multisig": account.ToBase58(),
+				"signers":  []string{auth.ToBase58(), signer.ToBase58()},
+				"m":        uint8(1),
+			},
+		},
+		{
+			ins:      InitializeMint2(common.TokenProgramID, 6, mint, auth, signer),
+			wantType: "initializeMint2",
+			wantInfo: map[string]interface{}{
+				"mint":            mint.ToBase58(),
+				"decimals":        uint8(6),
+				"mintAuthority":   auth.ToBase58(),
+				"freezeAuthority": signer.ToBase58(),
+			},
+		},
+		{
+			ins:      GetAccountDataSize(common.Token2022ProgramID, mint, []ExtensionType{ExtensionTypeImmutableOwner}),
+			wantType: "getAccountDataSize",
+			wantInfo: map[string]interface{}{
+				"mint":           mint.ToBase58(),
+				"extensionTypes": []string{"immutableOwner"},
+			},
+		},
+		{
+			ins:      InitializeImmutableOwner(common.TokenProgramID, account),
+			wantType: "initializeImmutableOwner",
+			wantInfo: map[string]interface{}{
+				"account": account.ToBase58(),
+			},
+		},
+		{
+			ins:      AmountToUiAmount(common.TokenProgramID, mint, 1500),
+			wantType: "amountToUiAmount",
+			wantInfo: map[string]interface{}{
+				"mint":   mint.ToBase58(),
+				"amount": "1500",
+			},
+		},
+		{
+			ins:      UiAmountToAmount(common.TokenProgramID, mint, "1.5"),
+			wantType: "uiAmountToAmount",
+			wantInfo: map[string]interface{}{
+				"mint":     mint.ToBase58(),
+				"uiAmount": "1.5",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantType, func(t *testing.T) {
+			got, err := ParseToken(tt.ins)
+			if err != nil {
+				t.Fatalf("ParseToken() error = %v", err)
+			}
+			if got.Parsed.InstructionType != tt.wantType {
+				t.Errorf("ParseToken() type = %v, want %v", got.Parsed.InstructionType, tt.wantType)
+			}
+			if !reflect.DeepEqual(got.Parsed.Info, tt.wantInfo) {
+				t.Errorf("ParseToken() info = %v, want %v", got.Parsed.Info, tt.wantInfo)
+			}
+		})
+	}
+}
+
+func TestParseTokenExtensions(t *testing.T) {
+	account := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	mint := common.PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH")
+	auth := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	signer := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+
+	tests := []struct {
+		ins      types.Instruction
+		wantType string
+		wantInfo map[string]interface{}
+	}{
+		{
+			ins:      InitializeMintCloseAuthority(mint, auth),
+			wantType: "initializeMintCloseAuthority",
+			wantInfo: map[string]interface{}{
+				"mint":         mint.ToBase58(),
+				"newAuthority": auth.ToBase58(),
+			},
+		},
+		{
+			ins:      InitializeTransferFeeConfig(mint, auth, common.PublicKey{}, 50, 5000),
+			wantType: "initializeTransferFeeConfig",
+			wantInfo: map[string]interface{}{
+				"mint":                       mint.ToBase58(),
+				"transferFeeConfigAuthority": auth.ToBase58(),
+				"withdrawWithheldAuthority":  nil,
+				"transferFeeBasisPoints":     uint16(50),
+				"maximumFee":                 uint64(5000),
+			},
+		},
+		{
+			ins:      WithdrawWithheldTokensFromMint(mint, account, auth, nil),
+			wantType: "withdrawWithheldTokensFromMint",
+			wantInfo: map[string]interface{}{
+				"mint":                      mint.ToBase58(),
+				"feeRecipient":              account.ToBase58(),
+				"withdrawWithheldAuthority": auth.ToBase58(),
+			},
+		},
+		{
+			ins:      WithdrawWithheldTokensFromAccounts(mint, account, auth, []common.PublicKey{signer}, []common.PublicKey{account}),
+			wantType: "withdrawWithheldTokensFromAccounts",
+			wantInfo: map[string]interface{}{
+				"mint":                              mint.ToBase58(),
+				"feeRecipient":                      account.ToBase58(),
+				"multisigWithdrawWithheldAuthority": auth.ToBase58(),
+				"signers":                           []string{signer.ToBase58()},
+				"sourceAccounts":                    []string{account.ToBase58()},
+			},
+		},
+		{
+			ins:      HarvestWithheldTokensToMint(mint, []common.PublicKey{account}),
+			wantType: "harvestWithheldTokensToMint",
+			wantInfo: map[string]interface{}{
+				"mint":           mint.ToBase58(),
+				"sourceAccounts": []string{account.ToBase58()},
+			},
+		},
+		{
+			ins:      SetTransferFee(mint, auth, nil, 10, 100),
+			wantType: "setTransferFee",
+			wantInfo: map[string]interface{}{
+				"mint":                       mint.ToBase58(),
+				"transferFeeBasisPoints":     uint16(10),
+				"maximumFee":                 uint64(100),
+				"transferFeeConfigAuthority": auth.ToBase58(),
+			},
+		},
+		{
+			ins:      InitializeDefaultAccountState(mint, TokenAccountFrozen),
+			wantType: "initializeDefaultAccountState",
+			wantInfo: map[string]interface{}{
+				"mint":         mint.ToBase58(),
+				"accountState": "frozen",
+			},
+		},
+		{
+			ins:      UpdateDefaultAccountState(mint, auth, nil, TokenAccountStateInitialized),
+			wantType: "updateDefaultAccountState",
+			wantInfo: map[string]interface{}{
+				"mint":            mint.ToBase58(),
+				"accountState":    "initialized",
+				"freezeAuthority": auth.ToBase58(),
+			},
+		},
+		{
+			ins:      Reallocate(account, signer, auth, nil, []ExtensionType{ExtensionTypeMemoTransfer}),
+			wantType: "reallocate",
+			wantInfo: map[string]interface{}{
+				"account":        account.ToBase58(),
+				"payer":          signer.ToBase58(),
+				"systemProgram":  common.SystemProgramID.ToBase58(),
+				"extensionTypes": []string{"memoTransfer"},
+				"owner":          auth.ToBase58(),
+			},
+		},
+		{
+			ins:      EnableRequiredMemoTransfers(account, auth, nil),
+			wantType: "enableRequiredMemoTransfers",
+			wantInfo: map[string]interface{}{
+				"account": account.ToBase58(),
+				"owner":   auth.ToBase58(),
+			},
+		},
+		{
+			ins:      DisableRequiredMemoTransfers(account, auth, []common.PublicKey{signer}),
+			wantType: "disableRequiredMemoTransfers",
+			wantInfo: map[string]interface{}{
+				"account":       account.ToBase58(),
+				"multisigOwner": auth.ToBase58(),
+				"signers":       []string{signer.ToBase58()},
+			},
+		},
+		{
+			ins:      InitializeNonTransferableMint(mint),
+			wantType: "initializeNonTransferableMint",
+			wantInfo: map[string]interface{}{
+				"mint": mint.ToBase58(),
+			},
+		},
+		{
+			ins:      InitializeInterestBearingMint(mint, auth, -25),
+			wantType: "initializeInterestBearingConfig",
+			wantInfo: map[string]interface{}{
+				"mint":          mint.ToBase58(),
+				"rateAuthority": auth.ToBase58(),
+				"rate":          int16(-25),
+			},
+		},
+		{
+			ins:      UpdateInterestRate(mint, auth, nil, 30),
+			wantType: "updateInterestBearingConfigRate",
+			wantInfo: map[string]interface{}{
+				"mint":          mint.ToBase58(),
+				"newRate":       int16(30),
+				"rateAuthority": auth.ToBase58(),
+			},
+		},
+		{
+			ins:      EnableCpiGuard(account, auth, nil),
+			wantType: "enableCpiGuard",
+			wantInfo: map[string]interface{}{
+				"account": account.ToBase58(),
+				"owner":   auth.ToBase58(),
+			},
+		},
+		{
+			ins:      DisableCpiGuard(account, auth, nil),
+			wantType: "disableCpiGuard",
+			wantInfo: map[string]interface{}{
+				"account": account.ToBase58(),
+				"owner":   auth.ToBase58(),
+			},
+		},
+		{
+			ins:      InitializePermanentDelegate(mint, auth),
+			wantType: "initializePermanentDelegate",
+			wantInfo: map[string]interface{}{
+				"mint":     mint.ToBase58(),
+				"delegate": auth.ToBase58(),
+			},
+		},
+		{
+			ins:      InitializeTransferHook(mint, auth, common.PublicKey{}),
+			wantType: "initializeTransferHook",
+			wantInfo: map[string]interface{}{
+				"mint":      mint.ToBase58(),
+				"authority": auth.ToBase58(),
+				"programId": nil,
+			},
+		},
+		{
+			ins:      UpdateTransferHook(mint, auth, nil, signer),
+			wantType: "updateTransferHook",
+			wantInfo: map[string]interface{}{
+				"mint":      mint.ToBase58(),
+				"programId": signer.ToBase58(),
+				"authority": auth.ToBase58(),
+			},
+		},
+		{
+			ins:      InitializeMetadataPointer(mint, auth, mint),
+			wantType: "initializeMetadataPointer",
+			wantInfo: map[string]interface{}{
+				"mint":            mint.ToBase58(),
+				"authority":       auth.ToBase58(),
+				"metadataAddress": mint.ToBase58(),
+			},
+		},
+		{
+			ins:      UpdateMetadataPointer(mint, auth, nil, account),
+			wantType: "updateMetadataPointer",
+			wantInfo: map[string]interface{}{
+				"mint":            mint.ToBase58(),
+				"metadataAddress": account.ToBase58(),
+				"authority":       auth.ToBase58(),
+			},
+		},
+		{
+			ins: types.Instruction{
+				ProgramID: common.Token2022ProgramID,
+				Accounts:  []types.AccountMeta{{PubKey: mint, IsWritable: true}},
+				Data:      append([]byte{byte(InstructionGroupPointerExtension), 0}, append(auth.Bytes(), make([]byte, 32)...)...),
+			},
+			wantType: "initializeGroupPointer",
+			wantInfo: map[string]interface{}{
+				"mint":         mint.ToBase58(),
+				"authority":    auth.ToBase58(),
+				"groupAddress": nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantType, func(t *testing.T) {
+			got, err := ParseToken(tt.ins)
+			if err != nil {
+				t.Fatalf("ParseToken() error = %v", err)
+			}
+			if got.Parsed.InstructionType != tt.wantType {
+				t.Errorf("ParseToken() type = %v, want %v", got.Parsed.InstructionType, tt.wantType)
+			}
+			if !reflect.DeepEqual(got.Parsed.Info, tt.wantInfo) {
+				t.Errorf("ParseToken() info = %v, want %v", got.Parsed.Info, tt.wantInfo)
+			}
+		})
+	}
+}
+
+func TestParseTokenInvalid(t *testing.T) {
+	account := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	mint := common.PublicKeyFromString("8765cK2Vucsic6NA5nm4cfkrCzusaFVqBf6Pk31tGkXH")
+	auth := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+
+	transfer := Transfer(common.TokenProgramID, account, mint, auth, nil, 1)
+	transfer.Accounts = transfer.Accounts[:2]
+	setAuthority := SetAuthority(common.TokenProgramID, mint, account, AuthorityTypeFreezeAccount, auth, nil)
+	setAuthority.Accounts = setAuthority.Accounts[:1]
+	initializeMint2 := InitializeMint2(common.TokenProgramID, 6, mint, auth, common.PublicKey{})
+	initializeMint2.Accounts = nil
+
+	tests := []struct {
+		name string
+		ins  types.Instruction
+	}{
+		{name: "empty data", ins: types.Instruction{ProgramID: common.TokenProgramID}},
+		{name: "transfer without authority", ins: transfer},
+		{name: "set authority without authority", ins: setAuthority},
+		{name: "initialize mint2 without mint", ins: initializeMint2},
+		{name: "unsupported instruction", ins: types.Instruction{
+			ProgramID: common.Token2022ProgramID,
+			Accounts:  []types.AccountMeta{{PubKey: mint}},
+			Data:      []byte{byte(InstructionConfidentialTransferExtension), 0},
+		}},
+		{name: "unsupported transfer fee instruction", ins: types.Instruction{
+			ProgramID: common.Token2022ProgramID,
+			Accounts:  []types.AccountMeta{{PubKey: mint}},
+			Data:      []byte{byte(InstructionTransferFeeExtension), 100},
+		}},
+		{name: "update metadata pointer without authority", ins: types.Instruction{
+			ProgramID: common.Token2022ProgramID,
+			Accounts:  []types.AccountMeta{{PubKey: mint}},
+			Data:      append([]byte{byte(InstructionMetadataPointerExtension), 1}, auth.Bytes()...),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseToken(tt.ins); err == nil {
+				t.Errorf("ParseToken() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -1,10 +1,10 @@
 package tokenprog
 
 import (
-	"bytes"
-	"encoding/binary"
+	"errors"
 	"fmt"
 
+	"github.com/portto/solana-go-sdk/borsh"
 	"github.com/portto/solana-go-sdk/common"
 )
 
@@ -23,59 +23,64 @@ var (
 	None = []byte{0, 0, 0, 0}
 )
 
+var ErrAccountNotInitialized = errors.New("account is not initialized")
+
+// serializeExtensions appends the account type and the extensions to the base account,
+// the base is padded to TokenAccountSize like Token-2022 does
+func serializeExtensions(base []byte, accountType AccountType, extensions []Extension) []byte {
+	if extensions == nil {
+		return base
+	}
+	data := make([]byte, TokenAccountSize, TokenAccountSize+1)
+	copy(data, base)
+	data = append(data, byte(accountType))
+	for _, extension := range extensions {
+		data = append(data, borsh.MustMarshal(extensionHeader{Type: extension.Type, Length: uint16(len(extension.Data))})...)
+		data = append(data, extension.Data...)
+	}
+	return data
+}
+
 // TokenAccount is token program account
 type TokenAccount struct {
 	Mint            common.PublicKey
 	Owner           common.PublicKey
 	Amount          uint64
-	Delegate        *common.PublicKey
+	Delegate        *common.PublicKey `borsh:"coption"`
 	State           TokenAccountState
-	IsNative        *uint64
+	IsNative        *uint64 `borsh:"coption"`
 	DelegatedAmount uint64
-	CloseAuthority  *common.PublicKey
+	CloseAuthority  *common.PublicKey `borsh:"coption"`
+	// Extensions of a Token-2022 account, nil if it has none
+	Extensions []Extension `borsh:"skip"`
 }
 
+// TokenAccountFromData decodes a token account of the token program or Token-2022, it fails on an uninitialized account
 func TokenAccountFromData(data []byte) (*TokenAccount, error) {
-	if len(data) != TokenAccountSize {
+	if len(data) < TokenAccountSize || len(data) == int(MultisigAccountSize) {
 		return nil, fmt.Errorf("data length not match")
 	}
-
-	mint := common.PublicKeyFromBytes(data[:32])
-
-	owner := common.PublicKeyFromBytes(data[32:64])
-
-	amount := binary.LittleEndian.Uint64(data[64:72])
-
-	var delegate *common.PublicKey
-	if bytes.Equal(data[72:76], Some) {
-		key := common.PublicKeyFromBytes(data[76:108])
-		delegate = &key
+	accountType, extensions, err := ExtensionsFromData(data)
+	if err != nil {
+		return nil, err
 	}
-
-	state := TokenAccountState(data[108])
-
-	var isNative *uint64
-	if bytes.Equal(data[109:113], Some) {
-		num := binary.LittleEndian.Uint64(data[113:121])
-		isNative = &num
+	if accountType != AccountTypeAccount {
+		return nil, fmt.Errorf("account type not match")
 	}
 
-	delegatedAmount := binary.LittleEndian.Uint64(data[121:129])
-
-	var closeAuthority *common.PublicKey
-	if bytes.Equal(data[129:133], Some) {
-		key := common.PublicKeyFromBytes(data[133:165])
-		closeAuthority = &key
+	var account TokenAccount
+	if err := borsh.Unmarshal(data, &account); err != nil {
+		return nil, err
+	}
+	if account.State == TokenAccountStateUninitialized {
+		return nil, ErrAccountNotInitialized
 	}
+	account.Extensions = extensions
+	return &account, nil
+}
 
-	return &TokenAccount{
-		Mint:            mint,
-		Owner:           owner,
-		Amount:          amount,
-		Delegate:        delegate,
-		State:           state,
-		IsNative:        isNative,
-		DelegatedAmount: delegatedAmount,
-		CloseAuthority:  closeAuthority,
-	}, nil
+// Serialize encodes the token account, extensions are appended if they are not nil
+func (a TokenAccount) Serialize() []byte {
+	data := borsh.MustMarshal(a)
+	return serializeExtensions(data, AccountTypeAccount, a.Extensions)
 }
@@ -33,10 +33,21 @@ func TestAccountFromData(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "uninitialized",
+			args: args{
+				data: make([]byte, TokenAccountSize),
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := TokenAccountFromData(tt.args.data)
+			if tt.wantErr && err != ErrAccountNotInitialized {
+				t.Errorf("TokenAccountFromData() error = %v, want %v", err, ErrAccountNotInitialized)
+			}
 			if (err != nil) != tt.wantErr {
 				t.Errorf("TokenAccountFromData() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -44,6 +55,9 @@ func TestAccountFromData(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("TokenAccountFromData() = %v, want %v", got, tt.want)
 			}
+			if got != nil && !reflect.DeepEqual(got.Serialize(), tt.args.data) {
+				t.Errorf("TokenAccount.Serialize() = %v, want %v", got.Serialize(), tt.args.data)
+			}
 		})
 	}
 }
@@ -16,11 +16,38 @@ type MessageHeader struct {
 	NumReadonlyUnsignedAccounts uint8
 }
 
+type MessageVersion uint8
+
+const (
+	MessageVersionLegacy MessageVersion = iota
+	MessageVersionV0
+)
+
+// messageVersionPrefix is set in the first byte of a versioned message, a legacy message
+// starts with NumRequireSignatures which never has this bit
+const messageVersionPrefix = 0x80
+
 type Message struct {
+	Version         MessageVersion
 	Header          MessageHeader
 	Accounts        []common.PublicKey
 	RecentBlockHash string
 	Instructions    []CompiledInstruction
+	// AddressLookupTables is only used by v0 messages
+	AddressLookupTables []CompiledAddressLookupTable
+}
+
+// CompiledAddressLookupTable loads the addresses at the indexes of a lookup table
+type CompiledAddressLookupTable struct {
+	AccountKey      common.PublicKey
+	WritableIndexes []uint8
+	ReadonlyIndexes []uint8
+}
+
+// AddressLookupTableAccount is a lookup table and the addresses stored in it
+type AddressLookupTableAccount struct {
+	Key       common.PublicKey
+	Addresses []common.PublicKey
 }
 
 func (m *Message) GetUniqueSigners() []string {
@@ -28,6 +55,16 @@ func (m *Message) GetUniqueSigners() []string {
 }
 func (m *Message) Serialize() ([]byte, error) {
 	b := []byte{}
+	switch m.Version {
+	case MessageVersionLegacy:
+		if len(m.AddressLookupTables) > 0 {
+			return nil, errors.New("legacy message doesn't support address lookup tables")
+		}
+	case MessageVersionV0:
+		b = append(b, messageVersionPrefix|byte(m.Version-MessageVersionV0))
+	default:
+		return nil, fmt.Errorf("unsupported message version %d", m.Version)
+	}
 	b = append(b, m.Header.NumRequireSignatures)
 	b = append(b, m.Header.NumReadonlySignedAccounts)
 	b = append(b, m.Header.NumReadonlyUnsignedAccounts)
@@ -54,32 +91,128 @@ func (m *Message) Serialize() ([]byte, error) {
 		b = append(b, common.UintToVarLenBytes(uint64(len(instruction.Data)))...)
 		b = append(b, instruction.Data...)
 	}
+
+	if m.Version == MessageVersionV0 {
+		b = append(b, common.UintToVarLenBytes(uint64(len(m.AddressLookupTables)))...)
+		for _, table := range m.AddressLookupTables {
+			b = append(b, table.AccountKey[:]...)
+			b = append(b, common.UintToVarLenBytes(uint64(len(table.WritableIndexes)))...)
+			b = append(b, table.WritableIndexes...)
+			b = append(b, common.UintToVarLenBytes(uint64(len(table.ReadonlyIndexes)))...)
+			b = append(b, table.ReadonlyIndexes...)
+		}
+	}
 	return b, nil
 }
 
+// DecompileInstructions decompiles instructions with the static accounts only,
+// accounts loaded from address lookup tables are left as empty public keys
 func (m *Message) DecompileInstructions() []Instruction {
+	return m.decompileInstructions(m.Accounts)
+}
+
+// DecompileInstructionsWithLookupTables decompiles instructions of a v0 message,
+// tables must contain every lookup table the message uses
+func (m *Message) DecompileInstructionsWithLookupTables(tables []AddressLookupTableAccount) ([]Instruction, error) {
+	accounts, err := m.ResolveAccounts(tables)
+	if err != nil {
+		return nil, err
+	}
+	return m.decompileInstructions(accounts), nil
+}
+
+// ResolveAccounts returns the static accounts followed by the writable and then the readonly
+// addresses loaded from the lookup tables, which is the order compiled instructions index them
+func (m *Message) ResolveAccounts(tables []AddressLookupTableAccount) ([]common.PublicKey, error) {
+	addresses := map[common.PublicKey][]common.PublicKey{}
+	for _, table := range tables {
+		addresses[table.Key] = table.Addresses
+	}
+
+	accounts := make([]common.PublicKey, 0, len(m.Accounts))
+	accounts = append(accounts, m.Accounts...)
+	load := func(table common.PublicKey, indexes []uint8) error {
+		tableAddresses, exist := addresses[table]
+		if !exist {
+			return fmt.Errorf("lack address lookup table %s", table.ToBase58())
+		}
+		for _, idx := range indexes {
+			if int(idx) >= len(tableAddresses) {
+				return fmt.Errorf("index %d is out of address lookup table %s", idx, table.ToBase58())
+			}
+			accounts = append(accounts, tableAddresses[idx])
+		}
+		return nil
+	}
+	for _, table := range m.AddressLookupTables {
+		if err := load(table.AccountKey, table.WritableIndexes); err != nil {
+			return nil, err
+		}
+	}
+	for _, table := range m.AddressLookupTables {
+		if err := load(table.AccountKey, table.ReadonlyIndexes); err != nil {
+			return nil, err
+		}
+	}
+	return accounts, nil
+}
+
+func (m *Message) decompileInstructions(accounts []common.PublicKey) []Instruction {
+	getAccount := func(idx int) common.PublicKey {
+		if idx < len(accounts) {
+			return accounts[idx]
+		}
+		return common.PublicKey{}
+	}
+
 	instructions := make([]Instruction, 0, len(m.Instructions))
 	for _, cins := range m.Instructions {
-		accounts := make([]AccountMeta, 0, len(cins.Accounts))
+		metas := make([]AccountMeta, 0, len(cins.Accounts))
 		for i := 0; i < len(cins.Accounts); i++ {
-			accounts = append(accounts, AccountMeta{
-				PubKey:   m.Accounts[cins.Accounts[i]],
-				IsSigner: cins.Accounts[i] < int(m.Header.NumRequireSignatures),
-				IsWritable: cins.Accounts[i] < int(m.Header.NumRequireSignatures-m.Header.NumReadonlySignedAccounts) ||
-					(cins.Accounts[i] >= int(m.Header.NumRequireSignatures) &&
-						cins.Accounts[i] < len(m.Accounts)-int(m.Header.NumReadonlyUnsignedAccounts)),
+			metas = append(metas, AccountMeta{
+				PubKey:     getAccount(cins.Accounts[i]),
+				IsSigner:   m.isSigner(cins.Accounts[i]),
+				IsWritable: m.isWritable(cins.Accounts[i]),
 			})
 		}
 		instructions = append(instructions, Instruction{
-			ProgramID: m.Accounts[cins.ProgramIDIndex],
-			Accounts:  accounts,
+			ProgramID: getAccount(cins.ProgramIDIndex),
+			Accounts:  metas,
 			Data:      cins.Data,
 		})
 	}
 	return instructions
 }
 
+func (m *Message) isSigner(idx int) bool {
+	return idx < int(m.Header.NumRequireSignatures)
+}
+
+// isWritable checks the account at idx of the accounts returned by ResolveAccounts
+func (m *Message) isWritable(idx int) bool {
+	numStatic := len(m.Accounts)
+	if idx >= numStatic {
+		numWritableLoaded := 0
+		for _, table := range m.AddressLookupTables {
+			numWritableLoaded += len(table.WritableIndexes)
+		}
+		return idx < numStatic+numWritableLoaded
+	}
+	return idx < int(m.Header.NumRequireSignatures-m.Header.NumReadonlySignedAccounts) ||
+		(idx >= int(m.Header.NumRequireSignatures) &&
+			idx < numStatic-int(m.Header.NumReadonlyUnsignedAccounts))
+}
+
 func MessageDeserialize(messageData []byte) (Message, error) {
+	version := MessageVersionLegacy
+	if len(messageData) > 0 && messageData[0]&messageVersionPrefix != 0 {
+		if v := messageData[0] &^ messageVersionPrefix; v != 0 {
+			return Message{}, fmt.Errorf("unsupported message version %d", v)
+		}
+		version = MessageVersionV0
+		messageData = messageData[1:]
+	}
+
 	var numRequireSignatures, numReadonlySignedAccounts, numReadonlyUnsignedAccounts uint8
 	var t uint64
 	var err error
@@ -134,6 +267,9 @@ func MessageDeserialize(messageData []byte) (Message, error) {
 		if err != nil {
 			return Message{}, fmt.Errorf("parse instruction #%d data length error: %v", i+1, err)
 		}
+		if uint64(len(messageData)) < dataLen {
+			return Message{}, fmt.Errorf("parse instruction #%d data error", i+1)
+		}
 		var data []byte
 		data, messageData = messageData[:dataLen], messageData[dataLen:]
 
@@ -144,18 +280,63 @@ func MessageDeserialize(messageData []byte) (Message, error) {
 		})
 	}
 
+	var addressLookupTables []CompiledAddressLookupTable
+	if version == MessageVersionV0 {
+		tableCount, err := parseUvarint(&messageData)
+		if err != nil {
+			return Message{}, fmt.Errorf("parse address lookup table count error: %v", err)
+		}
+		addressLookupTables = make([]CompiledAddressLookupTable, 0, tableCount)
+		for i := 0; i < int(tableCount); i++ {
+			if len(messageData) < 32 {
+				return Message{}, fmt.Errorf("parse address lookup table #%d account error", i+1)
+			}
+			accountKey := common.PublicKeyFromBytes(messageData[:32])
+			messageData = messageData[32:]
+			writableIndexes, err := parseBytes(&messageData)
+			if err != nil {
+				return Message{}, fmt.Errorf("parse address lookup table #%d writable indexes error: %v", i+1, err)
+			}
+			readonlyIndexes, err := parseBytes(&messageData)
+			if err != nil {
+				return Message{}, fmt.Errorf("parse address lookup table #%d readonly indexes error: %v", i+1, err)
+			}
+			addressLookupTables = append(addressLookupTables, CompiledAddressLookupTable{
+				AccountKey:      accountKey,
+				WritableIndexes: writableIndexes,
+				ReadonlyIndexes: readonlyIndexes,
+			})
+		}
+	}
+
 	return Message{
+		Version: version,
 		Header: MessageHeader{
 			NumRequireSignatures:        numRequireSignatures,
 			NumReadonlySignedAccounts:   numReadonlySignedAccounts,
 			NumReadonlyUnsignedAccounts: numReadonlyUnsignedAccounts,
 		},
-		Accounts:        accounts,
-		RecentBlockHash: blockHash,
-		Instructions:    instructions,
+		Accounts:            accounts,
+		RecentBlockHash:     blockHash,
+		Instructions:        instructions,
+		AddressLookupTables: addressLookupTables,
 	}, nil
 }
 
+// parseBytes parses a length prefixed byte array
+func parseBytes(data *[]byte) ([]byte, error) {
+	l, err := parseUvarint(data)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(*data)) < l {
+		return nil, errors.New("data is not enough")
+	}
+	b := append([]byte{}, (*data)[:l]...)
+	*data = (*data)[l:]
+	return b, nil
+}
+
 func MustMessageDeserialize(messageData []byte) Message {
 	message, err := MessageDeserialize(messageData)
 	if err != nil {
@@ -271,3 +452,100 @@ func NewMessage(feePayer common.PublicKey, instructions []Instruction, recentBlo
 		Instructions:    compiledInstructions,
 	}
 }
+
+// NewMessageV0 compiles a v0 message. Accounts found in the lookup tables are loaded from them,
+// except signers and programs which have to stay in the static accounts.
+func NewMessageV0(feePayer common.PublicKey, instructions []Instruction, recentBlockHash string, tables []AddressLookupTableAccount) Message {
+	legacy := NewMessage(feePayer, instructions, recentBlockHash)
+
+	programIDs := map[common.PublicKey]bool{}
+	for _, instruction := range instructions {
+		programIDs[instruction.ProgramID] = true
+	}
+	lookup := func(key common.PublicKey) (int, uint8, bool) {
+		for t, table := range tables {
+			for idx, address := range table.Addresses {
+				if idx > 255 {
+					break
+				}
+				if address == key {
+					return t, uint8(idx), true
+				}
+			}
+		}
+		return 0, 0, false
+	}
+
+	numSigners := int(legacy.Header.NumRequireSignatures)
+	numWritable := len(legacy.Accounts) - int(legacy.Header.NumReadonlyUnsignedAccounts)
+	staticAccounts := []common.PublicKey{}
+	numReadonlyUnsigned := 0
+	lookups := make([]CompiledAddressLookupTable, len(tables))
+	writableLoaded := make([][]common.PublicKey, len(tables))
+	readonlyLoaded := make([][]common.PublicKey, len(tables))
+	for i, key := range legacy.Accounts {
+		if i >= numSigners && !programIDs[key] {
+			if t, idx, ok := lookup(key); ok {
+				if i < numWritable {
+					lookups[t].WritableIndexes = append(lookups[t].WritableIndexes, idx)
+					writableLoaded[t] = append(writableLoaded[t], key)
+				} else {
+					lookups[t].ReadonlyIndexes = append(lookups[t].ReadonlyIndexes, idx)
+					readonlyLoaded[t] = append(readonlyLoaded[t], key)
+				}
+				continue
+			}
+		}
+		staticAccounts = append(staticAccounts, key)
+		if i >= numWritable {
+			numReadonlyUnsigned++
+		}
+	}
+
+	accounts := append([]common.PublicKey{}, staticAccounts...)
+	for _, keys := range writableLoaded {
+		accounts = append(accounts, keys...)
+	}
+	for _, keys := range readonlyLoaded {
+		accounts = append(accounts, keys...)
+	}
+	publicKeyToIdx := map[common.PublicKey]int{}
+	for idx, publicKey := range accounts {
+		publicKeyToIdx[publicKey] = idx
+	}
+
+	compiledInstructions := []CompiledInstruction{}
+	for _, instruction := range instructions {
+		accountIdx := []int{}
+		for _, account := range instruction.Accounts {
+			accountIdx = append(accountIdx, publicKeyToIdx[account.PubKey])
+		}
+		compiledInstructions = append(compiledInstructions, CompiledInstruction{
+			ProgramIDIndex: publicKeyToIdx[instruction.ProgramID],
+			Accounts:       accountIdx,
+			Data:           instruction.Data,
+		})
+	}
+
+	addressLookupTables := []CompiledAddressLookupTable{}
+	for t, table := range tables {
+		if len(lookups[t].WritableIndexes) == 0 && len(lookups[t].ReadonlyIndexes) == 0 {
+			continue
+		}
+		lookups[t].AccountKey = table.Key
+		addressLookupTables = append(addressLookupTables, lookups[t])
+	}
+
+	return Message{
+		Version: MessageVersionV0,
+		Header: MessageHeader{
+			NumRequireSignatures:        legacy.Header.NumRequireSignatures,
+			NumReadonlySignedAccounts:   legacy.Header.NumReadonlySignedAccounts,
+			NumReadonlyUnsignedAccounts: uint8(numReadonlyUnsigned),
+		},
+		Accounts:            staticAccounts,
+		RecentBlockHash:     recentBlockHash,
+		Instructions:        compiledInstructions,
+		AddressLookupTables: addressLookupTables,
+	}
+}
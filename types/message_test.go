@@ -124,3 +124,83 @@ func TestMessage_DecompileInstructions(t *testing.T) {
 		})
 	}
 }
+
+func TestMessageV0(t *testing.T) {
+	feePayer := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	to := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+	readonly := common.PublicKeyFromString("SysvarRent111111111111111111111111111111111")
+	notInTable := common.PublicKeyFromString("FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5")
+	tables := []AddressLookupTableAccount{
+		{
+			Key:       common.PublicKeyFromString("9FfgNAyyvGPMCgjsDYgW9wcWaxzKw5ohtkMudySGU7Pd"),
+			Addresses: []common.PublicKey{readonly, to, feePayer},
+		},
+		{
+			Key:       common.PublicKeyFromString("AgPQjYoHnXs5L9QiXZXe5rqBfkdcRiPA3ZMVQoWUNE9c"),
+			Addresses: []common.PublicKey{to},
+		},
+	}
+	instructions := []Instruction{
+		{
+			ProgramID: common.SystemProgramID,
+			Accounts: []AccountMeta{
+				{PubKey: feePayer, IsSigner: true, IsWritable: true},
+				{PubKey: to, IsSigner: false, IsWritable: true},
+				{PubKey: readonly, IsSigner: false, IsWritable: false},
+				{PubKey: notInTable, IsSigner: false, IsWritable: false},
+			},
+			Data: []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+		},
+	}
+
+	m := NewMessageV0(feePayer, instructions, "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5", tables)
+	want := Message{
+		Version: MessageVersionV0,
+		Header: MessageHeader{
+			NumRequireSignatures:        1,
+			NumReadonlySignedAccounts:   0,
+			NumReadonlyUnsignedAccounts: 2,
+		},
+		Accounts:        []common.PublicKey{feePayer, common.SystemProgramID, notInTable},
+		RecentBlockHash: "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+		Instructions: []CompiledInstruction{
+			{ProgramIDIndex: 1, Accounts: []int{0, 3, 4, 2}, Data: []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0}},
+		},
+		AddressLookupTables: []CompiledAddressLookupTable{
+			{AccountKey: tables[0].Key, WritableIndexes: []uint8{1}, ReadonlyIndexes: []uint8{0}},
+		},
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Fatalf("NewMessageV0() = %+v, want %+v", m, want)
+	}
+
+	b, err := m.Serialize()
+	if err != nil {
+		t.Fatalf("Message.Serialize() error = %v", err)
+	}
+	if b[0] != 0x80 {
+		t.Errorf("Message.Serialize() prefix = %x, want 0x80", b[0])
+	}
+	got, err := MessageDeserialize(b)
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("MessageDeserialize() = %+v, %v, want %+v", got, err, want)
+	}
+
+	accounts, err := m.ResolveAccounts(tables)
+	wantAccounts := []common.PublicKey{feePayer, common.SystemProgramID, notInTable, to, readonly}
+	if err != nil || !reflect.DeepEqual(accounts, wantAccounts) {
+		t.Errorf("Message.ResolveAccounts() = %v, %v, want %v", accounts, err, wantAccounts)
+	}
+	if _, err := m.ResolveAccounts(tables[1:]); err == nil {
+		t.Errorf("Message.ResolveAccounts() without the used table should fail")
+	}
+
+	decompiled, err := m.DecompileInstructionsWithLookupTables(tables)
+	if err != nil || !reflect.DeepEqual(decompiled, instructions) {
+		t.Errorf("Message.DecompileInstructionsWithLookupTables() = %v, %v, want %v", decompiled, err, instructions)
+	}
+
+	if _, err := MessageDeserialize(append([]byte{0x81}, b[1:]...)); err == nil {
+		t.Errorf("MessageDeserialize() should reject unsupported versions")
+	}
+}
@@ -0,0 +1,139 @@
+package types
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+// InstructionParser decodes the instructions of a program
+type InstructionParser func(ins Instruction) (ParsedInstruction, error)
+
+var (
+	parsersMu sync.RWMutex
+	parsers   = map[common.PublicKey]InstructionParser{}
+)
+
+// RegisterInstructionParser sets the parser of a program, it replaces the registered one if any.
+// Program packages register their parsers when they are imported.
+func RegisterInstructionParser(programID common.PublicKey, parser InstructionParser) {
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+	parsers[programID] = parser
+}
+
+func getInstructionParser(programID common.PublicKey) (InstructionParser, bool) {
+	parsersMu.RLock()
+	defer parsersMu.RUnlock()
+	parser, exist := parsers[programID]
+	return parser, exist
+}
+
+// ParseInstruction decodes the instruction with the parser registered for its program.
+// If there is no parser or it fails, the instruction is returned with its accounts and base58 data,
+// together with the parser error.
+func ParseInstruction(ins Instruction) (ParsedInstruction, error) {
+	parser, exist := getInstructionParser(ins.ProgramID)
+	if !exist {
+		return rawParsedInstruction(ins), nil
+	}
+
+	parsed, err := safeParse(parser, ins)
+	if err != nil || parsed.Parsed == nil || parsed.Parsed.InstructionType == "" {
+		return rawParsedInstruction(ins), err
+	}
+	// parsers of application programs may name their program
+	if name := common.GetProgramName(ins.ProgramID); parsed.Program == "" && name != "Unknown" {
+		parsed.Program = name
+	}
+	parsed.ProgramID = ins.ProgramID.ToBase58()
+	return parsed, nil
+}
+
+// safeParse turns a panic of a parser, like an instruction with missing accounts, into an error
+func safeParse(parser InstructionParser, ins Instruction) (parsed ParsedInstruction, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to parse instruction of %s: %v", ins.ProgramID.ToBase58(), r)
+		}
+	}()
+	return parser(ins)
+}
+
+func rawParsedInstruction(ins Instruction) ParsedInstruction {
+	accounts := make([]string, 0, len(ins.Accounts))
+	for _, account := range ins.Accounts {
+		accounts = append(accounts, account.PubKey.ToBase58())
+	}
+	return ParsedInstruction{
+		Accounts:  accounts,
+		Data:      base58.Encode(ins.Data),
+		ProgramID: ins.ProgramID.ToBase58(),
+	}
+}
+
+// ParseTransaction decodes all instructions of the transaction, instructions which can't be
+// decoded keep their raw data. It fails on a v0 message which loads accounts from lookup tables,
+// use ParseTransactionWithLookupTables for it.
+func ParseTransaction(tx Transaction) (ParsedTransaction, error) {
+	return parseTransaction(tx, tx.Message.Accounts, tx.Message.DecompileInstructions())
+}
+
+// ParseTransactionWithLookupTables is ParseTransaction for a v0 transaction which uses lookup tables
+func ParseTransactionWithLookupTables(tx Transaction, tables []AddressLookupTableAccount) (ParsedTransaction, error) {
+	accounts, err := tx.Message.ResolveAccounts(tables)
+	if err != nil {
+		return ParsedTransaction{}, err
+	}
+	instructions, err := tx.Message.DecompileInstructionsWithLookupTables(tables)
+	if err != nil {
+		return ParsedTransaction{}, err
+	}
+	return parseTransaction(tx, accounts, instructions)
+}
+
+func parseTransaction(tx Transaction, accounts []common.PublicKey, instructions []Instruction) (ParsedTransaction, error) {
+	for _, ins := range tx.Message.Instructions {
+		if ins.ProgramIDIndex >= len(accounts) {
+			return ParsedTransaction{}, fmt.Errorf("program id index %d is out of accounts", ins.ProgramIDIndex)
+		}
+		for _, idx := range ins.Accounts {
+			if idx >= len(accounts) {
+				return ParsedTransaction{}, fmt.Errorf("account index %d is out of accounts", idx)
+			}
+		}
+	}
+
+	signatures := make([]string, 0, len(tx.Signatures))
+	for _, signature := range tx.Signatures {
+		signatures = append(signatures, signature.ToBase58())
+	}
+
+	accountKeys := make([]ParsedAccKey, 0, len(accounts))
+	for i, account := range accounts {
+		accountKeys = append(accountKeys, ParsedAccKey{
+			PubKey:     account.ToBase58(),
+			IsSigner:   tx.Message.isSigner(i),
+			IsWritable: tx.Message.isWritable(i),
+		})
+	}
+
+	parsedInstructions := make([]ParsedInstruction, 0, len(instructions))
+	for _, ins := range instructions {
+		// a failed instruction keeps its raw data like the rpc node does
+		parsed, _ := ParseInstruction(ins)
+		parsedInstructions = append(parsedInstructions, parsed)
+	}
+
+	return ParsedTransaction{
+		Signatures: signatures,
+		Message: ParsedMessage{
+			Header:          tx.Message.Header,
+			AccountKeys:     accountKeys,
+			RecentBlockhash: tx.Message.RecentBlockHash,
+			Instructions:    parsedInstructions,
+		},
+	}, nil
+}
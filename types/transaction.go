@@ -10,6 +10,9 @@ import (
 	"github.com/portto/solana-go-sdk/common"
 )
 
+// PacketDataSize is the max size of a serialized transaction
+const PacketDataSize = 1232
+
 type Signature []byte
 
 func (p Signature) ToBase58() string {
@@ -117,6 +120,8 @@ type CreateRawTransactionParam struct {
 	Signers         []Account
 	FeePayer        common.PublicKey
 	RecentBlockHash string
+	// AddressLookupTables makes a v0 transaction which loads accounts from the tables if it is not nil
+	AddressLookupTables []AddressLookupTableAccount
 }
 
 func CreateRawTransaction(param CreateRawTransactionParam) ([]byte, error) {
@@ -127,9 +132,13 @@ func CreateRawTransaction(param CreateRawTransactionParam) ([]byte, error) {
 		return nil, errors.New("no instructions provided")
 	}
 
+	message := NewMessage(param.FeePayer, param.Instructions, param.RecentBlockHash)
+	if param.AddressLookupTables != nil {
+		message = NewMessageV0(param.FeePayer, param.Instructions, param.RecentBlockHash, param.AddressLookupTables)
+	}
 	tx := Transaction{
 		Signatures: []Signature{},
-		Message:    NewMessage(param.FeePayer, param.Instructions, param.RecentBlockHash),
+		Message:    message,
 	}
 
 	signTx, err := tx.sign(param.Signers)
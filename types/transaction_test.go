@@ -1,6 +1,7 @@
 package types
 
 import (
+	"crypto/ed25519"
 	"reflect"
 	"testing"
 
@@ -343,3 +344,42 @@ func TestCreateTransaction(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionDeserializeV0(t *testing.T) {
+	feePayer := NewAccount()
+	to := common.PublicKeyFromString("A4iUVr5KjmsLymUcv4eSKPedUtoaBceiPeGipKMYc69b")
+	tables := []AddressLookupTableAccount{
+		{Key: common.PublicKeyFromString("9FfgNAyyvGPMCgjsDYgW9wcWaxzKw5ohtkMudySGU7Pd"), Addresses: []common.PublicKey{to}},
+	}
+	rawTx, err := CreateRawTransaction(CreateRawTransactionParam{
+		Instructions: []Instruction{
+			{
+				ProgramID: common.SystemProgramID,
+				Accounts: []AccountMeta{
+					{PubKey: feePayer.PublicKey, IsSigner: true, IsWritable: true},
+					{PubKey: to, IsSigner: false, IsWritable: true},
+				},
+				Data: []byte{2, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0},
+			},
+		},
+		Signers:             []Account{feePayer},
+		FeePayer:            feePayer.PublicKey,
+		RecentBlockHash:     "FwRYtTPRk5N4wUeP87rTw9kQVSwigB6kbikGzzeCMrW5",
+		AddressLookupTables: tables,
+	})
+	if err != nil {
+		t.Fatalf("CreateRawTransaction() error = %v", err)
+	}
+
+	tx, err := TransactionDeserialize(rawTx)
+	if err != nil {
+		t.Fatalf("TransactionDeserialize() error = %v", err)
+	}
+	if tx.Message.Version != MessageVersionV0 || len(tx.Message.AddressLookupTables) != 1 || len(tx.Message.Accounts) != 2 {
+		t.Errorf("TransactionDeserialize() message = %+v", tx.Message)
+	}
+	message, _ := tx.Message.Serialize()
+	if !ed25519.Verify(feePayer.PublicKey.Bytes(), message, tx.Signatures[0]) {
+		t.Errorf("signature doesn't match the v0 message")
+	}
+}
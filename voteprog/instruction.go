@@ -0,0 +1,241 @@
+package voteprog
+
+import (
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// VoteStateSize is the size of a vote account
+const VoteStateSize uint64 = 3762
+
+type Instruction uint32
+
+const (
+	InstructionInitializeAccount Instruction = iota
+	InstructionAuthorize
+	InstructionVote
+	InstructionWithdraw
+	InstructionUpdateValidatorIdentity
+	InstructionUpdateCommission
+	InstructionVoteSwitch
+	InstructionAuthorizeChecked
+	InstructionUpdateVoteState
+	InstructionUpdateVoteStateSwitch
+	InstructionAuthorizeWithSeed
+	InstructionAuthorizeCheckedWithSeed
+	InstructionCompactUpdateVoteState
+	InstructionCompactUpdateVoteStateSwitch
+)
+
+type VoteAuthorizationType uint32
+
+const (
+	VoteAuthorizationTypeVoter VoteAuthorizationType = iota
+	VoteAuthorizationTypeWithdrawer
+)
+
+// VoteParam is the vote of Vote and VoteSwitch, Hash is the bank hash of the last slot
+type VoteParam struct {
+	Slots     []uint64 `borsh:"u64len"`
+	Hash      [32]byte
+	Timestamp *int64
+}
+
+func InitializeAccount(votePubkey, nodePubkey, authorizedVoterPubkey, authorizedWithdrawerPubkey common.PublicKey, commission uint8) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction          Instruction
+		Node                 common.PublicKey
+		AuthorizedVoter      common.PublicKey
+		AuthorizedWithdrawer common.PublicKey
+		Commission           uint8
+	}{
+		Instruction:          InstructionInitializeAccount,
+		Node:                 nodePubkey,
+		AuthorizedVoter:      authorizedVoterPubkey,
+		AuthorizedWithdrawer: authorizedWithdrawerPubkey,
+		Commission:           commission,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.VoteProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: votePubkey, IsSigner: false, IsWritable: true},
+			{PubKey: common.SysVarRentPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: nodePubkey, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+func Authorize(votePubkey, authPubkey, newAuthPubkey common.PublicKey, authType VoteAuthorizationType) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction           Instruction
+		NewAuthorized         common.PublicKey
+		VoteAuthorizationType VoteAuthorizationType
+	}{
+		Instruction:           InstructionAuthorize,
+		NewAuthorized:         newAuthPubkey,
+		VoteAuthorizationType: authType,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.VoteProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: votePubkey, IsSigner: false, IsWritable: true},
+			{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: authPubkey, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+func Vote(votePubkey, voteAuthPubkey common.PublicKey, vote VoteParam) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction Instruction
+		Vote        VoteParam
+	}{
+		Instruction: InstructionVote,
+		Vote:        vote,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.VoteProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: votePubkey, IsSigner: false, IsWritable: true},
+			{PubKey: common.SysVarSlotHashesPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: voteAuthPubkey, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+func Withdraw(votePubkey, withdrawAuthPubkey, toPubkey common.PublicKey, lamports uint64) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction Instruction
+		Lamports    uint64
+	}{
+		Instruction: InstructionWithdraw,
+		Lamports:    lamports,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.VoteProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: votePubkey, IsSigner: false, IsWritable: true},
+			{PubKey: toPubkey, IsSigner: false, IsWritable: true},
+			{PubKey: withdrawAuthPubkey, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+// UpdateValidatorIdentity changes the node of the vote account, both the new node and the withdrawer have to sign
+func UpdateValidatorIdentity(votePubkey, withdrawAuthPubkey, newNodePubkey common.PublicKey) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction Instruction
+	}{
+		Instruction: InstructionUpdateValidatorIdentity,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.VoteProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: votePubkey, IsSigner: false, IsWritable: true},
+			{PubKey: newNodePubkey, IsSigner: true, IsWritable: false},
+			{PubKey: withdrawAuthPubkey, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+func UpdateCommission(votePubkey, withdrawAuthPubkey common.PublicKey, commission uint8) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction Instruction
+		Commission  uint8
+	}{
+		Instruction: InstructionUpdateCommission,
+		Commission:  commission,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.VoteProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: votePubkey, IsSigner: false, IsWritable: true},
+			{PubKey: withdrawAuthPubkey, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+// VoteSwitch is Vote with the hash of the switching proof
+func VoteSwitch(votePubkey, voteAuthPubkey common.PublicKey, vote VoteParam, proofHash [32]byte) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction Instruction
+		Vote        VoteParam
+		Hash        [32]byte
+	}{
+		Instruction: InstructionVoteSwitch,
+		Vote:        vote,
+		Hash:        proofHash,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.VoteProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: votePubkey, IsSigner: false, IsWritable: true},
+			{PubKey: common.SysVarSlotHashesPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: voteAuthPubkey, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
+
+// AuthorizeChecked is Authorize but the new authority has to sign
+func AuthorizeChecked(votePubkey, authPubkey, newAuthPubkey common.PublicKey, authType VoteAuthorizationType) types.Instruction {
+	data, err := borsh.Marshal(struct {
+		Instruction           Instruction
+		VoteAuthorizationType VoteAuthorizationType
+	}{
+		Instruction:           InstructionAuthorizeChecked,
+		VoteAuthorizationType: authType,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.Instruction{
+		ProgramID: common.VoteProgramID,
+		Accounts: []types.AccountMeta{
+			{PubKey: votePubkey, IsSigner: false, IsWritable: true},
+			{PubKey: common.SysVarClockPubkey, IsSigner: false, IsWritable: false},
+			{PubKey: authPubkey, IsSigner: true, IsWritable: false},
+			{PubKey: newAuthPubkey, IsSigner: true, IsWritable: false},
+		},
+		Data: data,
+	}
+}
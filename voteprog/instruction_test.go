@@ -0,0 +1,67 @@
+package voteprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func TestWithdraw(t *testing.T) {
+	type args struct {
+		votePubkey         common.PublicKey
+		withdrawAuthPubkey common.PublicKey
+		toPubkey           common.PublicKey
+		lamports           uint64
+	}
+	tests := []struct {
+		name string
+		args args
+		want types.Instruction
+	}{
+		{
+			args: args{
+				votePubkey:         common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"),
+				withdrawAuthPubkey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"),
+				toPubkey:           common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"),
+				lamports:           1,
+			},
+			want: types.Instruction{
+				ProgramID: common.VoteProgramID,
+				Accounts: []types.AccountMeta{
+					{PubKey: common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7"), IsSigner: false, IsWritable: true},
+					{PubKey: common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ"), IsSigner: true, IsWritable: false},
+				},
+				Data: []byte{3, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Withdraw(tt.args.votePubkey, tt.args.withdrawAuthPubkey, tt.args.toPubkey, tt.args.lamports); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Withdraw() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVote(t *testing.T) {
+	vote := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	auth := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	timestamp := int64(2)
+	hash := [32]byte(auth)
+
+	want := []byte{2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0}
+	want = append(want, auth.Bytes()...)
+	want = append(want, 1, 2, 0, 0, 0, 0, 0, 0, 0)
+
+	got := Vote(vote, auth, VoteParam{Slots: []uint64{1}, Hash: hash, Timestamp: &timestamp})
+	if !reflect.DeepEqual(got.Data, want) {
+		t.Errorf("Vote() data = %v, want %v", got.Data, want)
+	}
+	if len(got.Accounts) != 4 || got.Accounts[1].PubKey != common.SysVarSlotHashesPubkey || !got.Accounts[3].IsSigner {
+		t.Errorf("Vote() accounts = %v", got.Accounts)
+	}
+}
@@ -0,0 +1,187 @@
+package voteprog
+
+import (
+	"fmt"
+
+	"github.com/mr-tron/base58"
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+// instructionMinAccounts is the number of accounts an instruction needs to be parsed
+var instructionMinAccounts = map[Instruction]int{
+	InstructionInitializeAccount:       4,
+	InstructionAuthorize:               3,
+	InstructionVote:                    4,
+	InstructionWithdraw:                3,
+	InstructionUpdateValidatorIdentity: 3,
+	InstructionUpdateCommission:        2,
+	InstructionVoteSwitch:              4,
+	InstructionAuthorizeChecked:        4,
+}
+
+func (t VoteAuthorizationType) String() string {
+	switch t {
+	case VoteAuthorizationTypeVoter:
+		return "Voter"
+	case VoteAuthorizationTypeWithdrawer:
+		return "Withdrawer"
+	}
+	return fmt.Sprintf("VoteAuthorizationType(%d)", uint32(t))
+}
+
+func init() {
+	types.RegisterInstructionParser(common.VoteProgramID, ParseVote)
+}
+
+func ParseVote(ins types.Instruction) (types.ParsedInstruction, error) {
+	var parsedInstruction types.ParsedInstruction
+	var err error
+	var s struct {
+		Instruction Instruction
+	}
+	err = borsh.Unmarshal(ins.Data, &s)
+	if err != nil {
+		return parsedInstruction, err
+	}
+	minAccounts, exist := instructionMinAccounts[s.Instruction]
+	if !exist {
+		return parsedInstruction, fmt.Errorf("unsupported vote instruction %d", s.Instruction)
+	}
+	if len(ins.Accounts) < minAccounts {
+		return parsedInstruction, fmt.Errorf("vote instruction %d needs %d accounts, got %d", s.Instruction, minAccounts, len(ins.Accounts))
+	}
+
+	var instructionType string
+	var parsedInfo map[string]interface{}
+	switch s.Instruction {
+	case InstructionInitializeAccount:
+		var a struct {
+			Instruction          Instruction
+			Node                 common.PublicKey
+			AuthorizedVoter      common.PublicKey
+			AuthorizedWithdrawer common.PublicKey
+			Commission           uint8
+		}
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "initialize"
+		parsedInfo = map[string]interface{}{
+			"voteAccount":          ins.Accounts[0].PubKey.ToBase58(),
+			"rentSysvar":           ins.Accounts[1].PubKey.ToBase58(),
+			"clockSysvar":          ins.Accounts[2].PubKey.ToBase58(),
+			"node":                 a.Node.ToBase58(),
+			"authorizedVoter":      a.AuthorizedVoter.ToBase58(),
+			"authorizedWithdrawer": a.AuthorizedWithdrawer.ToBase58(),
+			"commission":           a.Commission,
+		}
+		break
+	case InstructionAuthorize:
+		var a struct {
+			Instruction           Instruction
+			NewAuthorized         common.PublicKey
+			VoteAuthorizationType VoteAuthorizationType
+		}
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "authorize"
+		parsedInfo = map[string]interface{}{
+			"voteAccount":   ins.Accounts[0].PubKey.ToBase58(),
+			"clockSysvar":   ins.Accounts[1].PubKey.ToBase58(),
+			"authority":     ins.Accounts[2].PubKey.ToBase58(),
+			"newAuthority":  a.NewAuthorized.ToBase58(),
+			"authorityType": a.VoteAuthorizationType.String(),
+		}
+		break
+	case InstructionVote, InstructionVoteSwitch:
+		var a struct {
+			Instruction Instruction
+			Vote        VoteParam
+		}
+		var n int
+		n, err = borsh.UnmarshalPrefix(ins.Data, &a)
+		parsedInfo = map[string]interface{}{
+			"voteAccount":      ins.Accounts[0].PubKey.ToBase58(),
+			"slotHashesSysvar": ins.Accounts[1].PubKey.ToBase58(),
+			"clockSysvar":      ins.Accounts[2].PubKey.ToBase58(),
+			"voteAuthority":    ins.Accounts[3].PubKey.ToBase58(),
+			"vote":             a.Vote.parsedInfo(),
+		}
+		instructionType = "vote"
+		if s.Instruction == InstructionVoteSwitch {
+			instructionType = "voteSwitch"
+			var hash [32]byte
+			if err == nil {
+				err = borsh.Unmarshal(ins.Data[n:], &hash)
+			}
+			parsedInfo["hash"] = base58.Encode(hash[:])
+		}
+		break
+	case InstructionWithdraw:
+		var a struct {
+			Instruction Instruction
+			Lamports    uint64
+		}
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "withdraw"
+		parsedInfo = map[string]interface{}{
+			"voteAccount":       ins.Accounts[0].PubKey.ToBase58(),
+			"destination":       ins.Accounts[1].PubKey.ToBase58(),
+			"withdrawAuthority": ins.Accounts[2].PubKey.ToBase58(),
+			"lamports":          a.Lamports,
+		}
+		break
+	case InstructionUpdateValidatorIdentity:
+		instructionType = "updateValidatorIdentity"
+		parsedInfo = map[string]interface{}{
+			"voteAccount":          ins.Accounts[0].PubKey.ToBase58(),
+			"newValidatorIdentity": ins.Accounts[1].PubKey.ToBase58(),
+			"withdrawAuthority":    ins.Accounts[2].PubKey.ToBase58(),
+		}
+		break
+	case InstructionUpdateCommission:
+		var a struct {
+			Instruction Instruction
+			Commission  uint8
+		}
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "updateCommission"
+		parsedInfo = map[string]interface{}{
+			"voteAccount":       ins.Accounts[0].PubKey.ToBase58(),
+			"withdrawAuthority": ins.Accounts[1].PubKey.ToBase58(),
+			"commission":        a.Commission,
+		}
+		break
+	case InstructionAuthorizeChecked:
+		var a struct {
+			Instruction           Instruction
+			VoteAuthorizationType VoteAuthorizationType
+		}
+		err = borsh.Unmarshal(ins.Data, &a)
+		instructionType = "authorizeChecked"
+		parsedInfo = map[string]interface{}{
+			"voteAccount":   ins.Accounts[0].PubKey.ToBase58(),
+			"clockSysvar":   ins.Accounts[1].PubKey.ToBase58(),
+			"authority":     ins.Accounts[2].PubKey.ToBase58(),
+			"newAuthority":  ins.Accounts[3].PubKey.ToBase58(),
+			"authorityType": a.VoteAuthorizationType.String(),
+		}
+		break
+	}
+	parsedInstruction.Parsed = &types.InstructionInfo{
+		Info:            parsedInfo,
+		InstructionType: instructionType,
+	}
+	return parsedInstruction, err
+}
+
+func (v VoteParam) parsedInfo() map[string]interface{} {
+	info := map[string]interface{}{
+		"slots":     v.Slots,
+		"hash":      base58.Encode(v.Hash[:]),
+		"timestamp": nil,
+	}
+	if v.Timestamp != nil {
+		info["timestamp"] = *v.Timestamp
+	}
+	return info
+}
@@ -0,0 +1,143 @@
+package voteprog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+	"github.com/portto/solana-go-sdk/types"
+)
+
+func TestParseVote(t *testing.T) {
+	vote := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	auth := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	other := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	timestamp := int64(1600000000)
+	hash := [32]byte(other)
+
+	tests := []struct {
+		ins      types.Instruction
+		wantType string
+		wantInfo map[string]interface{}
+	}{
+		{
+			ins:      InitializeAccount(vote, other, auth, auth, 10),
+			wantType: "initialize",
+			wantInfo: map[string]interface{}{
+				"voteAccount":          vote.ToBase58(),
+				"rentSysvar":           common.SysVarRentPubkey.ToBase58(),
+				"clockSysvar":          common.SysVarClockPubkey.ToBase58(),
+				"node":                 other.ToBase58(),
+				"authorizedVoter":      auth.ToBase58(),
+				"authorizedWithdrawer": auth.ToBase58(),
+				"commission":           uint8(10),
+			},
+		},
+		{
+			ins:      Authorize(vote, auth, other, VoteAuthorizationTypeWithdrawer),
+			wantType: "authorize",
+			wantInfo: map[string]interface{}{
+				"voteAccount":   vote.ToBase58(),
+				"clockSysvar":   common.SysVarClockPubkey.ToBase58(),
+				"authority":     auth.ToBase58(),
+				"newAuthority":  other.ToBase58(),
+				"authorityType": "Withdrawer",
+			},
+		},
+		{
+			ins:      Vote(vote, auth, VoteParam{Slots: []uint64{1, 2}, Hash: hash, Timestamp: &timestamp}),
+			wantType: "vote",
+			wantInfo: map[string]interface{}{
+				"voteAccount":      vote.ToBase58(),
+				"slotHashesSysvar": common.SysVarSlotHashesPubkey.ToBase58(),
+				"clockSysvar":      common.SysVarClockPubkey.ToBase58(),
+				"voteAuthority":    auth.ToBase58(),
+				"vote": map[string]interface{}{
+					"slots":     []uint64{1, 2},
+					"hash":      other.ToBase58(),
+					"timestamp": timestamp,
+				},
+			},
+		},
+		{
+			ins:      Withdraw(vote, auth, other, 100),
+			wantType: "withdraw",
+			wantInfo: map[string]interface{}{
+				"voteAccount":       vote.ToBase58(),
+				"destination":       other.ToBase58(),
+				"withdrawAuthority": auth.ToBase58(),
+				"lamports":          uint64(100),
+			},
+		},
+		{
+			ins:      UpdateValidatorIdentity(vote, auth, other),
+			wantType: "updateValidatorIdentity",
+			wantInfo: map[string]interface{}{
+				"voteAccount":          vote.ToBase58(),
+				"newValidatorIdentity": other.ToBase58(),
+				"withdrawAuthority":    auth.ToBase58(),
+			},
+		},
+		{
+			ins:      UpdateCommission(vote, auth, 5),
+			wantType: "updateCommission",
+			wantInfo: map[string]interface{}{
+				"voteAccount":       vote.ToBase58(),
+				"withdrawAuthority": auth.ToBase58(),
+				"commission":        uint8(5),
+			},
+		},
+		{
+			ins:      VoteSwitch(vote, auth, VoteParam{Slots: []uint64{3}, Hash: hash}, [32]byte(auth)),
+			wantType: "voteSwitch",
+			wantInfo: map[string]interface{}{
+				"voteAccount":      vote.ToBase58(),
+				"slotHashesSysvar": common.SysVarSlotHashesPubkey.ToBase58(),
+				"clockSysvar":      common.SysVarClockPubkey.ToBase58(),
+				"voteAuthority":    auth.ToBase58(),
+				"vote": map[string]interface{}{
+					"slots":     []uint64{3},
+					"hash":      other.ToBase58(),
+					"timestamp": nil,
+				},
+				"hash": auth.ToBase58(),
+			},
+		},
+		{
+			ins:      AuthorizeChecked(vote, auth, other, VoteAuthorizationTypeVoter),
+			wantType: "authorizeChecked",
+			wantInfo: map[string]interface{}{
+				"voteAccount":   vote.ToBase58(),
+				"clockSysvar":   common.SysVarClockPubkey.ToBase58(),
+				"authority":     auth.ToBase58(),
+				"newAuthority":  other.ToBase58(),
+				"authorityType": "Voter",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantType, func(t *testing.T) {
+			got, err := ParseVote(tt.ins)
+			if err != nil {
+				t.Fatalf("ParseVote() error = %v", err)
+			}
+			if got.Parsed.InstructionType != tt.wantType {
+				t.Errorf("ParseVote() type = %v, want %v", got.Parsed.InstructionType, tt.wantType)
+			}
+			if !reflect.DeepEqual(got.Parsed.Info, tt.wantInfo) {
+				t.Errorf("ParseVote() info = %v, want %v", got.Parsed.Info, tt.wantInfo)
+			}
+		})
+	}
+}
+
+func TestParseVoteError(t *testing.T) {
+	if _, err := ParseVote(types.Instruction{ProgramID: common.VoteProgramID, Data: []byte{99, 0, 0, 0}}); err == nil {
+		t.Errorf("ParseVote() should fail on unknown instruction")
+	}
+	ins := Vote(common.VoteProgramID, common.VoteProgramID, VoteParam{Slots: []uint64{1}, Hash: [32]byte(common.VoteProgramID)})
+	ins.Data = ins.Data[:20]
+	if _, err := ParseVote(ins); err == nil {
+		t.Errorf("ParseVote() should fail on short vote data")
+	}
+}
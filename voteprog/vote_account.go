@@ -0,0 +1,210 @@
+package voteprog
+
+import (
+	"fmt"
+
+	"github.com/portto/solana-go-sdk/borsh"
+	"github.com/portto/solana-go-sdk/common"
+)
+
+type VoteStateVersion uint32
+
+const (
+	VoteStateVersionV0_23_5 VoteStateVersion = iota
+	VoteStateVersionV1_14_11
+	VoteStateVersionCurrent
+)
+
+// priorVotersSize is the capacity of the prior voters circular buffer
+const priorVotersSize = 32
+
+type Lockout struct {
+	Slot              uint64
+	ConfirmationCount uint32
+}
+
+// LandedVote is a vote with its latency, which is 0 before VoteStateVersionCurrent
+type LandedVote struct {
+	Latency uint8
+	Lockout Lockout
+}
+
+type AuthorizedVoter struct {
+	Epoch  uint64
+	Pubkey common.PublicKey
+}
+
+type PriorVoter struct {
+	Pubkey     common.PublicKey
+	EpochStart uint64
+	EpochEnd   uint64
+}
+
+type EpochCredits struct {
+	Epoch       uint64
+	Credits     uint64
+	PrevCredits uint64
+}
+
+type BlockTimestamp struct {
+	Slot      uint64
+	Timestamp int64
+}
+
+type VoteAccount struct {
+	Version              VoteStateVersion
+	NodePubkey           common.PublicKey
+	AuthorizedWithdrawer common.PublicKey
+	Commission           uint8
+	Votes                []LandedVote
+	// RootSlot is nil until the vote account has a root
+	RootSlot         *uint64
+	AuthorizedVoters []AuthorizedVoter
+	// PriorVoters are ordered from the oldest to the latest
+	PriorVoters   []PriorVoter
+	EpochCredits  []EpochCredits
+	LastTimestamp BlockTimestamp
+}
+
+// voteStateV0_23_5 is the bincode layout of VoteStateVersionV0_23_5, which has a single authorized voter
+// and prior voters which also record the slot they were replaced in
+type voteStateV0_23_5 struct {
+	NodePubkey           common.PublicKey
+	AuthorizedVoter      common.PublicKey
+	AuthorizedVoterEpoch uint64
+	PriorVoters          [priorVotersSize]struct {
+		PriorVoter
+		ReplacedSlot uint64
+	}
+	PriorVotersIdx       uint64
+	AuthorizedWithdrawer common.PublicKey
+	Commission           uint8
+	Votes                []Lockout `borsh:"u64len"`
+	RootSlot             *uint64
+	EpochCredits         []EpochCredits `borsh:"u64len"`
+	LastTimestamp        BlockTimestamp
+}
+
+// voteStateV1_14_11 is the bincode layout of VoteStateVersionV1_14_11
+type voteStateV1_14_11 struct {
+	NodePubkey           common.PublicKey
+	AuthorizedWithdrawer common.PublicKey
+	Commission           uint8
+	Votes                []Lockout `borsh:"u64len"`
+	RootSlot             *uint64
+	AuthorizedVoters     []AuthorizedVoter `borsh:"u64len"`
+	PriorVoters          [priorVotersSize]PriorVoter
+	PriorVotersIdx       uint64
+	PriorVotersIsEmpty   bool
+	EpochCredits         []EpochCredits `borsh:"u64len"`
+	LastTimestamp        BlockTimestamp
+}
+
+// voteStateCurrent is the bincode layout of VoteStateVersionCurrent, the votes have their latency
+type voteStateCurrent struct {
+	NodePubkey           common.PublicKey
+	AuthorizedWithdrawer common.PublicKey
+	Commission           uint8
+	Votes                []LandedVote `borsh:"u64len"`
+	RootSlot             *uint64
+	AuthorizedVoters     []AuthorizedVoter `borsh:"u64len"`
+	PriorVoters          [priorVotersSize]PriorVoter
+	PriorVotersIdx       uint64
+	PriorVotersIsEmpty   bool
+	EpochCredits         []EpochCredits `borsh:"u64len"`
+	LastTimestamp        BlockTimestamp
+}
+
+func VoteAccountDeserialize(data []byte) (VoteAccount, error) {
+	var version VoteStateVersion
+	n, err := borsh.UnmarshalPrefix(data, &version)
+	if err != nil {
+		return VoteAccount{}, err
+	}
+	data = data[n:]
+
+	switch version {
+	case VoteStateVersionV0_23_5:
+		var state voteStateV0_23_5
+		if err := borsh.Unmarshal(data, &state); err != nil {
+			return VoteAccount{}, err
+		}
+		priorVoters := make([]PriorVoter, 0, priorVotersSize)
+		for _, priorVoter := range state.PriorVoters {
+			priorVoters = append(priorVoters, priorVoter.PriorVoter)
+		}
+		return VoteAccount{
+			Version:              version,
+			NodePubkey:           state.NodePubkey,
+			AuthorizedWithdrawer: state.AuthorizedWithdrawer,
+			Commission:           state.Commission,
+			Votes:                landedVotes(state.Votes),
+			RootSlot:             state.RootSlot,
+			AuthorizedVoters:     []AuthorizedVoter{{Epoch: state.AuthorizedVoterEpoch, Pubkey: state.AuthorizedVoter}},
+			PriorVoters:          orderPriorVoters(priorVoters, state.PriorVotersIdx, false),
+			EpochCredits:         state.EpochCredits,
+			LastTimestamp:        state.LastTimestamp,
+		}, nil
+	case VoteStateVersionV1_14_11:
+		var state voteStateV1_14_11
+		if err := borsh.Unmarshal(data, &state); err != nil {
+			return VoteAccount{}, err
+		}
+		return VoteAccount{
+			Version:              version,
+			NodePubkey:           state.NodePubkey,
+			AuthorizedWithdrawer: state.AuthorizedWithdrawer,
+			Commission:           state.Commission,
+			Votes:                landedVotes(state.Votes),
+			RootSlot:             state.RootSlot,
+			AuthorizedVoters:     state.AuthorizedVoters,
+			PriorVoters:          orderPriorVoters(state.PriorVoters[:], state.PriorVotersIdx, state.PriorVotersIsEmpty),
+			EpochCredits:         state.EpochCredits,
+			LastTimestamp:        state.LastTimestamp,
+		}, nil
+	case VoteStateVersionCurrent:
+		var state voteStateCurrent
+		if err := borsh.Unmarshal(data, &state); err != nil {
+			return VoteAccount{}, err
+		}
+		return VoteAccount{
+			Version:              version,
+			NodePubkey:           state.NodePubkey,
+			AuthorizedWithdrawer: state.AuthorizedWithdrawer,
+			Commission:           state.Commission,
+			Votes:                state.Votes,
+			RootSlot:             state.RootSlot,
+			AuthorizedVoters:     state.AuthorizedVoters,
+			PriorVoters:          orderPriorVoters(state.PriorVoters[:], state.PriorVotersIdx, state.PriorVotersIsEmpty),
+			EpochCredits:         state.EpochCredits,
+			LastTimestamp:        state.LastTimestamp,
+		}, nil
+	}
+	return VoteAccount{}, fmt.Errorf("unknown vote state version %d", version)
+}
+
+// landedVotes gives the votes of the old versions a zero latency
+func landedVotes(lockouts []Lockout) []LandedVote {
+	votes := make([]LandedVote, 0, len(lockouts))
+	for _, lockout := range lockouts {
+		votes = append(votes, LandedVote{Lockout: lockout})
+	}
+	return votes
+}
+
+// orderPriorVoters unrolls the circular buffer, idx points at the latest entry
+func orderPriorVoters(buf []PriorVoter, idx uint64, isEmpty bool) []PriorVoter {
+	if isEmpty || idx >= uint64(len(buf)) {
+		return []PriorVoter{}
+	}
+	voters := []PriorVoter{}
+	for i := 1; i <= len(buf); i++ {
+		voter := buf[(int(idx)+i)%len(buf)]
+		// unused entries of a buffer which hasn't wrapped yet
+		if voter == (PriorVoter{}) {
+			continue
+		}
+		voters = append(voters, voter)
+	}
+	return voters
+}
@@ -0,0 +1,174 @@
+package voteprog
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/portto/solana-go-sdk/common"
+)
+
+func TestVoteAccountDeserialize(t *testing.T) {
+	node := common.PublicKeyFromString("BkXBQ9ThbQffhmG39c2TbXW94pEmVGJAvxWk6hfxRvUJ")
+	withdrawer := common.PublicKeyFromString("EvN4kgKmCmYzdbd5kL8Q8YgkUW5RoqMTpBczrfLExtx7")
+	voter := common.PublicKeyFromString("FtvD2ymcAFh59DGGmJkANyJzEpLDR1GLgqDrUxfe2dPm")
+	u64 := func(v uint64) []byte {
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, v)
+		return b
+	}
+	u32 := func(v uint32) []byte {
+		b := make([]byte, 4)
+		binary.LittleEndian.PutUint32(b, v)
+		return b
+	}
+	// prior voters with a single entry at index 0
+	priorVoters := func(withSlot bool) []byte {
+		var b []byte
+		for i := 0; i < priorVotersSize; i++ {
+			if i == 0 {
+				b = append(b, node.Bytes()...)
+				b = append(b, u64(1)...)
+				b = append(b, u64(2)...)
+			} else {
+				b = append(b, make([]byte, 48)...)
+			}
+			if withSlot {
+				b = append(b, u64(0)...)
+			}
+		}
+		return append(b, u64(0)...)
+	}
+	tail := append(u64(1), u64(3)...)
+	tail = append(tail, u64(100)...)
+	tail = append(tail, u64(50)...)
+	tail = append(tail, u64(42)...)
+	tail = append(tail, u64(1600000000)...)
+
+	current := u32(2)
+	current = append(current, node.Bytes()...)
+	current = append(current, withdrawer.Bytes()...)
+	current = append(current, 7)
+	current = append(current, u64(1)...)
+	current = append(current, 1)
+	current = append(current, u64(41)...)
+	current = append(current, u32(31)...)
+	current = append(current, 1)
+	current = append(current, u64(10)...)
+	current = append(current, u64(1)...)
+	current = append(current, u64(3)...)
+	current = append(current, voter.Bytes()...)
+	current = append(current, priorVoters(false)...)
+	current = append(current, 0)
+	current = append(current, tail...)
+
+	v1_14_11 := u32(1)
+	v1_14_11 = append(v1_14_11, node.Bytes()...)
+	v1_14_11 = append(v1_14_11, withdrawer.Bytes()...)
+	v1_14_11 = append(v1_14_11, 7)
+	v1_14_11 = append(v1_14_11, u64(1)...)
+	v1_14_11 = append(v1_14_11, u64(41)...)
+	v1_14_11 = append(v1_14_11, u32(31)...)
+	v1_14_11 = append(v1_14_11, 0)
+	v1_14_11 = append(v1_14_11, u64(1)...)
+	v1_14_11 = append(v1_14_11, u64(3)...)
+	v1_14_11 = append(v1_14_11, voter.Bytes()...)
+	v1_14_11 = append(v1_14_11, priorVoters(false)...)
+	v1_14_11 = append(v1_14_11, 1)
+	v1_14_11 = append(v1_14_11, tail...)
+
+	v0_23_5 := u32(0)
+	v0_23_5 = append(v0_23_5, node.Bytes()...)
+	v0_23_5 = append(v0_23_5, voter.Bytes()...)
+	v0_23_5 = append(v0_23_5, u64(3)...)
+	v0_23_5 = append(v0_23_5, priorVoters(true)...)
+	v0_23_5 = append(v0_23_5, withdrawer.Bytes()...)
+	v0_23_5 = append(v0_23_5, 7)
+	v0_23_5 = append(v0_23_5, u64(0)...)
+	v0_23_5 = append(v0_23_5, 0)
+	v0_23_5 = append(v0_23_5, tail...)
+
+	rootSlot := uint64(10)
+	authorizedVoters := []AuthorizedVoter{{Epoch: 3, Pubkey: voter}}
+	epochCredits := []EpochCredits{{Epoch: 3, Credits: 100, PrevCredits: 50}}
+	lastTimestamp := BlockTimestamp{Slot: 42, Timestamp: 1600000000}
+
+	type args struct {
+		data []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    VoteAccount
+		wantErr bool
+	}{
+		{
+			name: "current",
+			args: args{data: current},
+			want: VoteAccount{
+				Version:              VoteStateVersionCurrent,
+				NodePubkey:           node,
+				AuthorizedWithdrawer: withdrawer,
+				Commission:           7,
+				Votes:                []LandedVote{{Latency: 1, Lockout: Lockout{Slot: 41, ConfirmationCount: 31}}},
+				RootSlot:             &rootSlot,
+				AuthorizedVoters:     authorizedVoters,
+				PriorVoters:          []PriorVoter{{Pubkey: node, EpochStart: 1, EpochEnd: 2}},
+				EpochCredits:         epochCredits,
+				LastTimestamp:        lastTimestamp,
+			},
+		},
+		{
+			name: "v1.14.11 with empty prior voters",
+			args: args{data: v1_14_11},
+			want: VoteAccount{
+				Version:              VoteStateVersionV1_14_11,
+				NodePubkey:           node,
+				AuthorizedWithdrawer: withdrawer,
+				Commission:           7,
+				Votes:                []LandedVote{{Lockout: Lockout{Slot: 41, ConfirmationCount: 31}}},
+				AuthorizedVoters:     authorizedVoters,
+				PriorVoters:          []PriorVoter{},
+				EpochCredits:         epochCredits,
+				LastTimestamp:        lastTimestamp,
+			},
+		},
+		{
+			name: "v0.23.5",
+			args: args{data: v0_23_5},
+			want: VoteAccount{
+				Version:              VoteStateVersionV0_23_5,
+				NodePubkey:           node,
+				AuthorizedWithdrawer: withdrawer,
+				Commission:           7,
+				Votes:                []LandedVote{},
+				AuthorizedVoters:     authorizedVoters,
+				PriorVoters:          []PriorVoter{{Pubkey: node, EpochStart: 1, EpochEnd: 2}},
+				EpochCredits:         epochCredits,
+				LastTimestamp:        lastTimestamp,
+			},
+		},
+		{
+			name:    "unknown version",
+			args:    args{data: u32(3)},
+			wantErr: true,
+		},
+		{
+			name:    "data size is not enough",
+			args:    args{data: current[:len(current)-1]},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VoteAccountDeserialize(tt.args.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VoteAccountDeserialize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("VoteAccountDeserialize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
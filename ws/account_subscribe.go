@@ -0,0 +1,50 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/portto/solana-go-sdk/client"
+)
+
+type AccountSubscribeConfig struct {
+	Encoding   client.Encoding   `json:"encoding,omitempty"`
+	Commitment client.Commitment `json:"commitment,omitempty"`
+}
+
+type AccountNotification struct {
+	Context client.Context                `json:"context"`
+	Value   client.GetAccountInfoResponse `json:"value"`
+}
+
+type AccountSubscription struct {
+	*Subscription
+	C <-chan AccountNotification
+}
+
+// AccountSubscribe notifies when the lamports or data of the account change
+func (c *Client) AccountSubscribe(ctx context.Context, base58Addr string, cfg AccountSubscribeConfig) (*AccountSubscription, error) {
+	ch := make(chan AccountNotification)
+	sub := c.newSubscription(
+		"accountSubscribe",
+		"accountUnsubscribe",
+		[]interface{}{base58Addr, cfg},
+		false,
+		func(raw json.RawMessage, done <-chan struct{}) error {
+			var n AccountNotification
+			if err := json.Unmarshal(raw, &n); err != nil {
+				return err
+			}
+			select {
+			case ch <- n:
+			case <-done:
+			}
+			return nil
+		},
+		func() { close(ch) },
+	)
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &AccountSubscription{Subscription: sub, C: ch}, nil
+}
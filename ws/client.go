@@ -0,0 +1,420 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/portto/solana-go-sdk/client"
+)
+
+const (
+	DevnetWSEndpoint  = "wss://api.devnet.solana.com"
+	TestnetWSEndpoint = "wss://api.testnet.solana.com"
+	MainnetWSEndpoint = "wss://api.mainnet-beta.solana.com"
+)
+
+var ErrClosed = errors.New("websocket client is closed")
+
+const (
+	pingInterval      = 30 * time.Second
+	writeTimeout      = 10 * time.Second
+	minReconnectDelay = 100 * time.Millisecond
+	maxReconnectDelay = 10 * time.Second
+)
+
+// Option configures a Client, pass them to Connect
+type Option func(*Client)
+
+// WithHeader adds a header to the websocket handshake request
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.header.Add(key, value)
+	}
+}
+
+// WithDialer uses the given dialer to connect
+func WithDialer(dialer *websocket.Dialer) Option {
+	return func(c *Client) {
+		c.dialer = dialer
+	}
+}
+
+// Client is a pubsub client. When the connection drops it reconnects
+// and resubscribes all active subscriptions automatically.
+type Client struct {
+	endpoint string
+	dialer   *websocket.Dialer
+	header   http.Header
+
+	writeMu sync.Mutex
+
+	mu             sync.Mutex
+	conn           *websocket.Conn
+	nextID         uint64
+	pending        map[uint64]*pendingCall
+	subs           map[*Subscription]struct{}
+	subsByServerID map[uint64]*Subscription
+	closed         bool
+	closeCh        chan struct{}
+}
+
+type pendingCall struct {
+	// sub is set when the call is a subscribe request
+	sub    *Subscription
+	result chan callResult
+}
+
+type callResult struct {
+	result json.RawMessage
+	err    error
+}
+
+type message struct {
+	ID     *uint64          `json:"id"`
+	Result json.RawMessage  `json:"result"`
+	Error  *client.RPCError `json:"error"`
+	Method string           `json:"method"`
+	Params struct {
+		Result       json.RawMessage `json:"result"`
+		Subscription uint64          `json:"subscription"`
+	} `json:"params"`
+}
+
+// Connect dials the websocket endpoint
+func Connect(ctx context.Context, endpoint string, opts ...Option) (*Client, error) {
+	c := &Client{
+		endpoint:       endpoint,
+		dialer:         websocket.DefaultDialer,
+		header:         http.Header{},
+		pending:        map[uint64]*pendingCall{},
+		subs:           map[*Subscription]struct{}{},
+		subsByServerID: map[uint64]*Subscription{},
+		closeCh:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	conn, _, err := c.dialer.DialContext(ctx, c.endpoint, c.header)
+	if err != nil {
+		return nil, err
+	}
+	c.conn = conn
+
+	go c.readLoop(conn)
+	go c.pingLoop()
+	return c, nil
+}
+
+// Close closes the connection and all subscriptions
+func (c *Client) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	close(c.closeCh)
+	conn := c.conn
+	subs := c.failPendingLocked(ErrClosed)
+	for sub := range c.subs {
+		subs = append(subs, sub)
+	}
+	c.subs = map[*Subscription]struct{}{}
+	c.subsByServerID = map[uint64]*Subscription{}
+	c.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.stop(ErrClosed)
+	}
+	return conn.Close()
+}
+
+// call sends a request and waits for its response
+func (c *Client) call(ctx context.Context, method string, params []interface{}, sub *Subscription) (json.RawMessage, error) {
+	p := &pendingCall{sub: sub, result: make(chan callResult, 1)}
+
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil, ErrClosed
+	}
+	id := c.nextID
+	c.nextID++
+	c.pending[id] = p
+	conn := c.conn
+	c.mu.Unlock()
+
+	if err := c.write(conn, id, method, params); err != nil {
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+		return nil, err
+	}
+
+	select {
+	case r := <-p.result:
+		return r.result, r.err
+	case <-ctx.Done():
+		// a subscribe call keeps its pending entry so that handle can unsubscribe it if the node accepts it later
+		if sub == nil {
+			c.mu.Lock()
+			delete(c.pending, id)
+			c.mu.Unlock()
+		}
+		return nil, ctx.Err()
+	}
+}
+
+func (c *Client) write(conn *websocket.Conn, id uint64, method string, params []interface{}) error {
+	j, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	return conn.WriteMessage(websocket.TextMessage, j)
+}
+
+// subscribe sends the subscribe request of sub and registers it once the node accepts it
+func (c *Client) subscribe(ctx context.Context, sub *Subscription) error {
+	_, err := c.call(ctx, sub.method, sub.params, sub)
+	if err != nil {
+		sub.stop(err)
+		// the node may have accepted it between the call giving up and the stop
+		c.mu.Lock()
+		if _, ok := c.subs[sub]; ok {
+			delete(c.subs, sub)
+			if c.subsByServerID[sub.serverID] == sub {
+				delete(c.subsByServerID, sub.serverID)
+			}
+			c.unsubscribeLocked(sub.unsubscribeMethod, sub.serverID)
+		}
+		c.mu.Unlock()
+		return err
+	}
+	sub.mu.Lock()
+	sub.running = true
+	sub.mu.Unlock()
+	go sub.run()
+	return nil
+}
+
+// unsubscribe removes sub and tells the node to stop sending its notifications
+func (c *Client) unsubscribe(ctx context.Context, sub *Subscription) error {
+	c.mu.Lock()
+	_, active := c.subs[sub]
+	delete(c.subs, sub)
+	serverID, subscribed := sub.serverID, sub.subscribed
+	if subscribed && c.subsByServerID[serverID] == sub {
+		delete(c.subsByServerID, serverID)
+	}
+	c.mu.Unlock()
+
+	sub.stop(nil)
+	if !active || !subscribed {
+		return nil
+	}
+	_, err := c.call(ctx, sub.unsubscribeMethod, []interface{}{serverID}, nil)
+	return err
+}
+
+// unsubscribeLocked sends an unsubscribe request without waiting for its response, c.mu must be held
+func (c *Client) unsubscribeLocked(method string, serverID uint64) {
+	id := c.nextID
+	c.nextID++
+	conn := c.conn
+	go c.write(conn, id, method, []interface{}{serverID})
+}
+
+func (c *Client) readLoop(conn *websocket.Conn) {
+	for {
+		_, b, err := conn.ReadMessage()
+		if err != nil {
+			c.mu.Lock()
+			closed := c.closed
+			c.failPendingLocked(fmt.Errorf("connection lost: %v", err))
+			c.mu.Unlock()
+			if closed {
+				return
+			}
+			if conn = c.reconnect(); conn == nil {
+				return
+			}
+			continue
+		}
+		c.handle(b)
+	}
+}
+
+func (c *Client) handle(b []byte) {
+	var msg message
+	if err := json.Unmarshal(b, &msg); err != nil {
+		return
+	}
+
+	// notification
+	if msg.ID == nil {
+		c.mu.Lock()
+		sub, ok := c.subsByServerID[msg.Params.Subscription]
+		if ok && sub.oneshot {
+			// the node removes the subscription after the first notification
+			delete(c.subsByServerID, msg.Params.Subscription)
+			delete(c.subs, sub)
+		}
+		c.mu.Unlock()
+		if ok {
+			sub.push(msg.Params.Result, sub.oneshot)
+		}
+		return
+	}
+
+	// response
+	c.mu.Lock()
+	p, ok := c.pending[*msg.ID]
+	delete(c.pending, *msg.ID)
+	if ok && p.sub != nil && msg.Error == nil {
+		var serverID uint64
+		if err := json.Unmarshal(msg.Result, &serverID); err != nil {
+			c.mu.Unlock()
+			p.result <- callResult{err: fmt.Errorf("failed to parse subscription id: %v", err)}
+			return
+		}
+		if p.sub.isStopped() {
+			// the subscription was stopped before the node accepted it
+			c.unsubscribeLocked(p.sub.unsubscribeMethod, serverID)
+			c.mu.Unlock()
+			p.result <- callResult{result: msg.Result}
+			return
+		}
+		p.sub.serverID = serverID
+		p.sub.subscribed = true
+		c.subs[p.sub] = struct{}{}
+		c.subsByServerID[serverID] = p.sub
+	}
+	c.mu.Unlock()
+	if !ok {
+		return
+	}
+	if msg.Error != nil {
+		p.result <- callResult{err: msg.Error}
+		return
+	}
+	p.result <- callResult{result: msg.Result}
+}
+
+// reconnect dials until it succeeds or the client is closed, then resubscribes all subscriptions
+func (c *Client) reconnect() *websocket.Conn {
+	delay := minReconnectDelay
+	for {
+		select {
+		case <-c.closeCh:
+			return nil
+		case <-time.After(delay):
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), maxReconnectDelay)
+		conn, _, err := c.dialer.DialContext(ctx, c.endpoint, c.header)
+		cancel()
+		if err != nil {
+			if delay *= 2; delay > maxReconnectDelay {
+				delay = maxReconnectDelay
+			}
+			continue
+		}
+
+		c.mu.Lock()
+		if c.closed {
+			c.mu.Unlock()
+			conn.Close()
+			return nil
+		}
+		c.conn = conn
+		subs := make([]*Subscription, 0, len(c.subs))
+		for sub := range c.subs {
+			sub.subscribed = false
+			subs = append(subs, sub)
+		}
+		c.subs = map[*Subscription]struct{}{}
+		c.subsByServerID = map[uint64]*Subscription{}
+		c.mu.Unlock()
+
+		for _, sub := range subs {
+			c.resubscribe(conn, sub)
+		}
+		return conn
+	}
+}
+
+// resubscribe sends the subscribe request without waiting, the read loop handles the response
+func (c *Client) resubscribe(conn *websocket.Conn, sub *Subscription) {
+	p := &pendingCall{sub: sub, result: make(chan callResult, 1)}
+	c.mu.Lock()
+	id := c.nextID
+	c.nextID++
+	c.pending[id] = p
+	c.mu.Unlock()
+
+	if err := c.write(conn, id, sub.method, sub.params); err != nil {
+		// the read loop will notice the broken connection and try again
+		return
+	}
+	go func() {
+		r := <-p.result
+		if r.err != nil && r.err != errResubscribeAborted {
+			sub.stop(fmt.Errorf("failed to resubscribe: %v", r.err))
+		}
+	}()
+}
+
+// errResubscribeAborted means the connection was lost before the node answered a resubscription
+var errResubscribeAborted = errors.New("resubscribe aborted")
+
+// failPendingLocked fails all pending calls. Subscriptions waiting for a resubscription are kept
+// for the next reconnect unless the client is closed, they are returned in that case.
+func (c *Client) failPendingLocked(err error) []*Subscription {
+	var aborted []*Subscription
+	for id, p := range c.pending {
+		delete(c.pending, id)
+		if p.sub != nil && p.sub.isRunning() {
+			if c.closed {
+				aborted = append(aborted, p.sub)
+			} else {
+				c.subs[p.sub] = struct{}{}
+			}
+			p.result <- callResult{err: errResubscribeAborted}
+			continue
+		}
+		p.result <- callResult{err: err}
+	}
+	return aborted
+}
+
+func (c *Client) pingLoop() {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.closeCh:
+			return
+		case <-ticker.C:
+			c.mu.Lock()
+			conn := c.conn
+			c.mu.Unlock()
+			conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout))
+		}
+	}
+}
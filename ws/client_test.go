@@ -0,0 +1,278 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/portto/solana-go-sdk/client"
+)
+
+type fakeRequest struct {
+	Method string
+	SubID  uint64
+	conn   *fakeConn
+}
+
+type fakeConn struct {
+	mu   sync.Mutex
+	conn *websocket.Conn
+}
+
+func (c *fakeConn) send(v interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn.WriteJSON(v)
+}
+
+func (c *fakeConn) notify(method string, subID uint64, result string) {
+	c.send(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params": map[string]interface{}{
+			"result":       json.RawMessage(result),
+			"subscription": subID,
+		},
+	})
+}
+
+// fakeServer accepts every subscribe request except logsSubscribe
+type fakeServer struct {
+	*httptest.Server
+	requests chan fakeRequest
+	// hold delays the subscribe responses until it is closed, if set
+	hold chan struct{}
+
+	mu        sync.Mutex
+	nextSubID uint64
+}
+
+func newFakeServer() *fakeServer {
+	s := &fakeServer{requests: make(chan fakeRequest, 100)}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		fc := &fakeConn{conn: conn}
+		defer conn.Close()
+		for {
+			var req struct {
+				ID     uint64            `json:"id"`
+				Method string            `json:"method"`
+				Params []json.RawMessage `json:"params"`
+			}
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			switch {
+			case req.Method == "logsSubscribe":
+				fc.send(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "error": map[string]interface{}{"code": -32602, "message": "Invalid params"}})
+			case strings.HasSuffix(req.Method, "Unsubscribe"):
+				var subID uint64
+				if len(req.Params) > 0 {
+					json.Unmarshal(req.Params[0], &subID)
+				}
+				fc.send(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": true})
+				s.requests <- fakeRequest{Method: req.Method, SubID: subID, conn: fc}
+			default:
+				s.mu.Lock()
+				subID := s.nextSubID
+				s.nextSubID++
+				s.mu.Unlock()
+				if s.hold != nil {
+					s.requests <- fakeRequest{Method: req.Method, SubID: subID, conn: fc}
+					<-s.hold
+					fc.send(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": subID})
+					continue
+				}
+				fc.send(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": subID})
+				s.requests <- fakeRequest{Method: req.Method, SubID: subID, conn: fc}
+			}
+		}
+	}))
+	return s
+}
+
+func (s *fakeServer) url() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+func (s *fakeServer) nextRequest(t *testing.T) fakeRequest {
+	select {
+	case req := <-s.requests:
+		return req
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no request received")
+	}
+	return fakeRequest{}
+}
+
+func TestAccountSubscribe(t *testing.T) {
+	srv := newFakeServer()
+	defer srv.Close()
+	ctx := context.Background()
+
+	c, err := Connect(ctx, srv.url())
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer c.Close()
+
+	sub, err := c.AccountSubscribe(ctx, "RNfp4xTbBb4C3kcv2KqtAj8mu4YhMHxqm1Skg9uchZ7", AccountSubscribeConfig{Encoding: client.EncodingBase64})
+	if err != nil {
+		t.Fatalf("AccountSubscribe() error = %v", err)
+	}
+	req := srv.nextRequest(t)
+	if req.Method != "accountSubscribe" {
+		t.Fatalf("method = %v, want accountSubscribe", req.Method)
+	}
+
+	for i := uint64(1); i <= 3; i++ {
+		req.conn.notify("accountNotification", req.SubID, fmt.Sprintf(`{"context":{"slot":%d},"value":{"lamports":%d,"owner":"11111111111111111111111111111111","data":["","base64"],"rentEpoch":1}}`, i, i*10))
+	}
+	for i := uint64(1); i <= 3; i++ {
+		n := <-sub.C
+		if n.Context.Slot != i || n.Value.Lamports != i*10 {
+			t.Errorf("notification = %+v, want slot %d", n, i)
+		}
+	}
+
+	if err := sub.Unsubscribe(ctx); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+	if req := srv.nextRequest(t); req.Method != "accountUnsubscribe" {
+		t.Errorf("method = %v, want accountUnsubscribe", req.Method)
+	}
+	if _, ok := <-sub.C; ok {
+		t.Errorf("channel is not closed after Unsubscribe")
+	}
+}
+
+func TestSignatureSubscribe(t *testing.T) {
+	srv := newFakeServer()
+	defer srv.Close()
+	ctx := context.Background()
+
+	c, err := Connect(ctx, srv.url())
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer c.Close()
+
+	sub, err := c.SignatureSubscribe(ctx, "sig", SignatureSubscribeConfig{Commitment: client.CommitmentFinalized})
+	if err != nil {
+		t.Fatalf("SignatureSubscribe() error = %v", err)
+	}
+	req := srv.nextRequest(t)
+	req.conn.notify("signatureNotification", req.SubID, `{"context":{"slot":5},"value":{"err":{"InstructionError":[0,{"Custom":1}]}}}`)
+
+	n, ok := <-sub.C
+	if !ok || n.Value.Err == nil || n.Value.Err.Kind != client.TransactionErrorKindInstructionError {
+		t.Errorf("notification = %+v, %v", n, ok)
+	}
+	if _, ok := <-sub.C; ok {
+		t.Errorf("channel is not closed after the notification")
+	}
+	if sub.Err() != nil {
+		t.Errorf("Err() = %v, want nil", sub.Err())
+	}
+}
+
+func TestSubscribeError(t *testing.T) {
+	srv := newFakeServer()
+	defer srv.Close()
+	ctx := context.Background()
+
+	c, err := Connect(ctx, srv.url())
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer c.Close()
+
+	_, err = c.LogsSubscribe(ctx, LogsFilterMentions("11111111111111111111111111111111"), LogsSubscribeConfig{})
+	var rpcErr *client.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != client.RPCErrorCodeInvalidParams {
+		t.Errorf("LogsSubscribe() error = %v, want invalid params", err)
+	}
+}
+
+func TestSubscribeCanceled(t *testing.T) {
+	srv := newFakeServer()
+	srv.hold = make(chan struct{})
+	defer srv.Close()
+
+	c, err := Connect(context.Background(), srv.url())
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := c.SlotSubscribe(ctx)
+		errCh <- err
+	}()
+	req := srv.nextRequest(t)
+	cancel()
+	if err := <-errCh; !errors.Is(err, context.Canceled) {
+		t.Fatalf("SlotSubscribe() error = %v, want %v", err, context.Canceled)
+	}
+
+	// the node accepts the subscription after the call gave up, the client should unsubscribe it
+	close(srv.hold)
+	unsub := srv.nextRequest(t)
+	if unsub.Method != "slotUnsubscribe" || unsub.SubID != req.SubID {
+		t.Errorf("unsubscribe request = %+v, want slotUnsubscribe of %d", unsub, req.SubID)
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	srv := newFakeServer()
+	defer srv.Close()
+	ctx := context.Background()
+
+	c, err := Connect(ctx, srv.url())
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+
+	sub, err := c.SlotSubscribe(ctx)
+	if err != nil {
+		t.Fatalf("SlotSubscribe() error = %v", err)
+	}
+	req := srv.nextRequest(t)
+	req.conn.notify("slotNotification", req.SubID, `{"parent":1,"root":0,"slot":2}`)
+	if n := <-sub.C; n.Slot != 2 {
+		t.Errorf("notification = %+v, want slot 2", n)
+	}
+
+	// drop the connection, the client should resubscribe on a new one
+	req.conn.conn.Close()
+	req2 := srv.nextRequest(t)
+	if req2.Method != "slotSubscribe" || req2.SubID == req.SubID || req2.conn == req.conn {
+		t.Fatalf("resubscribe request = %+v", req2)
+	}
+	req2.conn.notify("slotNotification", req2.SubID, `{"parent":2,"root":1,"slot":3}`)
+	if n := <-sub.C; n.Slot != 3 {
+		t.Errorf("notification = %+v, want slot 3", n)
+	}
+
+	c.Close()
+	if _, ok := <-sub.C; ok {
+		t.Errorf("channel is not closed after Close")
+	}
+	if !errors.Is(sub.Err(), ErrClosed) {
+		t.Errorf("Err() = %v, want %v", sub.Err(), ErrClosed)
+	}
+}
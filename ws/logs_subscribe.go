@@ -0,0 +1,68 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/portto/solana-go-sdk/client"
+)
+
+// LogsFilter selects which txs are notified by LogsSubscribe
+type LogsFilter interface{}
+
+var (
+	// LogsFilterAll notifies all txs except simple vote txs
+	LogsFilterAll LogsFilter = "all"
+	// LogsFilterAllWithVotes notifies all txs
+	LogsFilterAllWithVotes LogsFilter = "allWithVotes"
+)
+
+// LogsFilterMentions notifies all txs which mention the address
+func LogsFilterMentions(base58Addr string) LogsFilter {
+	return map[string]interface{}{"mentions": []string{base58Addr}}
+}
+
+type LogsSubscribeConfig struct {
+	Commitment client.Commitment `json:"commitment,omitempty"`
+}
+
+type LogsNotification struct {
+	Context client.Context `json:"context"`
+	Value   struct {
+		Signature string                   `json:"signature"`
+		Err       *client.TransactionError `json:"err"`
+		Logs      []string                 `json:"logs"`
+	} `json:"value"`
+}
+
+type LogsSubscription struct {
+	*Subscription
+	C <-chan LogsNotification
+}
+
+// LogsSubscribe notifies the logs of txs selected by filter
+func (c *Client) LogsSubscribe(ctx context.Context, filter LogsFilter, cfg LogsSubscribeConfig) (*LogsSubscription, error) {
+	ch := make(chan LogsNotification)
+	sub := c.newSubscription(
+		"logsSubscribe",
+		"logsUnsubscribe",
+		[]interface{}{filter, cfg},
+		false,
+		func(raw json.RawMessage, done <-chan struct{}) error {
+			var n LogsNotification
+			if err := json.Unmarshal(raw, &n); err != nil {
+				return err
+			}
+			select {
+			case ch <- n:
+			case <-done:
+			}
+			return nil
+		},
+		func() { close(ch) },
+	)
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &LogsSubscription{Subscription: sub, C: ch}, nil
+}
@@ -0,0 +1,52 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/portto/solana-go-sdk/client"
+)
+
+type ProgramSubscribeConfig struct {
+	Encoding   client.Encoding   `json:"encoding,omitempty"`
+	Commitment client.Commitment `json:"commitment,omitempty"`
+	// filter should be either client.GetProgramAccountsConfigFilterMemCmp or client.GetProgramAccountsConfigFilterDataSize
+	Filters []interface{} `json:"filters,omitempty"`
+}
+
+type ProgramNotification struct {
+	Context client.Context            `json:"context"`
+	Value   client.GetProgramAccounts `json:"value"`
+}
+
+type ProgramSubscription struct {
+	*Subscription
+	C <-chan ProgramNotification
+}
+
+// ProgramSubscribe notifies when the lamports or data of an account owned by the program change
+func (c *Client) ProgramSubscribe(ctx context.Context, programID string, cfg ProgramSubscribeConfig) (*ProgramSubscription, error) {
+	ch := make(chan ProgramNotification)
+	sub := c.newSubscription(
+		"programSubscribe",
+		"programUnsubscribe",
+		[]interface{}{programID, cfg},
+		false,
+		func(raw json.RawMessage, done <-chan struct{}) error {
+			var n ProgramNotification
+			if err := json.Unmarshal(raw, &n); err != nil {
+				return err
+			}
+			select {
+			case ch <- n:
+			case <-done:
+			}
+			return nil
+		},
+		func() { close(ch) },
+	)
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &ProgramSubscription{Subscription: sub, C: ch}, nil
+}
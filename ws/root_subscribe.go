@@ -0,0 +1,39 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type RootSubscription struct {
+	*Subscription
+	// C receives the new root slot
+	C <-chan uint64
+}
+
+// RootSubscribe notifies when the node sets a new root
+func (c *Client) RootSubscribe(ctx context.Context) (*RootSubscription, error) {
+	ch := make(chan uint64)
+	sub := c.newSubscription(
+		"rootSubscribe",
+		"rootUnsubscribe",
+		[]interface{}{},
+		false,
+		func(raw json.RawMessage, done <-chan struct{}) error {
+			var n uint64
+			if err := json.Unmarshal(raw, &n); err != nil {
+				return err
+			}
+			select {
+			case ch <- n:
+			case <-done:
+			}
+			return nil
+		},
+		func() { close(ch) },
+	)
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &RootSubscription{Subscription: sub, C: ch}, nil
+}
@@ -0,0 +1,52 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/portto/solana-go-sdk/client"
+)
+
+type SignatureSubscribeConfig struct {
+	Commitment client.Commitment `json:"commitment,omitempty"`
+}
+
+type SignatureNotification struct {
+	Context client.Context `json:"context"`
+	Value   struct {
+		// Err is nil if the tx succeeded
+		Err *client.TransactionError `json:"err"`
+	} `json:"value"`
+}
+
+type SignatureSubscription struct {
+	*Subscription
+	C <-chan SignatureNotification
+}
+
+// SignatureSubscribe notifies once when the tx reaches the commitment, the channel is closed after that
+func (c *Client) SignatureSubscribe(ctx context.Context, signature string, cfg SignatureSubscribeConfig) (*SignatureSubscription, error) {
+	ch := make(chan SignatureNotification)
+	sub := c.newSubscription(
+		"signatureSubscribe",
+		"signatureUnsubscribe",
+		[]interface{}{signature, cfg},
+		true,
+		func(raw json.RawMessage, done <-chan struct{}) error {
+			var n SignatureNotification
+			if err := json.Unmarshal(raw, &n); err != nil {
+				return err
+			}
+			select {
+			case ch <- n:
+			case <-done:
+			}
+			return nil
+		},
+		func() { close(ch) },
+	)
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &SignatureSubscription{Subscription: sub, C: ch}, nil
+}
@@ -0,0 +1,44 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type SlotNotification struct {
+	Parent uint64 `json:"parent"`
+	Root   uint64 `json:"root"`
+	Slot   uint64 `json:"slot"`
+}
+
+type SlotSubscription struct {
+	*Subscription
+	C <-chan SlotNotification
+}
+
+// SlotSubscribe notifies when a slot is processed by the node
+func (c *Client) SlotSubscribe(ctx context.Context) (*SlotSubscription, error) {
+	ch := make(chan SlotNotification)
+	sub := c.newSubscription(
+		"slotSubscribe",
+		"slotUnsubscribe",
+		[]interface{}{},
+		false,
+		func(raw json.RawMessage, done <-chan struct{}) error {
+			var n SlotNotification
+			if err := json.Unmarshal(raw, &n); err != nil {
+				return err
+			}
+			select {
+			case ch <- n:
+			case <-done:
+			}
+			return nil
+		},
+		func() { close(ch) },
+	)
+	if err := c.subscribe(ctx, sub); err != nil {
+		return nil, err
+	}
+	return &SlotSubscription{Subscription: sub, C: ch}, nil
+}
@@ -0,0 +1,136 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// Subscription is the common part of all typed subscriptions.
+// Its notification channel is closed after Unsubscribe, Client.Close or an unrecoverable error.
+type Subscription struct {
+	client            *Client
+	method            string
+	unsubscribeMethod string
+	params            []interface{}
+	// oneshot subscriptions are removed by the node after the first notification
+	oneshot bool
+	// deliver decodes a notification and sends it to the typed channel, it gives up when done is closed
+	deliver func(raw json.RawMessage, done <-chan struct{}) error
+	closeC  func()
+
+	// guarded by client.mu
+	serverID   uint64
+	subscribed bool
+
+	mu      sync.Mutex
+	queue   []json.RawMessage
+	last    bool
+	running bool
+	stopped bool
+	err     error
+	signal  chan struct{}
+	done    chan struct{}
+}
+
+func (c *Client) newSubscription(
+	method, unsubscribeMethod string,
+	params []interface{},
+	oneshot bool,
+	deliver func(raw json.RawMessage, done <-chan struct{}) error,
+	closeC func(),
+) *Subscription {
+	return &Subscription{
+		client:            c,
+		method:            method,
+		unsubscribeMethod: unsubscribeMethod,
+		params:            params,
+		oneshot:           oneshot,
+		deliver:           deliver,
+		closeC:            closeC,
+		signal:            make(chan struct{}, 1),
+		done:              make(chan struct{}),
+	}
+}
+
+// Unsubscribe stops the subscription and closes its channel
+func (s *Subscription) Unsubscribe(ctx context.Context) error {
+	return s.client.unsubscribe(ctx, s)
+}
+
+// Err returns why the subscription stopped, nil if it is still active or was unsubscribed
+func (s *Subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// push queues a notification, last means no more notifications will come
+func (s *Subscription) push(raw json.RawMessage, last bool) {
+	s.mu.Lock()
+	s.queue = append(s.queue, raw)
+	s.last = s.last || last
+	s.mu.Unlock()
+
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Subscription) stop(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return
+	}
+	s.stopped = true
+	s.err = err
+	close(s.done)
+}
+
+func (s *Subscription) isStopped() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stopped
+}
+
+func (s *Subscription) isRunning() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running && !s.stopped
+}
+
+// run delivers queued notifications until the subscription stops,
+// so that a slow reader never blocks the connection
+func (s *Subscription) run() {
+	defer s.closeC()
+	for {
+		s.mu.Lock()
+		if s.stopped {
+			s.mu.Unlock()
+			return
+		}
+		if len(s.queue) > 0 {
+			raw := s.queue[0]
+			s.queue = s.queue[1:]
+			s.mu.Unlock()
+			if err := s.deliver(raw, s.done); err != nil {
+				s.stop(err)
+				return
+			}
+			continue
+		}
+		last := s.last
+		s.mu.Unlock()
+		if last {
+			s.stop(nil)
+			return
+		}
+
+		select {
+		case <-s.signal:
+		case <-s.done:
+		}
+	}
+}